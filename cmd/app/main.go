@@ -22,20 +22,34 @@ func main() {
 	}
 
 	// Run the application
+	runErr := make(chan error, 1)
 	go func() {
-		if err := app.Run(); err != nil {
-			app.Logger.Error("app run", zap.Error(err))
-		}
+		runErr <- app.Run()
 	}()
 
-	// graceful shutdown
+	// graceful shutdown, on a signal or when the app fails to run
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	<-sigs
 
-	app.Logger.Info("User service stops !")
+	failed := false
+	select {
+	case sig := <-sigs:
+		app.Logger.Info("Establishment service stops !", zap.String("signal", sig.String()))
+	case err := <-runErr:
+		if err != nil {
+			app.Logger.Error("app run", zap.Error(err))
+			failed = true
+		}
+	}
 
 	// app stops
-	app.Stop()
+	if err := app.Stop(); err != nil {
+		app.Logger.Error("app stop", zap.Error(err))
+		app.Logger.Sync()
+		failed = true
+	}
 
+	if failed {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"Booking/establishment-service-booking/internal/infrastructure/kafka"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/logger"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// dlq-replay sends messages of a dead letter topic back to the topics they
+// failed on, the kafka brokers come from the KAFKA_ADDRESS of the service
+//
+//	go run ./cmd/dlq-replay -topic user.service.dlq -limit 10
+func main() {
+	var (
+		deadLetterTopic = flag.String("topic", "", "dead letter topic to replay")
+		groupID         = flag.String("group", "establishment-service-dlq-replay", "consumer group of the replay")
+		to              = flag.String("to", "", "topic to replay to instead of the original topic")
+		limit           = flag.Int("limit", 0, "maximum number of messages to replay, 0 replays all")
+		idle            = flag.Duration("idle", 10*time.Second, "stop when no message arrives for this long")
+	)
+	flag.Parse()
+
+	if *deadLetterTopic == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config := config.New()
+
+	logger, err := logger.New(config.LogLevel, config.Environment, "dlq-replay.log")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logger.Sync()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	replayer := kafka.NewReplayer(config, logger, *deadLetterTopic, *groupID, *to)
+	replayed, err := replayer.Replay(ctx, *limit, *idle)
+	replayer.Close()
+
+	logger.Info("dead letters replayed", zap.String("topic", *deadLetterTopic), zap.Int("replayed", replayed))
+	if err != nil {
+		logger.Error("dead letter replay failed", zap.Error(err))
+		logger.Sync()
+		os.Exit(1)
+	}
+}
@@ -4,23 +4,39 @@ import (
 	pb "Booking/establishment-service-booking/genproto/establishment-proto"
 	grpc_server "Booking/establishment-service-booking/internal/delivery/grpc/server"
 	invest_grpc "Booking/establishment-service-booking/internal/delivery/grpc/services"
+	kafka_delivery "Booking/establishment-service-booking/internal/delivery/kafka"
+	"Booking/establishment-service-booking/internal/entity"
 	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
 	"Booking/establishment-service-booking/internal/infrastructure/kafka"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
 	repo "Booking/establishment-service-booking/internal/infrastructure/repository/postgresql"
+	pkg_app "Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/auth"
 	"Booking/establishment-service-booking/internal/pkg/config"
 	"Booking/establishment-service-booking/internal/pkg/logger"
+	"Booking/establishment-service-booking/internal/pkg/metrics"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"Booking/establishment-service-booking/internal/pkg/postgres"
 	"Booking/establishment-service-booking/internal/usecase"
 	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type App struct {
@@ -33,8 +49,39 @@ type App struct {
 	FavouriteUsecase  usecase.Favourite
 	Review            usecase.Review
 	Image             usecase.Image
+	Category          usecase.Category
+	Translation       usecase.Translation
+	Pricing           usecase.Pricing
+	Idempotency       usecase.Idempotency
+	Audit             usecase.Audit
+	Outbox            usecase.Outbox
+	Account           usecase.Account
+	Popularity        usecase.Popularity
+	BookingSummary    usecase.BookingSummary
 	ServiceClients    grpc_service_clients.ServiceClients
 	BrokerProducer    event.BrokerProducer
+	BrokerConsumer    event.BrokerConsumer
+	Readiness         *grpc_server.Readiness
+	ShutdownTracer    func(ctx context.Context) error
+	Metrics           *metrics.Metrics
+	Statistics        usecase.Statistics
+	metricsServer     *http.Server
+	healthInterval    time.Duration
+	shutdown          shutdownTimeouts
+	background        sync.WaitGroup
+	stopBackground    context.CancelFunc
+
+	// lifecycle orders start and Stop, the fields set by start are read
+	// by Stop once it holds the lock
+	lifecycle sync.Mutex
+	stopped   bool
+}
+
+// shutdownTimeouts bound the steps of Stop
+type shutdownTimeouts struct {
+	readinessDelay time.Duration
+	drainTimeout   time.Duration
+	timeout        time.Duration
 }
 
 func NewApp(cfg *config.Config) (*App, error) {
@@ -44,41 +91,131 @@ func NewApp(cfg *config.Config) (*App, error) {
 		return nil, err
 	}
 
-	kafkaProducer := kafka.NewProducer(cfg, logger)
+	// trace context travels in gRPC metadata and kafka headers
+	otlp.SetPropagator()
+
+	// spans are exported to the collector unless tracing is disabled
+	shutdownTracer, err := newTracer(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// collectors of RPCs, the pool, kafka and the business gauges
+	appMetrics := metrics.New()
+
+	kafkaProducer := kafka.NewProducer(cfg, logger, appMetrics)
+
+	kafkaConsumer, err := newConsumer(cfg, logger, appMetrics)
+	if err != nil {
+		return nil, err
+	}
 
 	// init db
 	db, err := postgres.New(cfg)
 	if err != nil {
 		return nil, err
 	}
+	appMetrics.RegisterPool(db.Pool)
+
+	// init authentication
+	authenticator, err := newAuthenticator(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_middleware.ChainUnaryServer(
+			otelgrpc.UnaryServerInterceptor(otelgrpc.WithInterceptorFilter(traceFilter)),
+			grpc_ctxtags.UnaryServerInterceptor(),
+			grpc_server.UnaryInterceptorTrace(),
+			appMetrics.RPC.UnaryServerInterceptor(),
+			grpc_zap.UnaryServerInterceptor(logger),
+			grpc_recovery.UnaryServerInterceptor(),
+		),
+		grpc_server.UnaryInterceptorError(logger),
+		grpc_server.UnaryInterceptorData(logger),
+	}
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors, grpc_server.UnaryInterceptorAuth(logger, authenticator, cfg.Auth.PublicMethods))
+	} else {
+		devCaller, err := newDevCaller(cfg)
+		if err != nil {
+			return nil, err
+		}
+		logger.Warn("no JWT keys configured, every request is made by the dev caller",
+			zap.String("user_id", devCaller.UserId), zap.String("role", devCaller.Role))
+		unaryInterceptors = append(unaryInterceptors, grpc_server.UnaryInterceptorDevCaller(devCaller))
+	}
+
+	// init idempotency of create requests
+	idempotency, err := newIdempotency(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+	unaryInterceptors = append(unaryInterceptors, grpc_server.UnaryInterceptorIdempotency(logger, idempotency, cfg.Idempotency.Methods))
 
 	// grpc server init
 	grpcServer := grpc.NewServer(
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			otelgrpc.StreamServerInterceptor(otelgrpc.WithInterceptorFilter(traceFilter)),
 			grpc_ctxtags.StreamServerInterceptor(),
+			grpc_server.StreamInterceptorTrace(),
+			appMetrics.RPC.StreamServerInterceptor(),
 			grpc_zap.StreamServerInterceptor(logger),
 			grpc_recovery.StreamServerInterceptor(),
 		)),
-		grpc.UnaryInterceptor(grpc_server.UnaryInterceptor(
-			grpc_middleware.ChainUnaryServer(
-				grpc_ctxtags.UnaryServerInterceptor(),
-				grpc_zap.UnaryServerInterceptor(logger),
-				grpc_recovery.UnaryServerInterceptor(),
-			),
-			grpc_server.UnaryInterceptorData(logger),
-		)),
+		grpc.UnaryInterceptor(grpc_server.UnaryInterceptor(unaryInterceptors...)),
 	)
 
+	// the service is not ready until its dependencies are checked
+	readiness, healthInterval, err := newReadiness(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdown, err := newShutdownTimeouts(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &App{
 		Config:         cfg,
 		Logger:         logger,
 		DB:             db,
 		GrpcServer:     grpcServer,
 		BrokerProducer: kafkaProducer,
+		BrokerConsumer: kafkaConsumer,
+		Idempotency:    idempotency,
+		Readiness:      readiness,
+		ShutdownTracer: shutdownTracer,
+		Metrics:        appMetrics,
+		healthInterval: healthInterval,
+		shutdown:       shutdown,
 	}, nil
 }
 
+// Run starts the app and serves RPCs until Stop
 func (a *App) Run() error {
+	if err := a.start(); err != nil {
+		return err
+	}
+
+	a.Logger.Info("gRPC Server Listening", zap.String("url", a.Config.RPCPort))
+	if err := grpc_server.Run(a.Config, a.GrpcServer); err != nil {
+		return fmt.Errorf("gRPC fatal to serve grpc server over %s %w", a.Config.RPCPort, err)
+	}
+	return nil
+}
+
+// start wires the services and starts the background workers. Stop waits
+// for it to finish, once Stop began nothing is started.
+func (a *App) start() error {
+	a.lifecycle.Lock()
+	defer a.lifecycle.Unlock()
+	if a.stopped {
+		return nil
+	}
+
 	var (
 		contextTimeout time.Duration
 	)
@@ -102,36 +239,468 @@ func (a *App) Run() error {
 	favouriteRepo := repo.NewFavouriteRepo(a.DB)
 	reviewRepo := repo.NewReviewRepo(a.DB)
 	imageRepo := repo.NewImageRepo(a.DB)
+	categoryRepo := repo.NewCategoryRepo(a.DB)
+	translationRepo := repo.NewTranslationRepo(a.DB)
+	priceRepo := repo.NewPriceRepo(a.DB)
+	currencyRateRepo := repo.NewCurrencyRateRepo(a.DB)
+	ownershipRepo := repo.NewOwnershipRepo(a.DB)
+	auditRepo := repo.NewAuditRepo(a.DB)
+	outboxRepo := repo.NewOutboxRepo(a.DB)
+	inboxRepo := repo.NewInboxRepo(a.DB)
+	bookingRepo := repo.NewBookingRepo(a.DB)
+	statisticsRepo := repo.NewStatisticsRepo(a.DB)
 
 	// usecase initialization
-	attracationUsecase := usecase.NewAttractionService(contextTimeout, attractionRepo)
-	restaurantUsecase := usecase.NewRestaurantService(contextTimeout, restaurantRepo)
-	hotelUsecase := usecase.NewHotelService(contextTimeout, hotelRepo)
-	favouriteUsecase := usecase.NewFavouriteService(contextTimeout, favouriteRepo)
-	reviewUsecase := usecase.NewReviewService(contextTimeout, reviewRepo)
-	imageUsecase := usecase.NewImageService(contextTimeout, imageRepo)
+	attracationUsecase := usecase.NewAttractionService(contextTimeout, attractionRepo, translationRepo, priceRepo, currencyRateRepo, auditRepo, outboxRepo, a.DB, serviceClients.UserService())
+	restaurantUsecase := usecase.NewRestaurantService(contextTimeout, restaurantRepo, translationRepo, priceRepo, currencyRateRepo, auditRepo, outboxRepo, a.DB, serviceClients.UserService())
+	hotelUsecase := usecase.NewHotelService(contextTimeout, hotelRepo, translationRepo, priceRepo, currencyRateRepo, auditRepo, outboxRepo, a.DB, serviceClients.UserService())
+	favouriteUsecase := usecase.NewFavouriteService(contextTimeout, favouriteRepo, auditRepo, outboxRepo, a.DB)
+	reviewUsecase := usecase.NewReviewService(contextTimeout, reviewRepo, auditRepo, outboxRepo, a.DB, serviceClients.UserService())
+	imageUsecase := usecase.NewImageService(contextTimeout, imageRepo, ownershipRepo, auditRepo, outboxRepo, a.DB)
+	a.Category = usecase.NewCategoryService(contextTimeout, categoryRepo)
+	a.Translation = usecase.NewTranslationService(contextTimeout, translationRepo, ownershipRepo)
+	a.Audit = usecase.NewAuditService(contextTimeout, auditRepo)
+	a.Pricing = usecase.NewPricingService(contextTimeout, priceRepo, currencyRateRepo, ownershipRepo)
+	a.Popularity = usecase.NewPopularityService(contextTimeout, bookingRepo, a.DB)
+	a.BookingSummary = usecase.NewBookingSummaryService(contextTimeout, ownershipRepo, serviceClients.BookingService())
+	a.Statistics = usecase.NewStatisticsService(contextTimeout, statisticsRepo)
+	a.Account = usecase.NewAccountService(contextTimeout, inboxRepo, ownershipRepo, hotelRepo, restaurantRepo, attractionRepo, reviewRepo, favouriteRepo, auditRepo, outboxRepo, a.DB)
+
+	// currency rates shipped with the deployment
+	if a.Config.CurrencyRates.File != "" {
+		if err := a.Pricing.LoadCurrencyRates(context.Background(), a.Config.CurrencyRates.File); err != nil {
+			return fmt.Errorf("error during loading currency rates: %w", err)
+		}
+	}
+
+	// events are sent from the outbox in the background
+	outbox, relayInterval, err := newOutbox(a.Config, contextTimeout, outboxRepo, a.DB, a.BrokerProducer, serviceClients.UserService())
+	if err != nil {
+		return err
+	}
+	a.Outbox = outbox
+
+	// expired idempotency keys are removed in the background
+	cleanup, err := time.ParseDuration(a.Config.Idempotency.Cleanup)
+	if err != nil {
+		return fmt.Errorf("error during parse duration for idempotency cleanup: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopBackground = cancel
+	a.goBackground(func() { a.deleteExpiredIdempotencyKeys(ctx, cleanup) })
+	a.goBackground(func() { a.relayOutbox(ctx, relayInterval, cleanup) })
+
+	// consumers of other services' events
+	userEvents := kafka_delivery.NewUserEvents(a.Logger, a.Account)
+	a.BrokerConsumer.RegisterConsumer(kafka.NewConsumerConfig(a.Config.Kafka.Address, a.Config.Kafka.Topic.UserService, a.Config.Kafka.Consumer.Group, userEvents.Handle))
+	bookingEvents := kafka_delivery.NewBookingEvents(a.Logger, a.Popularity)
+	a.BrokerConsumer.RegisterConsumer(kafka.NewConsumerConfig(a.Config.Kafka.Address, a.Config.Kafka.Topic.BookingService, a.Config.Kafka.Consumer.Group, bookingEvents.Handle))
+	a.BrokerConsumer.Run()
+
+	// readiness of the dependencies, reported in grpc.health.v1
+	a.Readiness.AddCheck("postgres", a.DB.Ping)
+	a.Readiness.AddCheck("kafka", a.BrokerProducer.Ping)
+	a.Readiness.AddCheck("services", a.ServiceClients.Check)
+	a.goBackground(func() { a.Readiness.Run(ctx, a.healthInterval) })
+	healthpb.RegisterHealthServer(a.GrpcServer, a.Readiness.Server())
+
+	// business gauges are counted in the background
+	statisticsInterval, err := time.ParseDuration(a.Config.Metrics.StatisticsInterval)
+	if err != nil {
+		return fmt.Errorf("error during parse duration for metrics statistics interval: %w", err)
+	}
+	a.goBackground(func() { a.refreshStatistics(ctx, statisticsInterval) })
 
 	pb.RegisterEstablishmentServiceServer(a.GrpcServer, invest_grpc.NewRPC(a.Logger, attracationUsecase, restaurantUsecase, hotelUsecase, favouriteUsecase,imageUsecase, reviewUsecase, a.BrokerProducer))
-	a.Logger.Info("gRPC Server Listening", zap.String("url", a.Config.RPCPort))
-	if err := grpc_server.Run(a.Config, a.GrpcServer); err != nil {
-		return fmt.Errorf("gRPC fatal to serve grpc server over %s %w", a.Config.RPCPort, err)
+
+	// every method is reported from the start, not from its first call
+	a.Metrics.RPC.InitializeMetrics(a.GrpcServer)
+	return a.serveMetrics()
+}
+
+// newAuthenticator builds the token authenticator from the configured keys,
+// nil means no keys are configured which is allowed outside production with
+// a dev caller
+func newAuthenticator(cfg *config.Config) (*auth.Authenticator, error) {
+	var sources []auth.KeySource
+
+	var static auth.StaticKeys
+	for _, secret := range cfg.Auth.HMACSecrets {
+		static = append(static, auth.NewHMACKey("", secret))
+	}
+	if cfg.Auth.RSAPublicKeyFile != "" {
+		key, err := auth.NewRSAKeyFromFile("", cfg.Auth.RSAPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		static = append(static, key)
+	}
+	if len(static) != 0 {
+		sources = append(sources, static)
+	}
+	if cfg.Auth.JWKSFile != "" {
+		sources = append(sources, auth.JWKSFile(cfg.Auth.JWKSFile))
+	}
+
+	if len(sources) == 0 {
+		if cfg.Environment == pkg_app.EnvironmentProduction {
+			return nil, fmt.Errorf("JWT keys are required in %s", cfg.Environment)
+		}
+		if cfg.Auth.DevCaller == "" {
+			return nil, errors.New("JWT keys or JWT_DEV_CALLER are required, without them no request could change anything")
+		}
+		return nil, nil
+	}
+
+	refresh, err := time.ParseDuration(cfg.Auth.KeysRefresh)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for JWT keys refresh: %w", err)
+	}
+	leeway, err := time.ParseDuration(cfg.Auth.Leeway)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for JWT leeway: %w", err)
+	}
+
+	keys, err := auth.NewKeySet(refresh, sources...)
+	if err != nil {
+		return nil, fmt.Errorf("error during loading JWT keys: %w", err)
+	}
+
+	return auth.NewAuthenticator(keys, cfg.Auth.Issuer, cfg.Auth.Audience, leeway), nil
+}
+
+// newDevCaller parses the caller of every request when no keys are configured
+func newDevCaller(cfg *config.Config) (*entity.Caller, error) {
+	if cfg.Environment == pkg_app.EnvironmentProduction {
+		return nil, fmt.Errorf("JWT_DEV_CALLER is not allowed in %s", cfg.Environment)
+	}
+	return auth.ParseDevCaller(cfg.Auth.DevCaller)
+}
+
+// newIdempotency builds the service storing responses of create requests
+func newIdempotency(cfg *config.Config, db *postgres.PostgresDB) (usecase.Idempotency, error) {
+	contextTimeout, err := time.ParseDuration(cfg.Context.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for context timeout : %w", err)
+	}
+
+	ttl, err := time.ParseDuration(cfg.Idempotency.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for idempotency TTL: %w", err)
+	}
+
+	return usecase.NewIdempotencyService(contextTimeout, ttl, repo.NewIdempotencyRepo(db)), nil
+}
+
+func (a *App) deleteExpiredIdempotencyKeys(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := a.Idempotency.DeleteExpired(ctx)
+			if err != nil {
+				a.Logger.Error("failed to delete expired idempotency keys", zap.Error(err))
+				continue
+			}
+			a.Logger.Debug("deleted expired idempotency keys", zap.Int64("count", deleted))
+		}
+	}
+}
+
+// newReadiness builds the readiness of the service and returns the interval
+// its checks run at
+func newReadiness(cfg *config.Config, logger *zap.Logger) (*grpc_server.Readiness, time.Duration, error) {
+	interval, err := time.ParseDuration(cfg.Health.Interval)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for health interval: %w", err)
+	}
+	timeout, err := time.ParseDuration(cfg.Health.Timeout)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for health timeout: %w", err)
 	}
+
+	return grpc_server.NewReadiness(logger, timeout, cfg.Health.Checks), interval, nil
+}
+
+// traceFilter leaves the health checks of the orchestrator out of the traces
+var traceFilter = filters.Not(filters.HealthCheck())
+
+// newTracer starts exporting spans, the returned func flushes them and is nil
+// when tracing is disabled and otlp.Start spans are not recorded
+func newTracer(cfg *config.Config, logger *zap.Logger) (func(ctx context.Context) error, error) {
+	enabled, err := strconv.ParseBool(cfg.OTLPCollector.Enabled)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse otlp enabled %q: %w", cfg.OTLPCollector.Enabled, err)
+	}
+	if !enabled {
+		logger.Info("tracing disabled")
+		return nil, nil
+	}
+	return otlp.InitOTLPProvider(cfg)
+}
+
+// serveMetrics serves /metrics on the configured port unless it is empty
+func (a *App) serveMetrics() error {
+	if a.Config.Metrics.Port == "" {
+		a.Logger.Info("metrics endpoint disabled")
+		return nil
+	}
+
+	lis, err := net.Listen("tcp", a.Config.Metrics.Port)
+	if err != nil {
+		return fmt.Errorf("error during listen for metrics on %s: %w", a.Config.Metrics.Port, err)
+	}
+
+	a.metricsServer = metrics.NewServer(a.Config.Metrics.Port, a.Metrics)
+	go func() {
+		if err := a.metricsServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.Logger.Error("metrics server failed", zap.Error(err))
+		}
+	}()
+	a.Logger.Info("metrics Server Listening", zap.String("url", a.Config.Metrics.Port))
 	return nil
 }
 
-func (a *App) Stop() {
-	// close broker producer
-	a.BrokerProducer.Close()
+func (a *App) refreshStatistics(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		statistics, err := a.Statistics.GetStatistics(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			a.Logger.Error("failed to count statistics", zap.Error(err))
+		} else {
+			a.Metrics.SetStatistics(statistics)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newShutdownTimeouts parses the timeouts of the steps of Stop
+func newShutdownTimeouts(cfg *config.Config) (shutdownTimeouts, error) {
+	var (
+		shutdown shutdownTimeouts
+		err      error
+	)
+	if shutdown.readinessDelay, err = time.ParseDuration(cfg.Shutdown.ReadinessDelay); err != nil {
+		return shutdown, fmt.Errorf("error during parse duration for shutdown readiness delay: %w", err)
+	}
+	if shutdown.drainTimeout, err = time.ParseDuration(cfg.Shutdown.DrainTimeout); err != nil {
+		return shutdown, fmt.Errorf("error during parse duration for shutdown drain timeout: %w", err)
+	}
+	if shutdown.timeout, err = time.ParseDuration(cfg.Shutdown.Timeout); err != nil {
+		return shutdown, fmt.Errorf("error during parse duration for shutdown timeout: %w", err)
+	}
+	return shutdown, nil
+}
+
+// goBackground runs the worker in the background, Stop waits for it
+func (a *App) goBackground(worker func()) {
+	a.background.Add(1)
+	go func() {
+		defer a.background.Done()
+		worker()
+	}()
+}
+
+// newConsumer builds the broker consumer with the retry policy of its handlers
+func newConsumer(cfg *config.Config, logger *zap.Logger, metrics *metrics.Metrics) (event.BrokerConsumer, error) {
+	maxAttempts, err := strconv.Atoi(cfg.Kafka.Consumer.MaxAttempts)
+	if err != nil || maxAttempts < 1 {
+		return nil, fmt.Errorf("error during parse kafka consumer max attempts %q: must be a positive number", cfg.Kafka.Consumer.MaxAttempts)
+	}
+	minBackoff, err := time.ParseDuration(cfg.Kafka.Consumer.MinBackoff)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for kafka consumer min backoff: %w", err)
+	}
+	maxBackoff, err := time.ParseDuration(cfg.Kafka.Consumer.MaxBackoff)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for kafka consumer max backoff: %w", err)
+	}
+
+	return kafka.NewConsumer(cfg, logger, metrics, event.RetryPolicy{
+		MaxAttempts: maxAttempts,
+		MinBackoff:  minBackoff,
+		MaxBackoff:  maxBackoff,
+	}), nil
+}
+
+// newOutbox builds the relay of the outbox and returns the interval it polls at
+func newOutbox(cfg *config.Config, contextTimeout time.Duration, outboxRepo repository.Outbox, transactor repository.Transactor, producer event.BrokerProducer, users grpc_service_clients.UserService) (usecase.Outbox, time.Duration, error) {
+	interval, err := time.ParseDuration(cfg.Outbox.Interval)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for outbox interval: %w", err)
+	}
+	publishTimeout, err := time.ParseDuration(cfg.Outbox.PublishTimeout)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for outbox publish timeout: %w", err)
+	}
+	minBackoff, err := time.ParseDuration(cfg.Outbox.MinBackoff)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for outbox min backoff: %w", err)
+	}
+	maxBackoff, err := time.ParseDuration(cfg.Outbox.MaxBackoff)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for outbox max backoff: %w", err)
+	}
+	retention, err := time.ParseDuration(cfg.Outbox.Retention)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error during parse duration for outbox retention: %w", err)
+	}
+
+	return usecase.NewOutboxService(contextTimeout, publishTimeout, minBackoff, maxBackoff, retention, outboxRepo, transactor, producer, users), interval, nil
+}
+
+// relayOutbox sends pending messages every interval, right away again while
+// there are more, and deletes old sent messages every cleanup
+func (a *App) relayOutbox(ctx context.Context, interval, cleanup time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	cleanupTicker := time.NewTicker(cleanup)
+	defer cleanupTicker.Stop()
+
+	for {
+		sent, err := a.Outbox.Relay(ctx)
+		if err != nil && ctx.Err() == nil {
+			a.Logger.Error("failed to relay outbox messages", zap.Error(err))
+		}
+		if sent != 0 && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-cleanupTicker.C:
+			deleted, err := a.Outbox.DeleteSent(ctx)
+			if err != nil {
+				a.Logger.Error("failed to delete sent outbox messages", zap.Error(err))
+				continue
+			}
+			a.Logger.Debug("deleted sent outbox messages", zap.Int64("count", deleted))
+		}
+	}
+}
+
+// Stop tears the app down so that nothing in flight is lost: the instance
+// stops being ready, running RPCs complete, consumers and background
+// workers stop, the producer flushes and only then the connections close
+func (a *App) Stop() error {
+	var errs []error
+
+	// a start in progress finishes first, a later one starts nothing
+	a.lifecycle.Lock()
+	a.stopped = true
+	a.lifecycle.Unlock()
+
+	// no new requests are routed to the instance
+	a.Readiness.Shutdown()
+	time.Sleep(a.shutdown.readinessDelay)
+
+	// running RPCs complete, they are canceled after the drain timeout
+	if err := drainServer(a.GrpcServer, a.shutdown.drainTimeout); err != nil {
+		errs = append(errs, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdown.timeout)
+	defer cancel()
+
+	// close broker consumer, a message being handled is redelivered
+	if err := a.BrokerConsumer.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// stop background cleanup, readiness checks and the outbox relay
+	if a.stopBackground != nil {
+		a.stopBackground()
+	}
+	if err := wait(ctx, &a.background); err != nil {
+		errs = append(errs, fmt.Errorf("error during stop background workers: %w", err))
+	}
+
+	// close broker producer once nothing publishes
+	if err := a.BrokerProducer.Close(); err != nil {
+		errs = append(errs, err)
+	}
 
 	// closing client service connections
-	a.ServiceClients.Close()
+	if a.ServiceClients != nil {
+		if err := a.ServiceClients.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// flush the spans of the teardown too
+	if a.ShutdownTracer != nil {
+		if err := a.ShutdownTracer(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
-	// stop gRPC server
-	a.GrpcServer.Stop()
+	// metrics are scraped until the end of the teardown
+	if a.metricsServer != nil {
+		if err := a.metricsServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error during shutdown metrics server: %w", err))
+		}
+	}
 
 	// database connection
 	a.DB.Close()
 
 	// zap logger sync
 	a.Logger.Sync()
+
+	return errors.Join(errs...)
+}
+
+// drainServer stops the server accepting RPCs and waits for the running
+// ones, those still running after the timeout are canceled
+func drainServer(server *grpc.Server, timeout time.Duration) error {
+	drained := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-drained:
+		return nil
+	case <-timer.C:
+		server.Stop()
+		<-drained
+		return fmt.Errorf("gRPC server did not drain in %s, running RPCs were canceled", timeout)
+	}
+}
+
+// wait waits for the group until ctx is done
+func wait(ctx context.Context, group *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
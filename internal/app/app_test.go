@@ -0,0 +1,119 @@
+package app
+
+import (
+	pkg_app "Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startSlowServer serves health checks taking the delay
+func startSlowServer(t *testing.T, delay time.Duration) (*grpc.Server, healthpb.HealthClient) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return server, healthpb.NewHealthClient(conn)
+}
+
+func TestDrainServer(t *testing.T) {
+	// running RPCs complete
+	server, client := startSlowServer(t, 100*time.Millisecond)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var err error
+	go func() {
+		defer wg.Done()
+		_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	assert.NoError(t, drainServer(server, time.Second))
+	wg.Wait()
+	assert.NoError(t, err)
+
+	// RPCs running after the timeout are canceled
+	server, client = startSlowServer(t, time.Minute)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	assert.Error(t, drainServer(server, 50*time.Millisecond))
+	wg.Wait()
+	assert.Error(t, err)
+}
+
+func TestWait(t *testing.T) {
+	var group sync.WaitGroup
+	assert.NoError(t, wait(context.Background(), &group))
+
+	group.Add(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, wait(ctx, &group), context.DeadlineExceeded)
+	group.Done()
+}
+
+func TestAuthenticationWithoutKeys(t *testing.T) {
+	cfg := &config.Config{Environment: pkg_app.EnvironmentDevelop}
+
+	// nobody could change anything
+	_, err := newAuthenticator(cfg)
+	assert.Error(t, err)
+
+	cfg.Auth.DevCaller = "8f14e45f-ceea-467f-a0e6-7b5b1b1f3a2c:owner"
+	authenticator, err := newAuthenticator(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, authenticator)
+	caller, err := newDevCaller(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "owner", caller.Role)
+
+	cfg.Environment = pkg_app.EnvironmentProduction
+	_, err = newAuthenticator(cfg)
+	assert.Error(t, err)
+	_, err = newDevCaller(cfg)
+	assert.Error(t, err)
+}
+
+func TestStartAfterStop(t *testing.T) {
+	a := &App{stopped: true}
+
+	// nothing is wired or started, Stop does not wait for it
+	assert.NoError(t, a.start())
+	assert.Nil(t, a.ServiceClients)
+	assert.Nil(t, a.stopBackground)
+	assert.Nil(t, a.metricsServer)
+}
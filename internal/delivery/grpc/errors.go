@@ -10,26 +10,36 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-var (
-	errNotFound   *entity.ErrNotFound
-	errConflict   *entity.ErrConflict
-	errValidation *entity.ErrValidation
-)
-
+// ErrorStatus maps domain errors to gRPC statuses, anything unknown becomes
+// Internal without details so storage errors are not leaked to clients
 func ErrorStatus(ctx context.Context, err error) *status.Status {
 	var (
-		st *status.Status
+		st              *status.Status
+		errNotFound     *entity.ErrNotFound
+		errConflict     *entity.ErrConflict
+		errValidation   *entity.ErrValidation
+		errUnauthorized *entity.ErrUnauthenticated
+		errDenied       *entity.ErrPermissionDenied
+		errRequired     *entity.ErrNoRequiredParameter
+		errVersion      *entity.ErrVersionMismatch
+		errUnavailable  *entity.ErrUnavailable
 	)
 	switch {
+	// error already carrying a status
+	case isStatus(err):
+		st, _ = status.FromError(err)
 	// error not found
 	case errors.As(err, &errNotFound):
-		st = status.New(codes.NotFound, err.Error())
+		st = status.New(codes.NotFound, errNotFound.Error())
 	// error conflict
 	case errors.As(err, &errConflict):
-		st = status.New(codes.AlreadyExists, err.Error())
+		st = status.New(codes.AlreadyExists, errConflict.Error())
+	// error stale version
+	case errors.As(err, &errVersion):
+		st = status.New(codes.Aborted, errVersion.Error())
 	// error validation errors
 	case errors.As(err, &errValidation):
-		st = status.New(codes.InvalidArgument, codes.InvalidArgument.String())
+		st = status.New(codes.InvalidArgument, errValidation.Error())
 		br := &epb.BadRequest{}
 		for field, des := range errValidation.Errors {
 			br.FieldViolations = append(br.FieldViolations, &epb.BadRequest_FieldViolation{
@@ -38,13 +48,27 @@ func ErrorStatus(ctx context.Context, err error) *status.Status {
 			})
 		}
 		st, _ = st.WithDetails(br)
+	// error missing parameters
+	case errors.As(err, &errRequired):
+		st = status.New(codes.InvalidArgument, errRequired.Error())
+	// error authentication
+	case errors.As(err, &errUnauthorized):
+		st = status.New(codes.Unauthenticated, errUnauthorized.Error())
+	// error authorization
+	case errors.As(err, &errDenied):
+		st = status.New(codes.PermissionDenied, errDenied.Error())
+	// error dependency down, the client may retry later
+	case errors.As(err, &errUnavailable):
+		st = status.New(codes.Unavailable, errUnavailable.Error())
+	// error deadline
+	case errors.Is(err, context.DeadlineExceeded):
+		st = status.New(codes.DeadlineExceeded, codes.DeadlineExceeded.String())
+	// error canceled
+	case errors.Is(err, context.Canceled):
+		st = status.New(codes.Canceled, codes.Canceled.String())
 	// error internal
 	default:
 		st = status.New(codes.Internal, codes.Internal.String())
-		errInfo := &epb.ErrorInfo{
-			Reason: err.Error(),
-		}
-		st, _ = st.WithDetails(errInfo)
 	}
 	return st
 }
@@ -52,3 +76,8 @@ func ErrorStatus(ctx context.Context, err error) *status.Status {
 func Error(ctx context.Context, err error) error {
 	return ErrorStatus(ctx, err).Err()
 }
+
+func isStatus(err error) bool {
+	_, ok := status.FromError(err)
+	return ok
+}
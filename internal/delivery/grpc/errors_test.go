@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"Booking/establishment-service-booking/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorStatus(t *testing.T) {
+	ctx := context.Background()
+
+	// repo errors are wrapped with the failed operation
+	st := ErrorStatus(ctx, fmt.Errorf("failed to get hotel: %w", entity.ErrorNotFound))
+	assert.Equal(t, codes.NotFound, st.Code())
+
+	st = ErrorStatus(ctx, fmt.Errorf("failed to execute SQL query for creating hotel: %w", entity.ErrorConflict))
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+
+	errValidation := entity.NewErrValidation()
+	errValidation.Err = errors.New("invalid hotel")
+	errValidation.Errors["hotel_name"] = "is required"
+	st = ErrorStatus(ctx, errValidation)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Len(t, st.Details(), 1)
+	assert.Equal(t, "hotel_name", st.Details()[0].(*epb.BadRequest).FieldViolations[0].Field)
+
+	st = ErrorStatus(ctx, fmt.Errorf("failed to update hotel: %w", entity.NewErrVersionMismatch("hotel", 3)))
+	assert.Equal(t, codes.Aborted, st.Code())
+
+	st = ErrorStatus(ctx, entity.ErrorUnauthenticated)
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+
+	st = ErrorStatus(ctx, entity.NewErrPermissionDenied("change the establishment"))
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+
+	st = ErrorStatus(ctx, fmt.Errorf("failed to list bookings: %w", entity.NewErrUnavailable("booking service")))
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "booking service is unavailable", st.Message())
+
+	st = ErrorStatus(ctx, fmt.Errorf("failed to get hotel: %w", context.DeadlineExceeded))
+	assert.Equal(t, codes.DeadlineExceeded, st.Code())
+
+	// statuses pass through untouched
+	st = ErrorStatus(ctx, status.Error(codes.Unauthenticated, "authorization token is required"))
+	assert.Equal(t, codes.Unauthenticated, st.Code())
+	assert.Equal(t, "authorization token is required", st.Message())
+
+	// internal details are not leaked
+	st = ErrorStatus(ctx, errors.New(`ERROR: relation "hotel_table" does not exist (SQLSTATE 42P01)`))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, codes.Internal.String(), st.Message())
+	assert.Empty(t, st.Details())
+}
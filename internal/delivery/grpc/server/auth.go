@@ -0,0 +1,79 @@
+package server
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/auth"
+	"context"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	mdKeyAuthorization = "authorization"
+	bearerPrefix       = "bearer "
+)
+
+// UnaryInterceptorAuth authenticates the caller by the bearer token and puts
+// it into the context, public methods and health checks may be called
+// without a token
+func UnaryInterceptorAuth(logger *zap.Logger, authenticator *auth.Authenticator, publicMethods []string) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, present := bearerToken(ctx)
+		if present && token == "" {
+			return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+		}
+		if !present {
+			if public[path.Base(info.FullMethod)] || strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+				return handler(ctx, req)
+			}
+			return nil, status.Error(codes.Unauthenticated, "authorization token is required")
+		}
+
+		caller, err := authenticator.Authenticate(token)
+		if err != nil {
+			logger.Debug("rejected token", zap.String("method", info.FullMethod), zap.Error(err))
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization token")
+		}
+
+		return handler(context.WithValue(ctx, app.CtxKeyCaller, caller), req)
+	}
+}
+
+// UnaryInterceptorDevCaller makes every request on behalf of the caller, it
+// replaces UnaryInterceptorAuth in local development without keys
+func UnaryInterceptorDevCaller(caller *entity.Caller) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(context.WithValue(ctx, app.CtxKeyCaller, caller), req)
+	}
+}
+
+// bearerToken returns the token of the authorization metadata and whether
+// the metadata was sent, the token is empty when it is not a bearer token
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	values := md.Get(mdKeyAuthorization)
+	if len(values) == 0 {
+		return "", false
+	}
+	if len(values[0]) <= len(bearerPrefix) || !strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+		return "", true
+	}
+
+	return strings.TrimSpace(values[0][len(bearerPrefix):]), true
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryInterceptorAuth(t *testing.T) {
+	keys, err := auth.NewKeySet(time.Minute, auth.StaticKeys{auth.NewHMACKey("k1", "secret")})
+	if err != nil {
+		t.Fatalf("failed to create key set: %v", err)
+	}
+	interceptor := UnaryInterceptorAuth(zap.NewNop(), auth.NewAuthenticator(keys, "", "", time.Minute), []string{"GetHotel"})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "u1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		Role:             "owner",
+	})
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return app.GetCallerFromContext(ctx), nil
+	}
+	call := func(method string, authorization ...string) (interface{}, error) {
+		ctx := context.Background()
+		if len(authorization) != 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization[0]))
+		}
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/establishment_service.EstablishmentService/" + method}, handler)
+	}
+
+	caller, err := call("UpdateHotel", "Bearer "+signed)
+	if assert.NoError(t, err) {
+		assert.Equal(t, &entity.Caller{UserId: "u1", Role: entity.RoleOwner}, caller)
+	}
+
+	// public methods may be called without the metadata
+	_, err = call("GetHotel")
+	assert.NoError(t, err)
+	_, err = call("UpdateHotel")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// metadata that is not a bearer token is rejected, even on public methods
+	for _, authorization := range []string{"Token " + signed, "Basic dTpw", "Bearer", "Bearer "} {
+		_, err = call("GetHotel", authorization)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), authorization)
+	}
+
+	_, err = call("GetHotel", "Bearer not-a-token")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
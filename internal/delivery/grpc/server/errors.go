@@ -0,0 +1,29 @@
+package server
+
+import (
+	delivery "Booking/establishment-service-booking/internal/delivery/grpc"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// UnaryInterceptorError converts errors returned by handlers into gRPC
+// statuses, internal errors are logged here since clients only see the code
+func UnaryInterceptorError(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		st := delivery.ErrorStatus(ctx, err)
+		if st.Code() == codes.Internal {
+			logger.Error("internal error", append(otlp.LogFields(ctx), zap.String("method", info.FullMethod), zap.Error(err))...)
+		}
+
+		return nil, st.Err()
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// HealthLiveness is SERVING while the process runs
+	HealthLiveness = "liveness"
+	// HealthReadiness is SERVING while every readiness check passes, the
+	// empty service name reports the same
+	HealthReadiness = "readiness"
+)
+
+// healthMethodPrefix prefixes the methods of grpc.health.v1, probes call
+// them without a token
+var healthMethodPrefix = "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+
+// Check reports if a dependency the service needs is reachable
+type Check func(ctx context.Context) error
+
+// Readiness reports the service in grpc.health.v1, it is ready while every
+// enabled check passes and never again once it shuts down
+type Readiness struct {
+	logger  *zap.Logger
+	server  *health.Server
+	timeout time.Duration
+	enabled map[string]bool
+
+	mu       sync.Mutex
+	checks   []namedCheck
+	shutdown bool
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// NewReadiness returns a readiness not serving until its checks first pass,
+// only the checks of the enabled names count
+func NewReadiness(logger *zap.Logger, timeout time.Duration, enabled []string) *Readiness {
+	server := health.NewServer()
+	server.SetServingStatus(HealthLiveness, healthpb.HealthCheckResponse_SERVING)
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	server.SetServingStatus(HealthReadiness, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	r := &Readiness{
+		logger:  logger,
+		server:  server,
+		timeout: timeout,
+		enabled: make(map[string]bool, len(enabled)),
+	}
+	for _, name := range enabled {
+		r.enabled[name] = true
+	}
+	return r
+}
+
+// Server is the grpc.health.v1 service to register
+func (r *Readiness) Server() healthpb.HealthServer {
+	return r.server
+}
+
+// AddCheck adds the check of a dependency unless its name is not enabled
+func (r *Readiness) AddCheck(name string, check Check) {
+	if !r.enabled[name] {
+		r.logger.Info("readiness check disabled", zap.String("check", name))
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, namedCheck{name: name, check: check})
+}
+
+// Update runs the checks and reports the result
+func (r *Readiness) Update(ctx context.Context) bool {
+	r.mu.Lock()
+	checks := r.checks
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	ready := true
+	for _, c := range checks {
+		if err := c.check(ctx); err != nil {
+			r.logger.Warn("readiness check failed", zap.String("check", c.name), zap.Error(err))
+			ready = false
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutdown {
+		return false
+	}
+
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	r.server.SetServingStatus("", status)
+	r.server.SetServingStatus(HealthReadiness, status)
+	return ready
+}
+
+// Run updates the readiness at the interval until ctx is done
+func (r *Readiness) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.Update(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports the service not ready for good, new requests go to
+// other instances while the running ones complete
+func (r *Readiness) Shutdown() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.shutdown = true
+	r.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	r.server.SetServingStatus(HealthReadiness, healthpb.HealthCheckResponse_NOT_SERVING)
+}
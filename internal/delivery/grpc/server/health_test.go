@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func healthStatus(t *testing.T, r *Readiness, service string) healthpb.HealthCheckResponse_ServingStatus {
+	response, err := r.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("failed to check %q: %v", service, err)
+	}
+	return response.Status
+}
+
+func TestReadiness(t *testing.T) {
+	ctx := context.Background()
+	r := NewReadiness(zap.NewNop(), time.Second, []string{"postgres", "kafka"})
+
+	var kafkaErr error
+	r.AddCheck("postgres", func(ctx context.Context) error { return nil })
+	r.AddCheck("kafka", func(ctx context.Context) error { return kafkaErr })
+	// not enabled, it never runs
+	r.AddCheck("services", func(ctx context.Context) error { return errors.New("user service is TRANSIENT_FAILURE") })
+
+	// not ready before the first checks
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, healthStatus(t, r, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, r, HealthLiveness))
+
+	assert.True(t, r.Update(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, r, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, r, HealthReadiness))
+
+	kafkaErr = errors.New("connection refused")
+	assert.False(t, r.Update(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, healthStatus(t, r, ""))
+
+	kafkaErr = nil
+	assert.True(t, r.Update(ctx))
+
+	// once shut down passing checks do not make it ready again
+	r.Shutdown()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, healthStatus(t, r, HealthReadiness))
+	assert.False(t, r.Update(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, healthStatus(t, r, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, healthStatus(t, r, HealthLiveness))
+}
+
+func TestReadinessCheckTimeout(t *testing.T) {
+	r := NewReadiness(zap.NewNop(), 10*time.Millisecond, []string{"postgres"})
+	r.AddCheck("postgres", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.False(t, r.Update(context.Background()))
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/usecase"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// UnaryInterceptorIdempotency runs a request of the methods sent with an
+// idempotency-key metadata once, retries with the same key and payload get
+// the stored response and a different payload under the key is rejected
+func UnaryInterceptorIdempotency(logger *zap.Logger, idempotency usecase.Idempotency, methods []string) grpc.UnaryServerInterceptor {
+	idempotent := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		idempotent[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !idempotent[path.Base(info.FullMethod)] || app.GetIdempotencyKeyFromContext(ctx) == "" {
+			return handler(ctx, req)
+		}
+
+		request, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		hash, err := requestHash(request)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := idempotency.Begin(ctx, info.FullMethod, hash)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			logger.Debug("replaying idempotent response", zap.String("method", info.FullMethod))
+			return replay(record.ResponseType, record.Response)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			// the request may be retried with the same key
+			if err := idempotency.Abort(context.WithoutCancel(ctx), info.FullMethod, hash); err != nil {
+				logger.Error("failed to release idempotency key", append(otlp.LogFields(ctx), zap.String("method", info.FullMethod), zap.Error(err))...)
+			}
+			return nil, err
+		}
+
+		if response, ok := resp.(proto.Message); ok {
+			data, err := proto.Marshal(response)
+			if err == nil {
+				err = idempotency.Complete(context.WithoutCancel(ctx), info.FullMethod, hash, proto.MessageName(response), data)
+			}
+			if err != nil {
+				// the object is created, a retry is answered with a conflict instead of the response
+				logger.Error("failed to store idempotent response", append(otlp.LogFields(ctx), zap.String("method", info.FullMethod), zap.Error(err))...)
+			}
+		}
+
+		return resp, nil
+	}
+}
+
+func requestHash(request proto.Message) (string, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func replay(response_type string, data []byte) (interface{}, error) {
+	t := proto.MessageType(response_type)
+	if t == nil {
+		return nil, fmt.Errorf("unknown type %q of stored response", response_type)
+	}
+
+	response, ok := reflect.New(t.Elem()).Interface().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("type %q of stored response is not a message", response_type)
+	}
+	if err := proto.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+
+	return response, nil
+}
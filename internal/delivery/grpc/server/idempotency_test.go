@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	pb "Booking/establishment-service-booking/genproto/establishment-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/app"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// memoryIdempotency keeps records like the usecase does, without a database
+type memoryIdempotency struct {
+	records map[string]*entity.IdempotencyRecord
+}
+
+func (m *memoryIdempotency) Begin(ctx context.Context, method, request_hash string) (*entity.IdempotencyRecord, error) {
+	key := app.GetIdempotencyKeyFromContext(ctx) + method
+	if existing, ok := m.records[key]; ok {
+		if existing.RequestHash != request_hash {
+			return nil, entity.NewErrValidation()
+		}
+		return existing, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryIdempotency) Complete(ctx context.Context, method, request_hash, response_type string, response []byte) error {
+	m.records[app.GetIdempotencyKeyFromContext(ctx)+method] = &entity.IdempotencyRecord{
+		RequestHash:  request_hash,
+		ResponseType: response_type,
+		Response:     response,
+	}
+	return nil
+}
+
+func (m *memoryIdempotency) Abort(ctx context.Context, method, request_hash string) error {
+	return nil
+}
+
+func (m *memoryIdempotency) DeleteExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestUnaryInterceptorIdempotency(t *testing.T) {
+	interceptor := UnaryInterceptorIdempotency(zap.NewNop(), &memoryIdempotency{records: map[string]*entity.IdempotencyRecord{}}, []string{"CreateHotel"})
+	info := &grpc.UnaryServerInfo{FullMethod: "/establishment_service.EstablishmentService/CreateHotel"}
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &pb.Hotel{HotelId: "hotel-1", HotelName: req.(*pb.Hotel).HotelName}, nil
+	}
+
+	ctx := context.WithValue(context.Background(), app.CtxKeyIdempotencyKey, "key-1")
+
+	first, err := interceptor(ctx, &pb.Hotel{HotelName: "Hilton"}, info, handler)
+	assert.NoError(t, err)
+
+	// the retry is answered with the stored response
+	second, err := interceptor(ctx, &pb.Hotel{HotelName: "Hilton"}, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.True(t, proto.Equal(first.(proto.Message), second.(proto.Message)))
+
+	// a different payload under the key is rejected
+	_, err = interceptor(ctx, &pb.Hotel{HotelName: "Hyatt"}, info, handler)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// requests without a key are not deduplicated
+	_, err = interceptor(context.Background(), &pb.Hotel{HotelName: "Hilton"}, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
@@ -1,13 +1,27 @@
 package server
 
 import (
+	"Booking/establishment-service-booking/internal/pkg/app"
 	"context"
+	"path"
+	"strconv"
+	"strings"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+const (
+	mdKeyCategories     = "categories"
+	mdKeyLocale         = "locale"
+	mdKeyAcceptLanguage = "accept-language"
+	mdKeyCurrency       = "currency"
+	mdKeyIdempotencyKey = "idempotency-key"
+	mdKeyUpdateMask     = "update-mask"
+	mdKeySort           = "sort"
+)
+
 func UnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
 	n := len(interceptors)
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -26,11 +40,71 @@ func UnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) grpc.UnarySer
 
 func UnaryInterceptorData(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = context.WithValue(ctx, app.CtxKeyMethod, path.Base(info.FullMethod))
+
 		md, ok := metadata.FromIncomingContext(ctx)
 		if ok {
-			_ = md
-			//...
+			// explicit locale wins over the first tag of accept-language
+			if locale := localeFromMetadata(md); locale != "" {
+				ctx = context.WithValue(ctx, app.CtxKeyLocalization, locale)
+			}
+
+			// ISO currency code prices are converted into
+			if values := md.Get(mdKeyCurrency); len(values) != 0 && strings.TrimSpace(values[0]) != "" {
+				ctx = context.WithValue(ctx, app.CtxKeyCurrency, strings.ToUpper(strings.TrimSpace(values[0])))
+			}
+
+			// retries of a create request carry the same key
+			if values := md.Get(mdKeyIdempotencyKey); len(values) != 0 && strings.TrimSpace(values[0]) != "" {
+				ctx = context.WithValue(ctx, app.CtxKeyIdempotencyKey, strings.TrimSpace(values[0]))
+			}
+
+			// version of the object an update or delete is based on
+			if values := md.Get(app.MetadataKeyVersion); len(values) != 0 {
+				if version, err := strconv.ParseInt(strings.TrimSpace(values[0]), 10, 64); err == nil {
+					ctx = context.WithValue(ctx, app.CtxKeyVersion, version)
+				}
+			}
+
+			// order of list requests, e.g. "popularity"
+			if values := md.Get(mdKeySort); len(values) != 0 && strings.TrimSpace(values[0]) != "" {
+				ctx = context.WithValue(ctx, app.CtxKeySort, strings.ToLower(strings.TrimSpace(values[0])))
+			}
+
+			// category codes, either repeated or comma separated
+			if values, exists := md[mdKeyCategories]; exists {
+				ctx = context.WithValue(ctx, app.CtxKeyCategories, splitValues(values))
+			}
+
+			// field paths of a partial update, e.g. "description,location.city"
+			if values, exists := md[mdKeyUpdateMask]; exists {
+				ctx = context.WithValue(ctx, app.CtxKeyUpdateMask, splitValues(values))
+			}
 		}
 		return handler(ctx, req)
 	}
 }
+
+func localeFromMetadata(md metadata.MD) string {
+	if values := md.Get(mdKeyLocale); len(values) != 0 && strings.TrimSpace(values[0]) != "" {
+		return strings.TrimSpace(values[0])
+	}
+	if values := md.Get(mdKeyAcceptLanguage); len(values) != 0 {
+		tag := strings.Split(values[0], ",")[0]
+		return strings.TrimSpace(strings.Split(tag, ";")[0])
+	}
+	return ""
+}
+
+// splitValues splits repeated or comma separated metadata values
+func splitValues(values []string) []string {
+	split := []string{}
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				split = append(split, v)
+			}
+		}
+	}
+	return split
+}
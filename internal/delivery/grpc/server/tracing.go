@@ -0,0 +1,38 @@
+package server
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// UnaryInterceptorTrace tags the request with the ids of its trace, the
+// request log and the loggers taken from the context carry them. It runs
+// after the tracing and the tags interceptors.
+func UnaryInterceptorTrace() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tagTrace(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptorTrace is UnaryInterceptorTrace for streams
+func StreamInterceptorTrace() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tagTrace(stream.Context())
+		return handler(srv, stream)
+	}
+}
+
+func tagTrace(ctx context.Context) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return
+	}
+	grpc_ctxtags.Extract(ctx).
+		Set(otlp.LogKeyTraceId, spanContext.TraceID().String()).
+		Set(otlp.LogKeySpanId, spanContext.SpanID().String())
+}
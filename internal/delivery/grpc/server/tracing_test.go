@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+func TestUnaryInterceptorTrace(t *testing.T) {
+	interceptor := UnaryInterceptorTrace()
+	info := &grpc.UnaryServerInfo{FullMethod: "/establishment_service.EstablishmentService/GetHotel"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+
+	// not traced, nothing to tag
+	ctx := grpc_ctxtags.SetInContext(context.Background(), grpc_ctxtags.NewTags())
+	_, err := interceptor(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Empty(t, grpc_ctxtags.Extract(ctx).Values())
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(ctx, "GetHotel")
+	defer span.End()
+	_, err = interceptor(ctx, nil, info, handler)
+	assert.NoError(t, err)
+
+	tags := grpc_ctxtags.Extract(ctx).Values()
+	assert.Equal(t, span.SpanContext().TraceID().String(), tags[otlp.LogKeyTraceId])
+	assert.Equal(t, span.SpanContext().SpanID().String(), tags[otlp.LogKeySpanId])
+	assert.Len(t, otlp.LogFields(ctx), 2)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"context"
+	"strings"
+)
+
+// filterFromContext builds list filter from the request metadata
+func filterFromContext(ctx context.Context) *entity.Filter {
+	return &entity.Filter{
+		Categories: app.GetCategoriesFromContext(ctx),
+		Sort:       app.GetSortFromContext(ctx),
+	}
+}
+
+// categoriesFromContext returns categories to link to an establishment,
+// nil keeps the current ones untouched
+func categoriesFromContext(ctx context.Context) []*entity.Category {
+	codes := app.GetCategoriesFromContext(ctx)
+	if codes == nil {
+		return nil
+	}
+
+	categories := make([]*entity.Category, 0, len(codes))
+	for _, code := range codes {
+		categories = append(categories, &entity.Category{Code: code})
+	}
+	return categories
+}
+
+// categoryCodes joins category codes to fill the location category of the response
+func categoryCodes(categories []*entity.Category) string {
+	codes := make([]string, 0, len(categories))
+	for _, category := range categories {
+		codes = append(codes, category.Code)
+	}
+	return strings.Join(codes, ",")
+}
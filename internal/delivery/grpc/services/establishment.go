@@ -7,12 +7,9 @@ import (
 	"Booking/establishment-service-booking/internal/usecase"
 	"Booking/establishment-service-booking/internal/usecase/event"
 	"context"
-	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type establishmentRPC struct {
@@ -58,8 +55,6 @@ func (s establishmentRPC) CreateAttraction(ctx context.Context, attraction *pb.A
 		image.EstablishmentId = i.EstablishmentId
 		image.ImageUrl = i.ImageUrl
 		image.Category = i.Category
-		image.CreatedAt = time.Now().Local()
-		image.UpdatedAt = time.Now().Local()
 
 		images = append(images, &image)
 	}
@@ -72,22 +67,19 @@ func (s establishmentRPC) CreateAttraction(ctx context.Context, attraction *pb.A
 		ContactNumber:  attraction.ContactNumber,
 		LicenceUrl:     attraction.LicenceUrl,
 		WebsiteUrl:     attraction.WebsiteUrl,
+		Categories:     categoriesFromContext(ctx),
 		Images:         images,
 		Location: entity.Location{
-			LocationId:      attraction.Location.LocationId,
-			EstablishmentId: attraction.Location.EstablishmentId,
-			Address:         attraction.Location.Address,
-			Latitude:        attraction.Location.Latitude,
-			Longitude:       attraction.Location.Longitude,
-			Country:         attraction.Location.Country,
-			City:            attraction.Location.City,
-			StateProvince:   attraction.Location.StateProvince,
-			Category:        attraction.Location.Category,
-			CreatedAt:       time.Now().Local(),
-			UpdatedAt:       time.Now().Local(),
+			LocationId:      attraction.GetLocation().GetLocationId(),
+			EstablishmentId: attraction.GetLocation().GetEstablishmentId(),
+			Address:         attraction.GetLocation().GetAddress(),
+			Latitude:        attraction.GetLocation().GetLatitude(),
+			Longitude:       attraction.GetLocation().GetLongitude(),
+			Country:         attraction.GetLocation().GetCountry(),
+			City:            attraction.GetLocation().GetCity(),
+			StateProvince:   attraction.GetLocation().GetStateProvince(),
+			Category:        attraction.GetLocation().GetCategory(),
 		},
-		CreatedAt: time.Now().Local(),
-		UpdatedAt: time.Now().Local(),
 	})
 	if err != nil {
 		return nil, err
@@ -126,6 +118,7 @@ func (s establishmentRPC) CreateAttraction(ctx context.Context, attraction *pb.A
 			Country:         response.Location.Country,
 			City:            response.Location.City,
 			StateProvince:   response.Location.StateProvince,
+			Category:        categoryCodes(response.Categories),
 			CreatedAt:       response.Location.CreatedAt.String(),
 			UpdatedAt:       response.Location.UpdatedAt.String(),
 		},
@@ -146,6 +139,8 @@ func (s establishmentRPC) GetAttraction(ctx context.Context, request *pb.GetAttr
 		return nil, err
 	}
 
+	setVersionHeader(ctx, attraction.Version)
+
 	var images []*pb.Image
 
 	for _, i := range attraction.Images {
@@ -180,6 +175,7 @@ func (s establishmentRPC) GetAttraction(ctx context.Context, request *pb.GetAttr
 				Country:         attraction.Location.Country,
 				City:            attraction.Location.City,
 				StateProvince:   attraction.Location.StateProvince,
+				Category:        categoryCodes(attraction.Categories),
 				CreatedAt:       attraction.CreatedAt.String(),
 				UpdatedAt:       attraction.UpdatedAt.String(),
 			},
@@ -196,9 +192,9 @@ func (s establishmentRPC) ListAttractions(ctx context.Context, request *pb.ListA
 	)
 	defer span.End()
 
-	attractions, overall, err := s.attracationUsecase.ListAttractions(ctx, request.Offset, request.Limit)
+	attractions, overall, err := s.attracationUsecase.ListAttractions(ctx, request.Offset, request.Limit, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch attractions: %v", err)
+		return nil, err
 	}
 
 	var pbAttractions []*pb.Attraction
@@ -233,6 +229,7 @@ func (s establishmentRPC) ListAttractions(ctx context.Context, request *pb.ListA
 				Country:         attraction.Location.Country,
 				City:            attraction.Location.City,
 				StateProvince:   attraction.Location.StateProvince,
+				Category:        categoryCodes(attraction.Categories),
 				CreatedAt:       attraction.CreatedAt.String(),
 				UpdatedAt:       attraction.UpdatedAt.String(),
 			},
@@ -251,7 +248,7 @@ func (s establishmentRPC) UpdateAttraction(ctx context.Context, request *pb.Upda
 
 	ctx, span := otlp.Start(ctx, "attraction_grpc_delivery", "Update")
 	span.SetAttributes(
-		attribute.Key("attraction_id").String(request.Attraction.AttractionId),
+		attribute.Key("attraction_id").String(request.GetAttraction().GetAttractionId()),
 	)
 	defer span.End()
 
@@ -267,30 +264,33 @@ func (s establishmentRPC) UpdateAttraction(ctx context.Context, request *pb.Upda
 	// 	imagesS = append(imagesS, &image)
 	// }
 	attraction, err := s.attracationUsecase.UpdateAttraction(ctx, &entity.Attraction{
-		AttractionId:   request.Attraction.AttractionId,
-		OwnerId:        request.Attraction.OwnerId,
-		AttractionName: request.Attraction.AttractionName,
-		Description:    request.Attraction.Description,
-		Rating:         request.Attraction.Rating,
-		ContactNumber:  request.Attraction.ContactNumber,
-		LicenceUrl:     request.Attraction.LicenceUrl,
-		WebsiteUrl:     request.Attraction.WebsiteUrl,
+		AttractionId:   request.GetAttraction().GetAttractionId(),
+		OwnerId:        request.GetAttraction().GetOwnerId(),
+		AttractionName: request.GetAttraction().GetAttractionName(),
+		Description:    request.GetAttraction().GetDescription(),
+		Rating:         request.GetAttraction().GetRating(),
+		ContactNumber:  request.GetAttraction().GetContactNumber(),
+		LicenceUrl:     request.GetAttraction().GetLicenceUrl(),
+		WebsiteUrl:     request.GetAttraction().GetWebsiteUrl(),
+		Categories:     categoriesFromContext(ctx),
 		// Images:         imagesS,
 		Location: entity.Location{
-			LocationId:      request.Attraction.Location.LocationId,
-			EstablishmentId: request.Attraction.Location.EstablishmentId,
-			Address:         request.Attraction.Location.Address,
-			Latitude:        request.Attraction.Location.Latitude,
-			Longitude:       request.Attraction.Location.Longitude,
-			Country:         request.Attraction.Location.Country,
-			City:            request.Attraction.Location.City,
-			StateProvince:   request.Attraction.Location.StateProvince,
+			LocationId:      request.GetAttraction().GetLocation().GetLocationId(),
+			EstablishmentId: request.GetAttraction().GetLocation().GetEstablishmentId(),
+			Address:         request.GetAttraction().GetLocation().GetAddress(),
+			Latitude:        request.GetAttraction().GetLocation().GetLatitude(),
+			Longitude:       request.GetAttraction().GetLocation().GetLongitude(),
+			Country:         request.GetAttraction().GetLocation().GetCountry(),
+			City:            request.GetAttraction().GetLocation().GetCity(),
+			StateProvince:   request.GetAttraction().GetLocation().GetStateProvince(),
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	setVersionHeader(ctx, attraction.Version)
+
 	var images []*pb.Image
 
 	for _, i := range attraction.Images {
@@ -325,6 +325,7 @@ func (s establishmentRPC) UpdateAttraction(ctx context.Context, request *pb.Upda
 				Country:         attraction.Location.Country,
 				City:            attraction.Location.City,
 				StateProvince:   attraction.Location.StateProvince,
+				Category:        categoryCodes(attraction.Categories),
 				CreatedAt:       attraction.CreatedAt.String(),
 				UpdatedAt:       attraction.UpdatedAt.String(),
 			},
@@ -359,9 +360,9 @@ func (s establishmentRPC) ListAttractionsByLocation(ctx context.Context, request
 	)
 	defer span.End()
 
-	attractions, count, err := s.attracationUsecase.ListAttractionsByLocation(ctx, request.Offset, request.Limit, request.Country, request.City, request.StateProvince)
+	attractions, count, err := s.attracationUsecase.ListAttractionsByLocation(ctx, request.Offset, request.Limit, request.Country, request.City, request.StateProvince, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch attractions: %v", err)
+		return nil, err
 	}
 
 	var pbAttractions []*pb.Attraction
@@ -396,6 +397,7 @@ func (s establishmentRPC) ListAttractionsByLocation(ctx context.Context, request
 				Country:         attraction.Location.Country,
 				City:            attraction.Location.City,
 				StateProvince:   attraction.Location.StateProvince,
+				Category:        categoryCodes(attraction.Categories),
 				CreatedAt:       attraction.CreatedAt.String(),
 				UpdatedAt:       attraction.UpdatedAt.String(),
 			},
@@ -417,9 +419,9 @@ func (s establishmentRPC) FindAttractionsByName(ctx context.Context, request *pb
 	)
 	defer span.End()
 
-	attractions, overall, err := s.attracationUsecase.FindAttractionsByName(ctx, request.Name)
+	attractions, overall, err := s.attracationUsecase.FindAttractionsByName(ctx, request.Name, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch attractions: %v", err)
+		return nil, err
 	}
 
 	var pbAttractions []*pb.Attraction
@@ -454,6 +456,7 @@ func (s establishmentRPC) FindAttractionsByName(ctx context.Context, request *pb
 				Country:         attraction.Location.Country,
 				City:            attraction.Location.City,
 				StateProvince:   attraction.Location.StateProvince,
+				Category:        categoryCodes(attraction.Categories),
 				CreatedAt:       attraction.CreatedAt.String(),
 				UpdatedAt:       attraction.UpdatedAt.String(),
 			},
@@ -485,8 +488,6 @@ func (s establishmentRPC) CreateRestaurant(ctx context.Context, restaurant *pb.R
 		image.EstablishmentId = i.EstablishmentId
 		image.ImageUrl = i.ImageUrl
 		image.Category = i.Category
-		image.CreatedAt = time.Now().Local()
-		image.UpdatedAt = time.Now().Local()
 
 		images = append(images, &image)
 	}
@@ -500,22 +501,19 @@ func (s establishmentRPC) CreateRestaurant(ctx context.Context, restaurant *pb.R
 		ContactNumber:  restaurant.ContactNumber,
 		LicenceUrl:     restaurant.LicenceUrl,
 		WebsiteUrl:     restaurant.WebsiteUrl,
+		Categories:     categoriesFromContext(ctx),
 		Images:         images,
 		Location: entity.Location{
-			LocationId:      restaurant.Location.LocationId,
-			EstablishmentId: restaurant.Location.EstablishmentId,
-			Address:         restaurant.Location.Address,
-			Latitude:        restaurant.Location.Latitude,
-			Longitude:       restaurant.Location.Longitude,
-			Country:         restaurant.Location.Country,
-			City:            restaurant.Location.City,
-			StateProvince:   restaurant.Location.StateProvince,
-			Category:        restaurant.Location.Category,
-			CreatedAt:       time.Now().Local(),
-			UpdatedAt:       time.Now().Local(),
+			LocationId:      restaurant.GetLocation().GetLocationId(),
+			EstablishmentId: restaurant.GetLocation().GetEstablishmentId(),
+			Address:         restaurant.GetLocation().GetAddress(),
+			Latitude:        restaurant.GetLocation().GetLatitude(),
+			Longitude:       restaurant.GetLocation().GetLongitude(),
+			Country:         restaurant.GetLocation().GetCountry(),
+			City:            restaurant.GetLocation().GetCity(),
+			StateProvince:   restaurant.GetLocation().GetStateProvince(),
+			Category:        restaurant.GetLocation().GetCategory(),
 		},
-		CreatedAt: time.Now().Local(),
-		UpdatedAt: time.Now().Local(),
 	})
 	if err != nil {
 		return nil, err
@@ -555,6 +553,7 @@ func (s establishmentRPC) CreateRestaurant(ctx context.Context, restaurant *pb.R
 			Country:         response.Location.Country,
 			City:            response.Location.City,
 			StateProvince:   response.Location.StateProvince,
+			Category:        categoryCodes(response.Categories),
 			CreatedAt:       response.Location.CreatedAt.String(),
 			UpdatedAt:       response.Location.UpdatedAt.String(),
 		},
@@ -575,6 +574,8 @@ func (s establishmentRPC) GetRestaurant(ctx context.Context, request *pb.GetRest
 		return nil, err
 	}
 
+	setVersionHeader(ctx, restaurant.Version)
+
 	var images []*pb.Image
 
 	for _, i := range restaurant.Images {
@@ -610,6 +611,7 @@ func (s establishmentRPC) GetRestaurant(ctx context.Context, request *pb.GetRest
 				Country:         restaurant.Location.Country,
 				City:            restaurant.Location.City,
 				StateProvince:   restaurant.Location.StateProvince,
+				Category:        categoryCodes(restaurant.Categories),
 				CreatedAt:       restaurant.CreatedAt.String(),
 				UpdatedAt:       restaurant.UpdatedAt.String(),
 			},
@@ -626,9 +628,9 @@ func (s establishmentRPC) ListRestaurants(ctx context.Context, request *pb.ListR
 	)
 	defer span.End()
 
-	restaurants, overall, err := s.restaurantUsecase.ListRestaurants(ctx, request.Offset, request.Limit)
+	restaurants, overall, err := s.restaurantUsecase.ListRestaurants(ctx, request.Offset, request.Limit, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch restaurants: %v", err)
+		return nil, err
 	}
 
 	var pbRestaurants []*pb.Restaurant
@@ -664,6 +666,7 @@ func (s establishmentRPC) ListRestaurants(ctx context.Context, request *pb.ListR
 				Country:         restaurant.Location.Country,
 				City:            restaurant.Location.City,
 				StateProvince:   restaurant.Location.StateProvince,
+				Category:        categoryCodes(restaurant.Categories),
 				CreatedAt:       restaurant.CreatedAt.String(),
 				UpdatedAt:       restaurant.UpdatedAt.String(),
 			},
@@ -681,13 +684,13 @@ func (s establishmentRPC) ListRestaurants(ctx context.Context, request *pb.ListR
 func (s establishmentRPC) UpdateRestaurant(ctx context.Context, request *pb.UpdateRestaurantRequest) (*pb.UpdateRestaurantResponse, error) {
 	ctx, span := otlp.Start(ctx, "restaurant_grpc_delivery", "Update")
 	span.SetAttributes(
-		attribute.Key("restaurant_id").String(request.Restaurant.RestaurantId),
+		attribute.Key("restaurant_id").String(request.GetRestaurant().GetRestaurantId()),
 	)
 	defer span.End()
 
 	var imagesS []*entity.Image
 
-	for _, i := range request.Restaurant.Images {
+	for _, i := range request.GetRestaurant().GetImages() {
 		var image entity.Image
 
 		image.ImageId = i.ImageId
@@ -698,31 +701,34 @@ func (s establishmentRPC) UpdateRestaurant(ctx context.Context, request *pb.Upda
 	}
 
 	restaurant, err := s.restaurantUsecase.UpdateRestaurant(ctx, &entity.Restaurant{
-		RestaurantId:   request.Restaurant.RestaurantId,
-		OwnerId:        request.Restaurant.OwnerId,
-		RestaurantName: request.Restaurant.RestaurantName,
-		Description:    request.Restaurant.Description,
-		Rating:         request.Restaurant.Rating,
-		OpeningHours:   request.Restaurant.OpeningHours,
-		ContactNumber:  request.Restaurant.ContactNumber,
-		LicenceUrl:     request.Restaurant.LicenceUrl,
-		WebsiteUrl:     request.Restaurant.WebsiteUrl,
+		RestaurantId:   request.GetRestaurant().GetRestaurantId(),
+		OwnerId:        request.GetRestaurant().GetOwnerId(),
+		RestaurantName: request.GetRestaurant().GetRestaurantName(),
+		Description:    request.GetRestaurant().GetDescription(),
+		Rating:         request.GetRestaurant().GetRating(),
+		OpeningHours:   request.GetRestaurant().GetOpeningHours(),
+		ContactNumber:  request.GetRestaurant().GetContactNumber(),
+		LicenceUrl:     request.GetRestaurant().GetLicenceUrl(),
+		WebsiteUrl:     request.GetRestaurant().GetWebsiteUrl(),
+		Categories:     categoriesFromContext(ctx),
 		Images:         imagesS,
 		Location: entity.Location{
-			LocationId:      request.Restaurant.Location.LocationId,
-			EstablishmentId: request.Restaurant.Location.EstablishmentId,
-			Address:         request.Restaurant.Location.Address,
-			Latitude:        request.Restaurant.Location.Latitude,
-			Longitude:       request.Restaurant.Location.Longitude,
-			Country:         request.Restaurant.Location.Country,
-			City:            request.Restaurant.Location.City,
-			StateProvince:   request.Restaurant.Location.StateProvince,
+			LocationId:      request.GetRestaurant().GetLocation().GetLocationId(),
+			EstablishmentId: request.GetRestaurant().GetLocation().GetEstablishmentId(),
+			Address:         request.GetRestaurant().GetLocation().GetAddress(),
+			Latitude:        request.GetRestaurant().GetLocation().GetLatitude(),
+			Longitude:       request.GetRestaurant().GetLocation().GetLongitude(),
+			Country:         request.GetRestaurant().GetLocation().GetCountry(),
+			City:            request.GetRestaurant().GetLocation().GetCity(),
+			StateProvince:   request.GetRestaurant().GetLocation().GetStateProvince(),
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	setVersionHeader(ctx, restaurant.Version)
+
 	var images []*pb.Image
 
 	for _, i := range restaurant.Images {
@@ -757,6 +763,7 @@ func (s establishmentRPC) UpdateRestaurant(ctx context.Context, request *pb.Upda
 				Country:         restaurant.Location.Country,
 				City:            restaurant.Location.City,
 				StateProvince:   restaurant.Location.StateProvince,
+				Category:        categoryCodes(restaurant.Categories),
 				CreatedAt:       restaurant.CreatedAt.String(),
 				UpdatedAt:       restaurant.UpdatedAt.String(),
 			},
@@ -791,9 +798,9 @@ func (s establishmentRPC) ListRestaurantsByLocation(ctx context.Context, request
 	)
 	defer span.End()
 
-	restaurants, count, err := s.restaurantUsecase.ListRestaurantsByLocation(ctx, request.Offset, request.Limit, request.Country, request.City, request.StateProvince)
+	restaurants, count, err := s.restaurantUsecase.ListRestaurantsByLocation(ctx, request.Offset, request.Limit, request.Country, request.City, request.StateProvince, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch restaurants: %v", err)
+		return nil, err
 	}
 
 	var pbRestaurants []*pb.Restaurant
@@ -829,6 +836,7 @@ func (s establishmentRPC) ListRestaurantsByLocation(ctx context.Context, request
 				Country:         restaurant.Location.Country,
 				City:            restaurant.Location.City,
 				StateProvince:   restaurant.Location.StateProvince,
+				Category:        categoryCodes(restaurant.Categories),
 				CreatedAt:       restaurant.CreatedAt.String(),
 				UpdatedAt:       restaurant.UpdatedAt.String(),
 			},
@@ -850,9 +858,9 @@ func (s establishmentRPC) FindRestaurantsByName(ctx context.Context, request *pb
 	)
 	defer span.End()
 
-	restaurants, overall, err := s.restaurantUsecase.FindRestaurantsByName(ctx, request.Name)
+	restaurants, overall, err := s.restaurantUsecase.FindRestaurantsByName(ctx, request.Name, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch attractions: %v", err)
+		return nil, err
 	}
 
 	var pbRestaurants []*pb.Restaurant
@@ -888,6 +896,7 @@ func (s establishmentRPC) FindRestaurantsByName(ctx context.Context, request *pb
 				Country:         restaurant.Location.Country,
 				City:            restaurant.Location.City,
 				StateProvince:   restaurant.Location.StateProvince,
+				Category:        categoryCodes(restaurant.Categories),
 				CreatedAt:       restaurant.CreatedAt.String(),
 				UpdatedAt:       restaurant.UpdatedAt.String(),
 			},
@@ -919,8 +928,6 @@ func (s establishmentRPC) CreateHotel(ctx context.Context, hotel *pb.Hotel) (*pb
 		image.EstablishmentId = i.EstablishmentId
 		image.ImageUrl = i.ImageUrl
 		image.Category = i.Category
-		image.CreatedAt = time.Now().Local()
-		image.UpdatedAt = time.Now().Local()
 
 		images = append(images, &image)
 	}
@@ -933,22 +940,19 @@ func (s establishmentRPC) CreateHotel(ctx context.Context, hotel *pb.Hotel) (*pb
 		ContactNumber: hotel.ContactNumber,
 		LicenceUrl:    hotel.LicenceUrl,
 		WebsiteUrl:    hotel.WebsiteUrl,
+		Categories:    categoriesFromContext(ctx),
 		Images:        images,
 		Location: entity.Location{
-			LocationId:      hotel.Location.LocationId,
-			EstablishmentId: hotel.Location.EstablishmentId,
-			Address:         hotel.Location.Address,
-			Latitude:        hotel.Location.Latitude,
-			Longitude:       hotel.Location.Longitude,
-			Country:         hotel.Location.Country,
-			City:            hotel.Location.City,
-			StateProvince:   hotel.Location.StateProvince,
-			Category:        hotel.Location.Category,
-			CreatedAt:       time.Now().Local(),
-			UpdatedAt:       time.Now().Local(),
+			LocationId:      hotel.GetLocation().GetLocationId(),
+			EstablishmentId: hotel.GetLocation().GetEstablishmentId(),
+			Address:         hotel.GetLocation().GetAddress(),
+			Latitude:        hotel.GetLocation().GetLatitude(),
+			Longitude:       hotel.GetLocation().GetLongitude(),
+			Country:         hotel.GetLocation().GetCountry(),
+			City:            hotel.GetLocation().GetCity(),
+			StateProvince:   hotel.GetLocation().GetStateProvince(),
+			Category:        hotel.GetLocation().GetCategory(),
 		},
-		CreatedAt: time.Now().Local(),
-		UpdatedAt: time.Now().Local(),
 	})
 	if err != nil {
 		return nil, err
@@ -987,6 +991,7 @@ func (s establishmentRPC) CreateHotel(ctx context.Context, hotel *pb.Hotel) (*pb
 			Country:         response.Location.Country,
 			City:            response.Location.City,
 			StateProvince:   response.Location.StateProvince,
+			Category:        categoryCodes(response.Categories),
 			CreatedAt:       response.Location.CreatedAt.String(),
 			UpdatedAt:       response.Location.UpdatedAt.String(),
 		},
@@ -1007,6 +1012,8 @@ func (s establishmentRPC) GetHotel(ctx context.Context, request *pb.GetHotelRequ
 		return nil, err
 	}
 
+	setVersionHeader(ctx, hotel.Version)
+
 	var images []*pb.Image
 
 	for _, i := range hotel.Images {
@@ -1041,6 +1048,7 @@ func (s establishmentRPC) GetHotel(ctx context.Context, request *pb.GetHotelRequ
 				Country:         hotel.Location.Country,
 				City:            hotel.Location.City,
 				StateProvince:   hotel.Location.StateProvince,
+				Category:        categoryCodes(hotel.Categories),
 				CreatedAt:       hotel.CreatedAt.String(),
 				UpdatedAt:       hotel.UpdatedAt.String(),
 			},
@@ -1057,9 +1065,9 @@ func (s establishmentRPC) ListHotels(ctx context.Context, request *pb.ListHotels
 	)
 	defer span.End()
 
-	hotels, overall, err := s.hotelUsecase.ListHotels(ctx, request.Offset, request.Limit)
+	hotels, overall, err := s.hotelUsecase.ListHotels(ctx, request.Offset, request.Limit, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch hotels: %v", err)
+		return nil, err
 	}
 
 	// Convert []*entity.Hotel to []*pb.Hotel
@@ -1095,6 +1103,7 @@ func (s establishmentRPC) ListHotels(ctx context.Context, request *pb.ListHotels
 				Country:         hotel.Location.Country,
 				City:            hotel.Location.City,
 				StateProvince:   hotel.Location.StateProvince,
+				Category:        categoryCodes(hotel.Categories),
 				CreatedAt:       hotel.CreatedAt.String(),
 				UpdatedAt:       hotel.UpdatedAt.String(),
 			},
@@ -1112,7 +1121,7 @@ func (s establishmentRPC) ListHotels(ctx context.Context, request *pb.ListHotels
 func (s establishmentRPC) UpdateHotel(ctx context.Context, request *pb.UpdateHotelRequest) (*pb.UpdateHotelResponse, error) {
 	ctx, span := otlp.Start(ctx, "hotel_grpc_delivery", "Update")
 	span.SetAttributes(
-		attribute.Key("hotel_id").String(request.Hotel.HotelId),
+		attribute.Key("hotel_id").String(request.GetHotel().GetHotelId()),
 	)
 	defer span.End()
 
@@ -1129,30 +1138,33 @@ func (s establishmentRPC) UpdateHotel(ctx context.Context, request *pb.UpdateHot
 	// }
 
 	hotel, err := s.hotelUsecase.UpdateHotel(ctx, &entity.Hotel{
-		HotelId:       request.Hotel.HotelId,
-		OwnerId:       request.Hotel.OwnerId,
-		HotelName:     request.Hotel.HotelName,
-		Description:   request.Hotel.Description,
-		Rating:        request.Hotel.Rating,
-		ContactNumber: request.Hotel.ContactNumber,
-		LicenceUrl:    request.Hotel.LicenceUrl,
-		WebsiteUrl:    request.Hotel.WebsiteUrl,
+		HotelId:       request.GetHotel().GetHotelId(),
+		OwnerId:       request.GetHotel().GetOwnerId(),
+		HotelName:     request.GetHotel().GetHotelName(),
+		Description:   request.GetHotel().GetDescription(),
+		Rating:        request.GetHotel().GetRating(),
+		ContactNumber: request.GetHotel().GetContactNumber(),
+		LicenceUrl:    request.GetHotel().GetLicenceUrl(),
+		WebsiteUrl:    request.GetHotel().GetWebsiteUrl(),
+		Categories:    categoriesFromContext(ctx),
 		// Images:        imagesS,
 		Location: entity.Location{
-			LocationId:      request.Hotel.Location.LocationId,
-			EstablishmentId: request.Hotel.Location.EstablishmentId,
-			Address:         request.Hotel.Location.Address,
-			Latitude:        request.Hotel.Location.Latitude,
-			Longitude:       request.Hotel.Location.Longitude,
-			Country:         request.Hotel.Location.Country,
-			City:            request.Hotel.Location.City,
-			StateProvince:   request.Hotel.Location.StateProvince,
+			LocationId:      request.GetHotel().GetLocation().GetLocationId(),
+			EstablishmentId: request.GetHotel().GetLocation().GetEstablishmentId(),
+			Address:         request.GetHotel().GetLocation().GetAddress(),
+			Latitude:        request.GetHotel().GetLocation().GetLatitude(),
+			Longitude:       request.GetHotel().GetLocation().GetLongitude(),
+			Country:         request.GetHotel().GetLocation().GetCountry(),
+			City:            request.GetHotel().GetLocation().GetCity(),
+			StateProvince:   request.GetHotel().GetLocation().GetStateProvince(),
 		},
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	setVersionHeader(ctx, hotel.Version)
+
 	var images []*pb.Image
 
 	for _, i := range hotel.Images {
@@ -1187,6 +1199,7 @@ func (s establishmentRPC) UpdateHotel(ctx context.Context, request *pb.UpdateHot
 				Country:         hotel.Location.Country,
 				City:            hotel.Location.City,
 				StateProvince:   hotel.Location.StateProvince,
+				Category:        categoryCodes(hotel.Categories),
 				CreatedAt:       hotel.CreatedAt.String(),
 				UpdatedAt:       hotel.UpdatedAt.String(),
 			},
@@ -1221,9 +1234,9 @@ func (s establishmentRPC) ListHotelsByLocation(ctx context.Context, request *pb.
 	)
 	defer span.End()
 
-	hotels, count, err := s.hotelUsecase.ListHotelsByLocation(ctx, request.Offset, request.Limit, request.Country, request.City, request.StateProvince)
+	hotels, count, err := s.hotelUsecase.ListHotelsByLocation(ctx, request.Offset, request.Limit, request.Country, request.City, request.StateProvince, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch hotels: %v", err)
+		return nil, err
 	}
 
 	var pbHotels []*pb.Hotel
@@ -1258,6 +1271,7 @@ func (s establishmentRPC) ListHotelsByLocation(ctx context.Context, request *pb.
 				Country:         hotel.Location.Country,
 				City:            hotel.Location.City,
 				StateProvince:   hotel.Location.StateProvince,
+				Category:        categoryCodes(hotel.Categories),
 				CreatedAt:       hotel.CreatedAt.String(),
 				UpdatedAt:       hotel.UpdatedAt.String(),
 			},
@@ -1279,9 +1293,9 @@ func (s establishmentRPC) FindHotelsByName(ctx context.Context, request *pb.Find
 	)
 	defer span.End()
 
-	hotels, overall, err := s.hotelUsecase.FindHotelsByName(ctx, request.Name)
+	hotels, overall, err := s.hotelUsecase.FindHotelsByName(ctx, request.Name, filterFromContext(ctx))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch hotels: %v", err)
+		return nil, err
 	}
 
 	var pbHotels []*pb.Hotel
@@ -1316,6 +1330,7 @@ func (s establishmentRPC) FindHotelsByName(ctx context.Context, request *pb.Find
 				Country:         hotel.Location.Country,
 				City:            hotel.Location.City,
 				StateProvince:   hotel.Location.StateProvince,
+				Category:        categoryCodes(hotel.Categories),
 				CreatedAt:       hotel.CreatedAt.String(),
 				UpdatedAt:       hotel.UpdatedAt.String(),
 			},
@@ -1334,16 +1349,14 @@ func (s establishmentRPC) FindHotelsByName(ctx context.Context, request *pb.Find
 func (s establishmentRPC) AddToFavourites(ctx context.Context, request *pb.AddToFavouritesRequest) (*pb.AddToFavouritesResponse, error) {
 	ctx, span := otlp.Start(ctx, "favourite_grpc_delivery", "Create")
 	span.SetAttributes(
-		attribute.Key("favourite_id").String(request.Favourite.FavouriteId),
+		attribute.Key("favourite_id").String(request.GetFavourite().GetFavouriteId()),
 	)
 	defer span.End()
 
 	response, err := s.favouriteUsecase.AddToFavourites(ctx, &entity.Favourite{
-		FavouriteId:     request.Favourite.FavouriteId,
-		EstablishmentId: request.Favourite.EstablishmentId,
-		UserId:          request.Favourite.UserId,
-		CreatedAt:       time.Now().Local(),
-		UpdatedAt:       time.Now().Local(),
+		FavouriteId:     request.GetFavourite().GetFavouriteId(),
+		EstablishmentId: request.GetFavourite().GetEstablishmentId(),
+		UserId:          request.GetFavourite().GetUserId(),
 	})
 	if err != nil {
 		return nil, err
@@ -1413,18 +1426,16 @@ func (s establishmentRPC) ListFavouritesByUserId(ctx context.Context, request *p
 func (s establishmentRPC) CreateReview(ctx context.Context, request *pb.CreateReviewRequest) (*pb.CreateReviewResponse, error) {
 	ctx, span := otlp.Start(ctx, "review_grpc_delivery", "Create")
 	span.SetAttributes(
-		attribute.Key("review_id").String(request.Review.ReviewId),
+		attribute.Key("review_id").String(request.GetReview().GetReviewId()),
 	)
 	defer span.End()
 
 	response, err := s.reviewUsecase.CreateReview(ctx, &entity.Review{
-		ReviewId:        request.Review.ReviewId,
-		EstablishmentId: request.Review.EstablishmentId,
-		UserId:          request.Review.UserId,
-		Rating:          float64(request.Review.Rating),
-		Comment:         request.Review.Comment,
-		CreatedAt:       time.Now().Local(),
-		UpdatedAt:       time.Now().Local(),
+		ReviewId:        request.GetReview().GetReviewId(),
+		EstablishmentId: request.GetReview().GetEstablishmentId(),
+		UserId:          request.GetReview().GetUserId(),
+		Rating:          float64(request.GetReview().GetRating()),
+		Comment:         request.GetReview().GetComment(),
 	})
 	if err != nil {
 		return nil, err
@@ -1508,9 +1519,6 @@ func (s establishmentRPC) CreateMedia(ctx context.Context, image *pb.Image) (*pb
 		EstablishmentId: image.EstablishmentId,
 		ImageUrl:        image.ImageUrl,
 		Category:        image.Category,
-		CreatedAt:       time.Now().Local(),
-		UpdatedAt:       time.Now().Local(),
-		DeletedAt:       time.Now().Local(),
 	})
 
 	if err != nil {
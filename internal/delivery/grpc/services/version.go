@@ -0,0 +1,17 @@
+package services
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// setVersionHeader sends the version of the establishment in the response
+// header, clients send it back in the metadata of updates and deletes
+func setVersionHeader(ctx context.Context, version int64) {
+	// no stream is attached when the handler is called directly
+	_ = grpc.SetHeader(ctx, metadata.Pairs(app.MetadataKeyVersion, strconv.FormatInt(version, 10)))
+}
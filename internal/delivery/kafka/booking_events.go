@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	booking "Booking/establishment-service-booking/genproto/booking-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/usecase"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BookingEvents handles the bookings created and canceled in the booking
+// service, a message holds the booking as a JSON encoded GeneralBook
+type BookingEvents struct {
+	logger     *zap.Logger
+	popularity usecase.Popularity
+}
+
+func NewBookingEvents(logger *zap.Logger, popularity usecase.Popularity) *BookingEvents {
+	return &BookingEvents{
+		logger:     logger,
+		popularity: popularity,
+	}
+}
+
+func (b *BookingEvents) Handle(ctx context.Context, key, value []byte) error {
+	var book booking.GeneralBook
+	if err := json.Unmarshal(value, &book); err != nil {
+		return event.Permanent(fmt.Errorf("failed to decode booking: %w", err))
+	}
+
+	bookedAt, err := parseBookingTime(book.CreatedAt)
+	if err != nil {
+		return event.Permanent(err)
+	}
+
+	if err := b.popularity.RecordBooking(ctx, &entity.Booking{
+		BookingId:       book.Id,
+		EstablishmentId: book.HraId,
+		UserId:          book.UserId,
+		Canceled:        book.IsCanceled,
+		BookedAt:        bookedAt,
+	}); err != nil {
+		var errValidation *entity.ErrValidation
+		if errors.As(err, &errValidation) {
+			return event.Permanent(err)
+		}
+		return err
+	}
+
+	b.logger.Debug("booking event handled", zap.String("booking_id", book.Id), zap.Bool("canceled", book.IsCanceled))
+	return nil
+}
+
+// parseBookingTime returns when the booking was made, now when it is not sent
+func parseBookingTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now().UTC(), nil
+	}
+	bookedAt, err := entity.ParseBookingTime(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid created_at of booking: %w", err)
+	}
+	return bookedAt, nil
+}
@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type popularityFunc func(ctx context.Context, booking *entity.Booking) error
+
+func (f popularityFunc) RecordBooking(ctx context.Context, booking *entity.Booking) error {
+	return f(ctx, booking)
+}
+
+func (f popularityFunc) CountBookings(ctx context.Context, establishment_id string, window time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func TestBookingEvents(t *testing.T) {
+	var recorded *entity.Booking
+	handler := NewBookingEvents(zap.NewNop(), popularityFunc(func(ctx context.Context, booking *entity.Booking) error {
+		recorded = booking
+		return booking.Validate()
+	}))
+	ctx := context.Background()
+
+	err := handler.Handle(ctx, nil, []byte(`{"id":"b1","user_id":"u1","hra_id":"7d4f5a1e-2c7b-4f1e-9a55-3c2b1d0e9f10","is_canceled":true,"created_at":"2024-05-01 10:00:00"}`))
+	assert.NoError(t, err)
+	if assert.NotNil(t, recorded) {
+		assert.Equal(t, "b1", recorded.BookingId)
+		assert.Equal(t, "7d4f5a1e-2c7b-4f1e-9a55-3c2b1d0e9f10", recorded.EstablishmentId)
+		assert.True(t, recorded.Canceled)
+		assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), recorded.BookedAt)
+	}
+
+	// broken and invalid bookings are not retried
+	err = handler.Handle(ctx, nil, []byte(`[]`))
+	assert.True(t, event.IsPermanent(err))
+
+	err = handler.Handle(ctx, nil, []byte(`{"id":"b2","hra_id":"7d4f5a1e-2c7b-4f1e-9a55-3c2b1d0e9f10","created_at":"yesterday"}`))
+	assert.True(t, event.IsPermanent(err))
+
+	err = handler.Handle(ctx, nil, []byte(`{"id":"b3","hra_id":"hotel"}`))
+	assert.True(t, event.IsPermanent(err))
+}
@@ -0,0 +1,46 @@
+package kafka
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/usecase"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// UserEvents handles the events of the user service
+type UserEvents struct {
+	logger  *zap.Logger
+	account usecase.Account
+}
+
+func NewUserEvents(logger *zap.Logger, account usecase.Account) *UserEvents {
+	return &UserEvents{
+		logger:  logger,
+		account: account,
+	}
+}
+
+// Handle decodes an event, events that cannot be decoded or are invalid go
+// to the dead letter topic without retries
+func (u *UserEvents) Handle(ctx context.Context, key, value []byte) error {
+	var userEvent entity.UserEvent
+	if err := json.Unmarshal(value, &userEvent); err != nil {
+		return event.Permanent(fmt.Errorf("failed to decode user event: %w", err))
+	}
+
+	if err := u.account.HandleUserEvent(ctx, &userEvent); err != nil {
+		var errValidation *entity.ErrValidation
+		if errors.As(err, &errValidation) {
+			return event.Permanent(err)
+		}
+		return err
+	}
+
+	u.logger.Debug("user event handled", zap.String("event_id", userEvent.EventId), zap.String("type", userEvent.Type))
+	return nil
+}
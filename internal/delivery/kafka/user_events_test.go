@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type accountFunc func(ctx context.Context, event *entity.UserEvent) error
+
+func (f accountFunc) HandleUserEvent(ctx context.Context, event *entity.UserEvent) error {
+	return f(ctx, event)
+}
+
+func TestUserEvents(t *testing.T) {
+	var handled *entity.UserEvent
+	handler := NewUserEvents(zap.NewNop(), accountFunc(func(ctx context.Context, userEvent *entity.UserEvent) error {
+		handled = userEvent
+		return userEvent.Validate()
+	}))
+	ctx := context.Background()
+
+	err := handler.Handle(ctx, nil, []byte(`{"event_id":"e1","type":"user.deleted","user_id":"5f0d4a4e-6b8c-4c47-9d3a-0d6d3b1f2a10","occurred_at":"2024-05-01T10:00:00Z"}`))
+	assert.NoError(t, err)
+	if assert.NotNil(t, handled) {
+		assert.Equal(t, "e1", handled.EventId)
+		assert.Equal(t, entity.UserEventDeleted, handled.Type)
+		assert.True(t, handled.RemovesAccount())
+	}
+
+	// broken and invalid events are not retried
+	err = handler.Handle(ctx, nil, []byte(`{"event_id":`))
+	assert.True(t, event.IsPermanent(err))
+
+	err = handler.Handle(ctx, nil, []byte(`{"event_id":"e2","type":"user.deleted","user_id":"not a uuid"}`))
+	assert.True(t, event.IsPermanent(err))
+}
+
+func TestUserEventsRetry(t *testing.T) {
+	handler := NewUserEvents(zap.NewNop(), accountFunc(func(ctx context.Context, userEvent *entity.UserEvent) error {
+		return errors.New("db is down")
+	}))
+
+	err := handler.Handle(context.Background(), nil, []byte(`{"event_id":"e1","type":"user.deleted","user_id":"5f0d4a4e-6b8c-4c47-9d3a-0d6d3b1f2a10"}`))
+	assert.Error(t, err)
+	assert.False(t, event.IsPermanent(err))
+}
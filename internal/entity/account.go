@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// events of the user service about accounts
+const (
+	UserEventDeleted     = "user.deleted"
+	UserEventDeactivated = "user.deactivated"
+
+	// AnonymousUserId replaces the author of reviews of removed accounts
+	AnonymousUserId = "00000000-0000-0000-0000-000000000000"
+)
+
+// UserEvent is an event of the user service, its id makes handling it twice
+// a no-op
+type UserEvent struct {
+	EventId    string    `json:"event_id"`
+	Type       string    `json:"type"`
+	UserId     string    `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// RemovesAccount reports if the user is gone, their establishments are
+// deleted, reviews anonymised and favourites dropped
+func (e *UserEvent) RemovesAccount() bool {
+	return e.Type == UserEventDeleted || e.Type == UserEventDeactivated
+}
+
+// EstablishmentRef points to an establishment of any type
+type EstablishmentRef struct {
+	EstablishmentType string
+	EstablishmentId   string
+	OwnerId           string
+}
@@ -11,17 +11,21 @@ type Attraction struct {
 	ContactNumber  string
 	LicenceUrl     string
 	WebsiteUrl     string
+	Categories     []*Category
+	Prices         []*Price
 	Images         []*Image
 	Location       Location
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	DeletedAt      time.Time
+	Version        int64
 }
 
 type Image struct {
 	ImageId         string
 	EstablishmentId string
 	ImageUrl        string
+	Caption         string
 	Category        string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
@@ -0,0 +1,160 @@
+package entity
+
+import (
+	"reflect"
+	"time"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	EntityTypeReview    = "review"
+	EntityTypeFavourite = "favourite"
+)
+
+// AuditEvent is an append-only record of a change made to an establishment
+// or to an object attached to it
+type AuditEvent struct {
+	EventId         string
+	EstablishmentId string
+	EntityType      string
+	EntityId        string
+	Action          string
+	ActorId         string
+	Method          string
+	Changes         map[string]FieldChange
+	CreatedAt       time.Time
+}
+
+// FieldChange holds the value of a field before and after a change, nil
+// before a create and after a delete
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditFilter narrows audit events down, empty fields match every event
+type AuditFilter struct {
+	EstablishmentId string
+	ActorId         string
+	From            time.Time
+	To              time.Time
+}
+
+// Diff returns the fields whose values differ between the audit fields of
+// an object before and after a change, a nil map stands for no object
+func Diff(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for field, value := range before {
+		if other, ok := after[field]; !ok || !reflect.DeepEqual(value, other) {
+			changes[field] = FieldChange{Before: value, After: other}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = FieldChange{After: value}
+		}
+	}
+	return changes
+}
+
+// locationAuditFields names the fields of the location under "location."
+// as field masks do
+func locationAuditFields(fields map[string]interface{}, l *Location) {
+	fields[FieldMaskLocation+".address"] = l.Address
+	fields[FieldMaskLocation+".latitude"] = l.Latitude
+	fields[FieldMaskLocation+".longitude"] = l.Longitude
+	fields[FieldMaskLocation+".country"] = l.Country
+	fields[FieldMaskLocation+".city"] = l.City
+	fields[FieldMaskLocation+".state_province"] = l.StateProvince
+}
+
+func categoryCodes(categories []*Category) []string {
+	codes := make([]string, 0, len(categories))
+	for _, category := range categories {
+		codes = append(codes, category.Code)
+	}
+	return codes
+}
+
+func imageUrls(images []*Image) []string {
+	urls := make([]string, 0, len(images))
+	for _, image := range images {
+		urls = append(urls, image.ImageUrl)
+	}
+	return urls
+}
+
+// AuditFields returns the fields of the hotel tracked by the audit log
+func (h *Hotel) AuditFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"hotel_name":        h.HotelName,
+		"owner_id":          h.OwnerId,
+		"description":       h.Description,
+		"rating":            h.Rating,
+		"contact_number":    h.ContactNumber,
+		"licence_url":       h.LicenceUrl,
+		"website_url":       h.WebsiteUrl,
+		FieldMaskCategories: categoryCodes(h.Categories),
+		"images":            imageUrls(h.Images),
+	}
+	locationAuditFields(fields, &h.Location)
+	return fields
+}
+
+func (r *Restaurant) AuditFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"restaurant_name":   r.RestaurantName,
+		"owner_id":          r.OwnerId,
+		"description":       r.Description,
+		"rating":            r.Rating,
+		"opening_hours":     r.OpeningHours,
+		"contact_number":    r.ContactNumber,
+		"licence_url":       r.LicenceUrl,
+		"website_url":       r.WebsiteUrl,
+		FieldMaskCategories: categoryCodes(r.Categories),
+		"images":            imageUrls(r.Images),
+	}
+	locationAuditFields(fields, &r.Location)
+	return fields
+}
+
+func (a *Attraction) AuditFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"attraction_name":   a.AttractionName,
+		"owner_id":          a.OwnerId,
+		"description":       a.Description,
+		"rating":            a.Rating,
+		"contact_number":    a.ContactNumber,
+		"licence_url":       a.LicenceUrl,
+		"website_url":       a.WebsiteUrl,
+		FieldMaskCategories: categoryCodes(a.Categories),
+		"images":            imageUrls(a.Images),
+	}
+	locationAuditFields(fields, &a.Location)
+	return fields
+}
+
+func (i *Image) AuditFields() map[string]interface{} {
+	return map[string]interface{}{
+		"image_url": i.ImageUrl,
+		"caption":   i.Caption,
+		"category":  i.Category,
+	}
+}
+
+func (r *Review) AuditFields() map[string]interface{} {
+	return map[string]interface{}{
+		"user_id": r.UserId,
+		"rating":  r.Rating,
+		"comment": r.Comment,
+	}
+}
+
+func (f *Favourite) AuditFields() map[string]interface{} {
+	return map[string]interface{}{
+		"user_id": f.UserId,
+	}
+}
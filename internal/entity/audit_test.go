@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	before := validHotel()
+	after := validHotel()
+	after.ContactNumber = "+998 (90) 765-43-21"
+	after.Location.City = "Samarkand"
+	after.Categories = []*Category{{Code: "spa"}}
+
+	assert.Equal(t, map[string]FieldChange{
+		"contact_number": {Before: before.ContactNumber, After: after.ContactNumber},
+		"location.city":  {Before: "Tashkent", After: "Samarkand"},
+		"categories":     {Before: []string{}, After: []string{"spa"}},
+		"owner_id":       {Before: before.OwnerId, After: after.OwnerId},
+	}, Diff(before.AuditFields(), after.AuditFields()))
+
+	// unchanged objects have no changes
+	assert.Empty(t, Diff(before.AuditFields(), before.AuditFields()))
+
+	// a create has no values before and a delete none after
+	review := &Review{UserId: "user", Rating: 5, Comment: "great"}
+	assert.Equal(t, FieldChange{After: "great"}, Diff(nil, review.AuditFields())["comment"])
+	assert.Equal(t, FieldChange{Before: 5.0}, Diff(review.AuditFields(), nil)["rating"])
+}
+
+func TestAuditFilterValidate(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, (&AuditFilter{}).Validate())
+	assert.NoError(t, (&AuditFilter{From: now.Add(-time.Hour), To: now}).Validate())
+
+	assert.Equal(t, map[string]string{
+		"establishment_id": "must be a valid UUID",
+		"actor_id":         "must be a valid UUID",
+		"to":               "must not be before from",
+	}, violations(t, (&AuditFilter{EstablishmentId: "hotel", ActorId: "admin", From: now, To: now.Add(-time.Hour)}).Validate()))
+}
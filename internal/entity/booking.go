@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// PopularityWindow is the rolling window of bookings the popularity order
+// counts
+const PopularityWindow = 30 * 24 * time.Hour
+
+// layouts of the times the booking service sends
+var bookingTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// Booking is a booking of an establishment made in the booking service, it
+// counts towards the popularity of the establishment on the day it was made
+// unless it is canceled
+type Booking struct {
+	BookingId       string
+	EstablishmentId string
+	UserId          string
+	Canceled        bool
+	BookedAt        time.Time
+
+	// the stay as the booking service describes it, not stored
+	ArriveAt time.Time
+	LeaveAt  time.Time
+	Guests   int64
+}
+
+// BookingSummary counts the bookings of an establishment that are not over
+type BookingSummary struct {
+	EstablishmentId string
+	Upcoming        int64
+	UpcomingGuests  int64
+	Current         int64
+	CurrentGuests   int64
+	Canceled        int64
+	At              time.Time
+}
+
+// ParseBookingTime parses a time sent by the booking service
+func ParseBookingTime(value string) (time.Time, error) {
+	for _, layout := range bookingTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid booking time: %q", value)
+}
+
+// leaveAt is when the stay ends, bookings without one (tables, tickets) end
+// with the day of the arrival
+func (b *Booking) leaveAt() time.Time {
+	if !b.LeaveAt.IsZero() {
+		return b.LeaveAt
+	}
+	year, month, day := b.ArriveAt.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, b.ArriveAt.Location())
+}
+
+// SummariseBookings counts the bookings at the given time: stays not begun
+// are upcoming, begun ones are current, past stays are left out
+func SummariseBookings(establishment_id string, bookings []*Booking, at time.Time) *BookingSummary {
+	summary := &BookingSummary{EstablishmentId: establishment_id, At: at}
+	for _, booking := range bookings {
+		switch {
+		case !at.Before(booking.leaveAt()):
+		case booking.Canceled:
+			summary.Canceled++
+		case at.Before(booking.ArriveAt):
+			summary.Upcoming++
+			summary.UpcomingGuests += booking.Guests
+		default:
+			summary.Current++
+			summary.CurrentGuests += booking.Guests
+		}
+	}
+	return summary
+}
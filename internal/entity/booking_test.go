@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummariseBookings(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+
+	summary := SummariseBookings("h1", []*Booking{
+		// upcoming
+		{ArriveAt: day(12), LeaveAt: day(14), Guests: 2},
+		{ArriveAt: day(11), Guests: 3},
+		// current, a booking without a leave time lasts the day of arrival
+		{ArriveAt: day(8), LeaveAt: day(11), Guests: 4},
+		{ArriveAt: day(10), Guests: 1},
+		// canceled and not over
+		{ArriveAt: day(12), LeaveAt: day(14), Guests: 2, Canceled: true},
+		// over
+		{ArriveAt: day(1), LeaveAt: day(3), Guests: 5},
+		{ArriveAt: day(9), Guests: 5},
+		{ArriveAt: day(1), LeaveAt: day(3), Guests: 5, Canceled: true},
+	}, now)
+
+	assert.Equal(t, &BookingSummary{
+		EstablishmentId: "h1",
+		Upcoming:        2,
+		UpcomingGuests:  5,
+		Current:         2,
+		CurrentGuests:   5,
+		Canceled:        1,
+		At:              now,
+	}, summary)
+}
+
+func TestParseBookingTime(t *testing.T) {
+	for _, value := range []string{"2024-05-01T10:00:00Z", "2024-05-01 10:00:00", "2024-05-01T10:00:00"} {
+		at, err := ParseBookingTime(value)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), at)
+	}
+
+	at, err := ParseBookingTime("2024-05-01")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), at)
+
+	_, err = ParseBookingTime("tomorrow")
+	assert.Error(t, err)
+}
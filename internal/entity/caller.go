@@ -0,0 +1,27 @@
+package entity
+
+const (
+	RoleUser  = "user"
+	RoleOwner = "owner"
+	RoleAdmin = "admin"
+)
+
+// Caller is the authenticated user a request is made on behalf of
+type Caller struct {
+	UserId string
+	Role   string
+}
+
+// IsAdmin reports whether the caller may change any establishment
+func (c *Caller) IsAdmin() bool {
+	return c != nil && c.Role == RoleAdmin
+}
+
+// IsRole reports whether the role is one of the known roles
+func IsRole(role string) bool {
+	switch role {
+	case RoleUser, RoleOwner, RoleAdmin:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+const (
+	EstablishmentTypeHotel      = "hotel"
+	EstablishmentTypeRestaurant = "restaurant"
+	EstablishmentTypeAttraction = "attraction"
+
+	DefaultLocale = "en"
+)
+
+type Category struct {
+	CategoryId        string
+	ParentId          string
+	EstablishmentType string
+	Code              string
+	Labels            map[string]string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         time.Time
+}
+
+// Label returns the label of the category in the given locale,
+// falling back to the default locale and finally to the code
+func (c *Category) Label(locale string) string {
+	if label, ok := c.Labels[locale]; ok && label != "" {
+		return label
+	}
+	if label, ok := c.Labels[DefaultLocale]; ok && label != "" {
+		return label
+	}
+	return c.Code
+}
+
+// orders of the list methods, by rating when none is given
+const (
+	SortRating     = "rating"
+	SortPopularity = "popularity"
+)
+
+// Filter holds optional conditions shared by the list methods
+type Filter struct {
+	Categories []string
+	Sort       string
+}
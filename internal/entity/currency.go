@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// BaseCurrency is the currency every rate is quoted against
+	BaseCurrency = "USD"
+
+	PriceItemRoom   = "room"
+	PriceItemTicket = "ticket"
+	PriceItemMenu   = "menu"
+)
+
+// currencyMinorUnits lists currencies whose minor unit differs from cents
+var currencyMinorUnits = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"BHD": 3,
+	"OMR": 3,
+}
+
+// Money is an amount in minor units (e.g. cents) of an ISO 4217 currency
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+type Price struct {
+	PriceId         string
+	EstablishmentId string
+	ItemType        string
+	Name            string
+	Price           Money
+	// Converted is the price in the requested currency, nil if none was requested
+	Converted *Money
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt time.Time
+}
+
+// CurrencyRate is the amount of the currency bought by one BaseCurrency,
+// kept as a decimal string so no precision is lost
+type CurrencyRate struct {
+	Currency  string
+	Rate      string
+	UpdatedAt time.Time
+}
+
+// IsCurrencyCode reports whether the code looks like an ISO 4217 code
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// MinorUnits returns the number of decimal digits of the currency's minor unit
+func MinorUnits(currency string) int {
+	if digits, ok := currencyMinorUnits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// Rates is the conversion table keyed by currency code
+type Rates map[string]*big.Rat
+
+func NewRates(rates []*CurrencyRate) (Rates, error) {
+	table := Rates{BaseCurrency: big.NewRat(1, 1)}
+	for _, rate := range rates {
+		r, ok := new(big.Rat).SetString(rate.Rate)
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q of currency %s", rate.Rate, rate.Currency)
+		}
+		table[rate.Currency] = r
+	}
+	return table, nil
+}
+
+// Convert converts money into the currency. The exact result is rounded
+// half away from zero to the minor unit of the target currency.
+func (r Rates) Convert(money Money, currency string) (Money, error) {
+	if money.Currency == currency {
+		return money, nil
+	}
+
+	from, ok := r[money.Currency]
+	if !ok {
+		return Money{}, NewErrNotFound("currency rate " + money.Currency)
+	}
+	to, ok := r[currency]
+	if !ok {
+		return Money{}, NewErrNotFound("currency rate " + currency)
+	}
+
+	// minor units -> major units of the source currency
+	amount := new(big.Rat).SetFrac(big.NewInt(money.Amount), pow10(MinorUnits(money.Currency)))
+	// source -> base -> target currency
+	amount.Quo(amount, from)
+	amount.Mul(amount, to)
+	// major units -> minor units of the target currency
+	amount.Mul(amount, new(big.Rat).SetInt(pow10(MinorUnits(currency))))
+
+	return Money{Amount: RoundHalfAwayFromZero(amount), Currency: currency}, nil
+}
+
+// RoundHalfAwayFromZero rounds to the nearest integer, ties go away from zero
+// (2.5 -> 3, -2.5 -> -3)
+func RoundHalfAwayFromZero(x *big.Rat) int64 {
+	num := new(big.Int).Abs(x.Num())
+	den := x.Denom()
+
+	// floor(|x| + 1/2) = floor((2*num + den) / (2*den))
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	result := num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+
+	if x.Sign() < 0 {
+		result.Neg(result)
+	}
+	return result.Int64()
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundHalfAwayFromZero(t *testing.T) {
+	assert.Equal(t, int64(2), RoundHalfAwayFromZero(big.NewRat(249, 100)))
+	assert.Equal(t, int64(3), RoundHalfAwayFromZero(big.NewRat(5, 2)))
+	assert.Equal(t, int64(-3), RoundHalfAwayFromZero(big.NewRat(-5, 2)))
+	assert.Equal(t, int64(-2), RoundHalfAwayFromZero(big.NewRat(-249, 100)))
+	assert.Equal(t, int64(7), RoundHalfAwayFromZero(big.NewRat(7, 1)))
+	assert.Equal(t, int64(0), RoundHalfAwayFromZero(big.NewRat(0, 1)))
+}
+
+func TestRatesConvert(t *testing.T) {
+	rates, err := NewRates([]*CurrencyRate{
+		{Currency: "UZS", Rate: "12650.5"},
+		{Currency: "EUR", Rate: "0.92"},
+		{Currency: "JPY", Rate: "151.37"},
+		{Currency: "CHF", Rate: "0.5"},
+	})
+	assert.NoError(t, err)
+
+	// 10.00 USD -> 126505.00 UZS
+	converted, err := rates.Convert(Money{Amount: 1000, Currency: "USD"}, "UZS")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 12650500, Currency: "UZS"}, converted)
+
+	// 1.00 EUR -> 1.0869565... USD, rounded to 1.09
+	converted, err = rates.Convert(Money{Amount: 100, Currency: "EUR"}, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 109, Currency: "USD"}, converted)
+
+	// JPY has no minor unit: 0.01 USD -> 1.5137 JPY, rounded to 2
+	converted, err = rates.Convert(Money{Amount: 1, Currency: "USD"}, "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 2, Currency: "JPY"}, converted)
+
+	// exactly half a cent goes away from zero: 0.01 USD -> 0.005 CHF -> 0.01 CHF
+	converted, err = rates.Convert(Money{Amount: 1, Currency: "USD"}, "CHF")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 1, Currency: "CHF"}, converted)
+
+	// cross rates go through the base currency: 100.00 EUR -> 54.35 CHF
+	converted, err = rates.Convert(Money{Amount: 10000, Currency: "EUR"}, "CHF")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 5435, Currency: "CHF"}, converted)
+
+	// the same currency is returned untouched
+	converted, err = rates.Convert(Money{Amount: 1, Currency: "UZS"}, "UZS")
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 1, Currency: "UZS"}, converted)
+
+	_, err = rates.Convert(Money{Amount: 100, Currency: "USD"}, "GBP")
+	assert.Error(t, err)
+}
+
+func TestNewRatesRejectsInvalidRate(t *testing.T) {
+	_, err := NewRates([]*CurrencyRate{{Currency: "UZS", Rate: "abc"}})
+	assert.Error(t, err)
+
+	_, err = NewRates([]*CurrencyRate{{Currency: "UZS", Rate: "0"}})
+	assert.Error(t, err)
+}
+
+func TestIsCurrencyCode(t *testing.T) {
+	assert.True(t, IsCurrencyCode("UZS"))
+	assert.False(t, IsCurrencyCode("uzs"))
+	assert.False(t, IsCurrencyCode("UZSS"))
+}
@@ -8,6 +8,8 @@ import (
 var (
 	ErrorConflict = NewErrConflict("object")
 	ErrorNotFound = NewErrNotFound("object")
+
+	ErrorUnauthenticated = &ErrUnauthenticated{}
 )
 
 // error not found
@@ -36,6 +38,53 @@ func NewErrConflict(text string) *ErrConflict {
 	return &ErrConflict{text}
 }
 
+// error unauthenticated
+type ErrUnauthenticated struct{}
+
+func (e *ErrUnauthenticated) Error() string {
+	return "caller is not authenticated"
+}
+
+// error permission denied
+type ErrPermissionDenied struct {
+	action string
+}
+
+func (e *ErrPermissionDenied) Error() string {
+	return "permission denied to " + e.action
+}
+
+func NewErrPermissionDenied(action string) *ErrPermissionDenied {
+	return &ErrPermissionDenied{action}
+}
+
+// error version mismatch, the object was changed since the client read it
+type ErrVersionMismatch struct {
+	name    string
+	Current int64
+}
+
+func (e *ErrVersionMismatch) Error() string {
+	return fmt.Sprintf("%s was modified, current version is %d", e.name, e.Current)
+}
+
+func NewErrVersionMismatch(name string, current int64) *ErrVersionMismatch {
+	return &ErrVersionMismatch{name: name, Current: current}
+}
+
+// error unavailable, a service this service depends on does not answer
+type ErrUnavailable struct {
+	service string
+}
+
+func (e *ErrUnavailable) Error() string {
+	return e.service + " is unavailable"
+}
+
+func NewErrUnavailable(service string) *ErrUnavailable {
+	return &ErrUnavailable{service}
+}
+
 // error validation
 type ErrValidation struct {
 	Err    error
@@ -43,6 +92,9 @@ type ErrValidation struct {
 }
 
 func (e ErrValidation) Error() string {
+	if e.Err == nil {
+		return "validation failed"
+	}
 	return e.Err.Error()
 }
 
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// EventSchemaVersion is bumped on incompatible changes of DomainEvent, a
+// consumer skips events of versions it does not know
+const EventSchemaVersion = 1
+
+const (
+	EventCreated  = "created"
+	EventUpdated  = "updated"
+	EventDeleted  = "deleted"
+	EventRestored = "restored"
+)
+
+// EventTypeOwnerLink marks outbox messages that link an establishment with
+// its owner in the user service, the relay makes the call instead of sending
+// them to the broker
+const EventTypeOwnerLink = "establishment.owner_link"
+
+// OwnerLink is the payload of an owner link message
+type OwnerLink struct {
+	OwnerId         string `json:"owner_id"`
+	EstablishmentId string `json:"establishment_id"`
+}
+
+// DomainEvent tells other services about a change of an establishment or of
+// an object attached to it. Events of an establishment share its id as the
+// partition key, so consumers see them in order.
+type DomainEvent struct {
+	EventId         string `json:"event_id"`
+	Type            string `json:"type"`
+	SchemaVersion   int    `json:"schema_version"`
+	EntityType      string `json:"entity_type"`
+	EntityId        string `json:"entity_id"`
+	EstablishmentId string `json:"establishment_id"`
+	// EntityVersion is the version of an establishment after the change
+	EntityVersion int64  `json:"entity_version,omitempty"`
+	ActorId       string `json:"actor_id,omitempty"`
+	// Data holds the fields of the object after the change, none after a delete
+	Data map[string]interface{} `json:"data,omitempty"`
+	// ChangedFields names the fields an update changed
+	ChangedFields []string  `json:"changed_fields,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// EventType returns the type of an event, e.g. "hotel.created"
+func EventType(entity_type, action string) string {
+	return entity_type + "." + action
+}
+
+// ChangedFields returns the sorted names of the changed fields
+func ChangedFields(changes map[string]FieldChange) []string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// consumers in other services decode events by these names, renaming a
+// field needs a new schema version
+func TestDomainEventSchema(t *testing.T) {
+	event := DomainEvent{
+		EventId:         "e1",
+		Type:            EventType(EstablishmentTypeHotel, EventUpdated),
+		SchemaVersion:   EventSchemaVersion,
+		EntityType:      EstablishmentTypeHotel,
+		EntityId:        "h1",
+		EstablishmentId: "h1",
+		EntityVersion:   2,
+		ActorId:         "u1",
+		Data:            map[string]interface{}{"contact_number": "+998 90 765 43 21"},
+		ChangedFields:   ChangedFields(map[string]FieldChange{"location.city": {}, "contact_number": {}}),
+		OccurredAt:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(event)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event_id": "e1",
+		"type": "hotel.updated",
+		"schema_version": 1,
+		"entity_type": "hotel",
+		"entity_id": "h1",
+		"establishment_id": "h1",
+		"entity_version": 2,
+		"actor_id": "u1",
+		"data": {"contact_number": "+998 90 765 43 21"},
+		"changed_fields": ["contact_number", "location.city"],
+		"occurred_at": "2024-05-01T12:00:00Z"
+	}`, string(data))
+
+	// a delete carries no data
+	data, err = json.Marshal(DomainEvent{Type: EventType(EntityTypeReview, EventDeleted), SchemaVersion: EventSchemaVersion})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), `"data"`)
+}
@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	FieldMaskLocation   = "location"
+	FieldMaskCategories = "categories"
+)
+
+// locationMask copies location fields named by paths relative to the location
+var locationMask = map[string]func(dst, src *Location){
+	"address":        func(dst, src *Location) { dst.Address = src.Address },
+	"latitude":       func(dst, src *Location) { dst.Latitude = src.Latitude },
+	"longitude":      func(dst, src *Location) { dst.Longitude = src.Longitude },
+	"country":        func(dst, src *Location) { dst.Country = src.Country },
+	"city":           func(dst, src *Location) { dst.City = src.City },
+	"state_province": func(dst, src *Location) { dst.StateProvince = src.StateProvince },
+}
+
+// locationField returns the setter of a "location.<field>" path
+func locationField(path string) (func(dst, src *Location), bool) {
+	if !strings.HasPrefix(path, FieldMaskLocation+".") {
+		return nil, false
+	}
+	set, ok := locationMask[strings.TrimPrefix(path, FieldMaskLocation+".")]
+	return set, ok
+}
+
+// applyFieldMask copies the fields named by the paths with the setters of the
+// entity, "location" copies the whole location and "location.<field>" a
+// single field of it. Unknown paths are reported all at once and nothing
+// is copied then.
+func applyFieldMask(paths []string, fields map[string]func(), dst, src *Location) error {
+	var unknown []string
+	for _, path := range paths {
+		if _, ok := fields[path]; ok || path == FieldMaskLocation {
+			continue
+		}
+		if _, ok := locationField(path); ok {
+			continue
+		}
+		unknown = append(unknown, path)
+	}
+
+	if len(paths) == 0 || len(unknown) != 0 {
+		message := "unknown field paths: " + strings.Join(unknown, ", ")
+		if len(paths) == 0 {
+			message = "at least one field path is required"
+		}
+		return &ErrValidation{Err: errors.New("invalid update mask"), Errors: map[string]string{"update_mask": message}}
+	}
+
+	for _, path := range paths {
+		if path == FieldMaskLocation {
+			for _, set := range locationMask {
+				set(dst, src)
+			}
+		} else if set, ok := locationField(path); ok {
+			set(dst, src)
+		} else {
+			fields[path]()
+		}
+	}
+	return nil
+}
+
+// ApplyFieldMask copies the fields named by the paths from src, categories
+// are only replaced when the mask names them
+func (h *Hotel) ApplyFieldMask(src *Hotel, paths []string) error {
+	categories := h.Categories
+	h.Categories = nil
+
+	err := applyFieldMask(paths, map[string]func(){
+		"hotel_name":        func() { h.HotelName = src.HotelName },
+		"description":       func() { h.Description = src.Description },
+		"rating":            func() { h.Rating = src.Rating },
+		"contact_number":    func() { h.ContactNumber = src.ContactNumber },
+		"licence_url":       func() { h.LicenceUrl = src.LicenceUrl },
+		"website_url":       func() { h.WebsiteUrl = src.WebsiteUrl },
+		FieldMaskCategories: func() { h.Categories = src.Categories },
+	}, &h.Location, &src.Location)
+	if err != nil {
+		h.Categories = categories
+	}
+	return err
+}
+
+func (r *Restaurant) ApplyFieldMask(src *Restaurant, paths []string) error {
+	categories := r.Categories
+	r.Categories = nil
+
+	err := applyFieldMask(paths, map[string]func(){
+		"restaurant_name":   func() { r.RestaurantName = src.RestaurantName },
+		"description":       func() { r.Description = src.Description },
+		"rating":            func() { r.Rating = src.Rating },
+		"opening_hours":     func() { r.OpeningHours = src.OpeningHours },
+		"contact_number":    func() { r.ContactNumber = src.ContactNumber },
+		"licence_url":       func() { r.LicenceUrl = src.LicenceUrl },
+		"website_url":       func() { r.WebsiteUrl = src.WebsiteUrl },
+		FieldMaskCategories: func() { r.Categories = src.Categories },
+	}, &r.Location, &src.Location)
+	if err != nil {
+		r.Categories = categories
+	}
+	return err
+}
+
+func (a *Attraction) ApplyFieldMask(src *Attraction, paths []string) error {
+	categories := a.Categories
+	a.Categories = nil
+
+	err := applyFieldMask(paths, map[string]func(){
+		"attraction_name":   func() { a.AttractionName = src.AttractionName },
+		"description":       func() { a.Description = src.Description },
+		"rating":            func() { a.Rating = src.Rating },
+		"contact_number":    func() { a.ContactNumber = src.ContactNumber },
+		"licence_url":       func() { a.LicenceUrl = src.LicenceUrl },
+		"website_url":       func() { a.WebsiteUrl = src.WebsiteUrl },
+		FieldMaskCategories: func() { a.Categories = src.Categories },
+	}, &a.Location, &src.Location)
+	if err != nil {
+		a.Categories = categories
+	}
+	return err
+}
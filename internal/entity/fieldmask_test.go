@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotelApplyFieldMask(t *testing.T) {
+	hotel := &Hotel{
+		HotelName:   "Hilton",
+		Description: "old",
+		Rating:      4.5,
+		Categories:  []*Category{{Code: "spa"}},
+		Location:    Location{Address: "Amir Temur 1", City: "Tashkent", Latitude: 41.3},
+	}
+	update := &Hotel{
+		Description: "new",
+		Categories:  []*Category{{Code: "pool"}},
+		Location:    Location{Address: "Navoi 5", City: "Samarkand"},
+	}
+
+	assert.NoError(t, hotel.ApplyFieldMask(update, []string{"description", "location.city"}))
+	assert.Equal(t, "Hilton", hotel.HotelName)
+	assert.Equal(t, "new", hotel.Description)
+	assert.Equal(t, float32(4.5), hotel.Rating)
+	assert.Equal(t, "Amir Temur 1", hotel.Location.Address)
+	assert.Equal(t, "Samarkand", hotel.Location.City)
+	assert.Equal(t, float32(41.3), hotel.Location.Latitude)
+	// nil categories leave the stored ones untouched
+	assert.Nil(t, hotel.Categories)
+
+	assert.NoError(t, hotel.ApplyFieldMask(update, []string{"location", "categories"}))
+	assert.Equal(t, "Navoi 5", hotel.Location.Address)
+	assert.Equal(t, float32(0), hotel.Location.Latitude)
+	assert.Equal(t, update.Categories, hotel.Categories)
+}
+
+func TestApplyFieldMaskRejectsUnknownPaths(t *testing.T) {
+	restaurant := &Restaurant{RestaurantName: "Caravan", Categories: []*Category{{Code: "halal"}}}
+	update := &Restaurant{RestaurantName: "Plov"}
+
+	err := restaurant.ApplyFieldMask(update, []string{"restaurant_name", "owner_id", "location.planet"})
+	assert.Equal(t, map[string]string{"update_mask": "unknown field paths: owner_id, location.planet"}, violations(t, err))
+	// nothing is copied from a rejected mask
+	assert.Equal(t, "Caravan", restaurant.RestaurantName)
+	assert.Len(t, restaurant.Categories, 1)
+
+	attraction := &Attraction{}
+	err = attraction.ApplyFieldMask(&Attraction{}, []string{})
+	assert.Equal(t, map[string]string{"update_mask": "at least one field path is required"}, violations(t, err))
+}
@@ -11,9 +11,12 @@ type Hotel struct {
 	ContactNumber string
 	LicenceUrl    string
 	WebsiteUrl    string
+	Categories    []*Category
+	Prices        []*Price
 	Images        []*Image
 	Location      Location
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     time.Time
+	Version       int64
 }
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// IdempotencyRecord remembers the first response of a create request sent
+// with an idempotency key, Response is nil while the request is in progress.
+// A request in progress holds the key until LockedUntil, a retry after that
+// runs it again as the request is taken to have died.
+type IdempotencyRecord struct {
+	Key          string
+	UserId       string
+	Method       string
+	RequestHash  string
+	ResponseType string
+	Response     []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+	LockedUntil  time.Time
+}
+
+// Completed reports whether the response of the request is stored
+func (r *IdempotencyRecord) Completed() bool {
+	return r.Response != nil
+}
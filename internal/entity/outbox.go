@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// OutboxMessage is an event stored with the change it describes, a relay
+// sends pending messages to the broker in order of their ids per key
+type OutboxMessage struct {
+	MessageId     int64
+	Key           string
+	Payload       []byte
+	Headers       map[string]string
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	SentAt        time.Time
+}
+
+// Sent reports whether the relay delivered the message
+func (m *OutboxMessage) Sent() bool {
+	return !m.SentAt.IsZero()
+}
@@ -11,4 +11,8 @@ type Review struct {
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       time.Time
+
+	// the author as the user service describes them, not stored
+	UserName   string
+	UserAvatar string
 }
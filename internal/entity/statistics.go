@@ -0,0 +1,9 @@
+package entity
+
+// Statistics are the counts the business metrics report
+type Statistics struct {
+	// Establishments that are not deleted by type
+	Establishments map[string]int64
+	// ReviewsLastDay are the reviews written in the last 24 hours
+	ReviewsLastDay int64
+}
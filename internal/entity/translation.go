@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	EntityTypeImage = "image"
+
+	TranslationFieldName        = "name"
+	TranslationFieldDescription = "description"
+	TranslationFieldCaption     = "caption"
+)
+
+// SupportedLocales are the locales the application ships in
+var SupportedLocales = []string{"uz", "ru", "en"}
+
+type Translation struct {
+	EntityId   string
+	EntityType string
+	Field      string
+	Locale     string
+	Value      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TranslationFields returns the translatable fields of an entity type
+func TranslationFields(entity_type string) []string {
+	switch entity_type {
+	case EstablishmentTypeHotel, EstablishmentTypeRestaurant, EstablishmentTypeAttraction:
+		return []string{TranslationFieldName, TranslationFieldDescription}
+	case EntityTypeImage:
+		return []string{TranslationFieldCaption}
+	}
+	return nil
+}
+
+// IsSupportedLocale reports whether translations can be stored for the locale
+func IsSupportedLocale(locale string) bool {
+	for _, supported := range SupportedLocales {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// LocaleFallbacks returns the chain of locales to look a translation up in,
+// e.g. "ru-RU" resolves to "ru-ru", "ru" and then the default locale
+func LocaleFallbacks(locale string) []string {
+	locale = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(locale, "_", "-")))
+	if locale == "" {
+		return nil
+	}
+
+	chain := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		chain = append(chain, locale[:i])
+	}
+	if chain[len(chain)-1] != DefaultLocale {
+		chain = append(chain, DefaultLocale)
+	}
+	return chain
+}
+
+// Translations indexes values by entity id, field and locale
+type Translations map[string]map[string]map[string]string
+
+func NewTranslations(translations []*Translation) Translations {
+	t := make(Translations)
+	for _, translation := range translations {
+		if t[translation.EntityId] == nil {
+			t[translation.EntityId] = make(map[string]map[string]string)
+		}
+		if t[translation.EntityId][translation.Field] == nil {
+			t[translation.EntityId][translation.Field] = make(map[string]string)
+		}
+		t[translation.EntityId][translation.Field][translation.Locale] = translation.Value
+	}
+	return t
+}
+
+// Resolve returns the first translation found along the locale chain,
+// the original value when there is none
+func (t Translations) Resolve(entity_id, field string, locales []string, original string) string {
+	for _, locale := range locales {
+		if value := t[entity_id][field][locale]; value != "" {
+			return value
+		}
+	}
+	return original
+}
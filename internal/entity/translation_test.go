@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleFallbacks(t *testing.T) {
+	assert.Nil(t, LocaleFallbacks(""))
+	assert.Equal(t, []string{"en"}, LocaleFallbacks("en"))
+	assert.Equal(t, []string{"ru", "en"}, LocaleFallbacks("ru"))
+	assert.Equal(t, []string{"ru-ru", "ru", "en"}, LocaleFallbacks("ru_RU"))
+	assert.Equal(t, []string{"en-us", "en"}, LocaleFallbacks("en-US"))
+}
+
+func TestTranslationsResolve(t *testing.T) {
+	translations := NewTranslations([]*Translation{
+		{EntityId: "1", Field: TranslationFieldName, Locale: "ru", Value: "Отель"},
+		{EntityId: "1", Field: TranslationFieldName, Locale: "en", Value: "Hotel"},
+		{EntityId: "1", Field: TranslationFieldDescription, Locale: "en", Value: "Description"},
+	})
+
+	assert.Equal(t, "Отель", translations.Resolve("1", TranslationFieldName, LocaleFallbacks("ru-RU"), "Mehmonxona"))
+	assert.Equal(t, "Hotel", translations.Resolve("1", TranslationFieldName, LocaleFallbacks("uz"), "Mehmonxona"))
+	assert.Equal(t, "Description", translations.Resolve("1", TranslationFieldDescription, LocaleFallbacks("ru"), "Tavsif"))
+	assert.Equal(t, "Mehmonxona", translations.Resolve("2", TranslationFieldName, LocaleFallbacks("ru"), "Mehmonxona"))
+	assert.Equal(t, "Mehmonxona", translations.Resolve("1", TranslationFieldName, nil, "Mehmonxona"))
+}
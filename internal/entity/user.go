@@ -0,0 +1,9 @@
+package entity
+
+// User is an account of the user service
+type User struct {
+	UserId     string
+	FullName   string
+	ProfileImg string
+	Role       string
+}
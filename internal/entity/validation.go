@@ -0,0 +1,299 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxNameLength        = 255
+	MaxDescriptionLength = 4000
+	MaxCommentLength     = 2000
+)
+
+var phoneRegexp = regexp.MustCompile(`^\+?[0-9 ()-]{5,20}$`)
+
+// Rule checks a single value, it returns the violation message or "" when
+// the value is valid
+type Rule[T any] func(value T) string
+
+// Check adds the violations it finds to the map keyed by field name
+type Check func(violations map[string]string)
+
+// Validate runs every check and returns an *ErrValidation holding all the
+// violations found, or nil
+func Validate(message string, checks ...Check) error {
+	violations := make(map[string]string)
+	for _, check := range checks {
+		check(violations)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ErrValidation{Err: errors.New(message), Errors: violations}
+}
+
+// Field checks the value with the rules, only the first violation of a field
+// is reported
+func Field[T any](name string, value T, rules ...Rule[T]) Check {
+	return func(violations map[string]string) {
+		for _, rule := range rules {
+			if message := rule(value); message != "" {
+				violations[name] = message
+				return
+			}
+		}
+	}
+}
+
+// Nested prefixes field names of the checks, e.g. location.latitude
+func Nested(prefix string, checks ...Check) Check {
+	return func(violations map[string]string) {
+		nested := make(map[string]string)
+		for _, check := range checks {
+			check(nested)
+		}
+		for name, message := range nested {
+			violations[prefix+"."+name] = message
+		}
+	}
+}
+
+// Each runs the checks of every item under an indexed prefix, e.g. images[0].image_url
+func Each[T any](prefix string, items []T, checks func(item T) []Check) Check {
+	return func(violations map[string]string) {
+		for i, item := range items {
+			Nested(fmt.Sprintf("%s[%d]", prefix, i), checks(item)...)(violations)
+		}
+	}
+}
+
+func Required(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "is required"
+	}
+	return ""
+}
+
+// UUID accepts empty values, combine it with Required for mandatory ids
+func UUID(value string) string {
+	if value == "" {
+		return ""
+	}
+	if _, err := uuid.Parse(value); err != nil {
+		return "must be a valid UUID"
+	}
+	return ""
+}
+
+func MaxLength(max int) Rule[string] {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) > max {
+			return fmt.Sprintf("must be at most %d characters long", max)
+		}
+		return ""
+	}
+}
+
+// URL accepts empty values and absolute http(s) urls
+func URL(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "must be a valid http(s) URL"
+	}
+	return ""
+}
+
+// Phone accepts empty values and international phone numbers
+func Phone(value string) string {
+	if value == "" {
+		return ""
+	}
+	if !phoneRegexp.MatchString(value) {
+		return "must be a valid phone number"
+	}
+	return ""
+}
+
+type number interface {
+	~int | ~int32 | ~int64 | ~uint64 | ~float32 | ~float64
+}
+
+func Between[T number](min, max T) Rule[T] {
+	return func(value T) string {
+		if value < min || value > max {
+			return fmt.Sprintf("must be between %v and %v", min, max)
+		}
+		return ""
+	}
+}
+
+func Min[T number](min T) Rule[T] {
+	return func(value T) string {
+		if value < min {
+			return fmt.Sprintf("must be at least %v", min)
+		}
+		return ""
+	}
+}
+
+// ValidatePage validates offset and limit of list requests, zero limit
+// means no limit
+func ValidatePage(offset, limit int64) error {
+	return Validate("invalid page",
+		Field("offset", offset, Min[int64](0)),
+		Field("limit", limit, Min[int64](0)),
+	)
+}
+
+// ValidateLocationPage validates the page and the parts of the location of
+// list by location requests, empty parts match any location
+func ValidateLocationPage(offset, limit uint64, country, city, state_province string) error {
+	return Validate("invalid location query",
+		Field("offset", offset, Between[uint64](0, math.MaxInt64)),
+		Field("limit", limit, Between[uint64](0, math.MaxInt64)),
+		Field("country", country, MaxLength(MaxNameLength)),
+		Field("city", city, MaxLength(MaxNameLength)),
+		Field("state_province", state_province, MaxLength(MaxNameLength)),
+	)
+}
+
+// ValidateId validates a mandatory id parameter
+func ValidateId(name, id string) error {
+	return Validate("invalid "+name, Field(name, id, Required, UUID))
+}
+
+func (l Location) checks() []Check {
+	return []Check{
+		Field("location_id", l.LocationId, UUID),
+		Field("establishment_id", l.EstablishmentId, UUID),
+		Field("address", l.Address, Required, MaxLength(MaxNameLength)),
+		Field("latitude", l.Latitude, Between[float32](-90, 90)),
+		Field("longitude", l.Longitude, Between[float32](-180, 180)),
+		Field("country", l.Country, Required, MaxLength(MaxNameLength)),
+		Field("city", l.City, Required, MaxLength(MaxNameLength)),
+		Field("state_province", l.StateProvince, MaxLength(MaxNameLength)),
+	}
+}
+
+func (l Location) Validate() error {
+	return Validate("invalid location", l.checks()...)
+}
+
+func (i *Image) checks() []Check {
+	return []Check{
+		Field("image_id", i.ImageId, UUID),
+		Field("establishment_id", i.EstablishmentId, Required, UUID),
+		Field("image_url", i.ImageUrl, Required, URL),
+		Field("caption", i.Caption, MaxLength(MaxNameLength)),
+	}
+}
+
+func (i *Image) Validate() error {
+	return Validate("invalid image", i.checks()...)
+}
+
+// establishmentChecks are shared by hotels, restaurants and attractions
+func establishmentChecks(id_field, id, owner_id, name, description string, rating float32, contact, licence, website string, location Location, images []*Image) []Check {
+	return []Check{
+		Field(id_field, id, UUID),
+		Field("owner_id", owner_id, Required, UUID),
+		Field(strings.TrimSuffix(id_field, "_id")+"_name", name, Required, MaxLength(MaxNameLength)),
+		Field("description", description, MaxLength(MaxDescriptionLength)),
+		Field("rating", rating, Between[float32](0, 5)),
+		Field("contact_number", contact, Phone),
+		Field("licence_url", licence, URL),
+		Field("website_url", website, URL),
+		Nested("location", location.checks()...),
+		Each("images", images, (*Image).checks),
+	}
+}
+
+func (h *Hotel) Validate() error {
+	return Validate("invalid hotel", establishmentChecks("hotel_id", h.HotelId, h.OwnerId, h.HotelName, h.Description, h.Rating,
+		h.ContactNumber, h.LicenceUrl, h.WebsiteUrl, h.Location, h.Images)...)
+}
+
+func (r *Restaurant) Validate() error {
+	checks := establishmentChecks("restaurant_id", r.RestaurantId, r.OwnerId, r.RestaurantName, r.Description, r.Rating,
+		r.ContactNumber, r.LicenceUrl, r.WebsiteUrl, r.Location, r.Images)
+	checks = append(checks, Field("opening_hours", r.OpeningHours, MaxLength(MaxNameLength)))
+
+	return Validate("invalid restaurant", checks...)
+}
+
+func (a *Attraction) Validate() error {
+	return Validate("invalid attraction", establishmentChecks("attraction_id", a.AttractionId, a.OwnerId, a.AttractionName, a.Description, a.Rating,
+		a.ContactNumber, a.LicenceUrl, a.WebsiteUrl, a.Location, a.Images)...)
+}
+
+func (r *Review) Validate() error {
+	return Validate("invalid review",
+		Field("review_id", r.ReviewId, UUID),
+		Field("establishment_id", r.EstablishmentId, Required, UUID),
+		Field("user_id", r.UserId, Required, UUID),
+		Field("rating", r.Rating, Between[float64](1, 5)),
+		Field("comment", r.Comment, MaxLength(MaxCommentLength)),
+	)
+}
+
+func (f *Favourite) Validate() error {
+	return Validate("invalid favourite",
+		Field("favourite_id", f.FavouriteId, UUID),
+		Field("establishment_id", f.EstablishmentId, Required, UUID),
+		Field("user_id", f.UserId, Required, UUID),
+	)
+}
+
+func (f *AuditFilter) Validate() error {
+	return Validate("invalid audit filter",
+		Field("establishment_id", f.EstablishmentId, UUID),
+		Field("actor_id", f.ActorId, UUID),
+		func(violations map[string]string) {
+			if !f.From.IsZero() && !f.To.IsZero() && f.To.Before(f.From) {
+				violations["to"] = "must not be before from"
+			}
+		},
+	)
+}
+
+func (e *UserEvent) Validate() error {
+	return Validate("invalid user event",
+		Field("event_id", e.EventId, Required, MaxLength(MaxNameLength)),
+		Field("type", e.Type, Required),
+		Field("user_id", e.UserId, Required, UUID),
+	)
+}
+
+func (f *Filter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	return Validate("invalid filter",
+		func(violations map[string]string) {
+			switch f.Sort {
+			case "", SortRating, SortPopularity:
+			default:
+				violations["sort"] = "must be " + SortRating + " or " + SortPopularity
+			}
+		},
+	)
+}
+
+func (b *Booking) Validate() error {
+	return Validate("invalid booking",
+		Field("booking_id", b.BookingId, Required, MaxLength(MaxNameLength)),
+		Field("establishment_id", b.EstablishmentId, Required, UUID),
+	)
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func validHotel() *Hotel {
+	hotel_id := uuid.New().String()
+	return &Hotel{
+		HotelId:       hotel_id,
+		OwnerId:       uuid.New().String(),
+		HotelName:     "Hilton",
+		Rating:        4.5,
+		ContactNumber: "+998 (90) 123-45-67",
+		WebsiteUrl:    "https://hilton.com",
+		Images: []*Image{
+			{ImageId: uuid.New().String(), EstablishmentId: hotel_id, ImageUrl: "https://cdn.hilton.com/1.png"},
+		},
+		Location: Location{
+			LocationId:      uuid.New().String(),
+			EstablishmentId: hotel_id,
+			Address:         "Amir Temur 1",
+			Latitude:        41.3,
+			Longitude:       69.2,
+			Country:         "Uzbekistan",
+			City:            "Tashkent",
+		},
+	}
+}
+
+func violations(t *testing.T, err error) map[string]string {
+	var errValidation *ErrValidation
+	if !errors.As(err, &errValidation) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	return errValidation.Errors
+}
+
+func TestHotelValidate(t *testing.T) {
+	assert.NoError(t, validHotel().Validate())
+
+	hotel := validHotel()
+	hotel.HotelId = "1"
+	hotel.HotelName = " "
+	hotel.Rating = 6
+	hotel.WebsiteUrl = "hilton.com"
+	hotel.ContactNumber = "call me"
+	hotel.Location.Latitude = 91
+	hotel.Location.Longitude = -181
+	hotel.Location.City = ""
+	hotel.Images[0].ImageUrl = ""
+
+	// every violation is reported at once
+	assert.Equal(t, map[string]string{
+		"hotel_id":            "must be a valid UUID",
+		"hotel_name":          "is required",
+		"rating":              "must be between 0 and 5",
+		"website_url":         "must be a valid http(s) URL",
+		"contact_number":      "must be a valid phone number",
+		"location.latitude":   "must be between -90 and 90",
+		"location.longitude":  "must be between -180 and 180",
+		"location.city":       "is required",
+		"images[0].image_url": "is required",
+	}, violations(t, hotel.Validate()))
+}
+
+func TestRestaurantAndAttractionValidate(t *testing.T) {
+	restaurant := &Restaurant{
+		RestaurantName: strings.Repeat("a", MaxNameLength+1),
+		OpeningHours:   "09:00-23:00",
+	}
+	errs := violations(t, restaurant.Validate())
+	assert.Equal(t, "must be at most 255 characters long", errs["restaurant_name"])
+	assert.Equal(t, "is required", errs["owner_id"])
+	assert.Equal(t, "is required", errs["location.address"])
+	assert.NotContains(t, errs, "opening_hours")
+
+	attraction := &Attraction{AttractionName: "Chorsu", OwnerId: "owner"}
+	errs = violations(t, attraction.Validate())
+	assert.Equal(t, "must be a valid UUID", errs["owner_id"])
+	assert.NotContains(t, errs, "attraction_name")
+}
+
+func TestReviewAndFavouriteValidate(t *testing.T) {
+	review := &Review{EstablishmentId: uuid.New().String(), UserId: uuid.New().String(), Rating: 5}
+	assert.NoError(t, review.Validate())
+
+	review.Rating = 0
+	review.Comment = strings.Repeat("a", MaxCommentLength+1)
+	assert.Equal(t, map[string]string{
+		"rating":  "must be between 1 and 5",
+		"comment": "must be at most 2000 characters long",
+	}, violations(t, review.Validate()))
+
+	favourite := &Favourite{EstablishmentId: "hotel"}
+	assert.Equal(t, map[string]string{
+		"establishment_id": "must be a valid UUID",
+		"user_id":          "is required",
+	}, violations(t, favourite.Validate()))
+}
+
+func TestValidatePageAndId(t *testing.T) {
+	assert.NoError(t, ValidatePage(0, 0))
+	assert.Equal(t, map[string]string{
+		"offset": "must be at least 0",
+		"limit":  "must be at least 0",
+	}, violations(t, ValidatePage(-1, -10)))
+
+	assert.NoError(t, ValidateId("hotel_id", uuid.New().String()))
+	assert.Equal(t, map[string]string{"hotel_id": "is required"}, violations(t, ValidateId("hotel_id", "")))
+}
+
+func TestValidateLocationPage(t *testing.T) {
+	assert.NoError(t, ValidateLocationPage(0, 10, "", "", ""))
+	assert.NoError(t, ValidateLocationPage(20, 10, "Uzbekistan", "Tashkent", ""))
+	assert.Equal(t, map[string]string{
+		"offset": fmt.Sprintf("must be between 0 and %d", uint64(math.MaxInt64)),
+		"city":   "must be at most 255 characters long",
+	}, violations(t, ValidateLocationPage(math.MaxUint64, 10, "", strings.Repeat("a", 256), "")))
+}
+
+func TestFilterValidate(t *testing.T) {
+	var filter *Filter
+	assert.NoError(t, filter.Validate())
+	assert.NoError(t, (&Filter{}).Validate())
+	assert.NoError(t, (&Filter{Sort: SortPopularity}).Validate())
+	assert.Contains(t, violations(t, (&Filter{Sort: "price"}).Validate()), "sort")
+}
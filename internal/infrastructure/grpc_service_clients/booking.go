@@ -0,0 +1,204 @@
+package grpc_service_clients
+
+import (
+	booking "Booking/establishment-service-booking/genproto/booking-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// bookingPageSize is the page of users and bookings asked at once
+const bookingPageSize = 100
+
+// MaxBookingUsers caps the users whose bookings are listed for one
+// establishment, the bookings of the users listed later are left out
+const MaxBookingUsers = 1000
+
+// BookingService is the part of the booking service this service uses
+type BookingService interface {
+	// ListBookings returns the bookings of the establishment that are not
+	// deleted, made by at most MaxBookingUsers users, an
+	// *entity.ErrUnavailable when the booking service is down
+	ListBookings(ctx context.Context, establishment *entity.EstablishmentRef) ([]*entity.Booking, error)
+}
+
+type bookingService struct {
+	client booking.BookingServiceClient
+}
+
+func NewBookingService(conn *grpc.ClientConn) BookingService {
+	return &bookingService{client: booking.NewBookingServiceClient(conn)}
+}
+
+// bookingCalls are the calls of the booking service for one establishment type
+type bookingCalls struct {
+	// users who booked the establishment
+	users func(ctx context.Context, in *booking.ListReqById, opts ...grpc.CallOption) (*booking.UserId, error)
+	// bookings of a user
+	bookings func(ctx context.Context, in *booking.ListReqById) ([]*booking.GeneralBook, int64, error)
+}
+
+func (b *bookingService) calls(establishment_type string) (bookingCalls, error) {
+	switch establishment_type {
+	case entity.EstablishmentTypeHotel:
+		return bookingCalls{users: b.client.UHBGetAllByHId, bookings: func(ctx context.Context, in *booking.ListReqById) ([]*booking.GeneralBook, int64, error) {
+			response, err := b.client.UHBGetAllByUId(ctx, in)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.UserHotel, response.Count, nil
+		}}, nil
+	case entity.EstablishmentTypeRestaurant:
+		return bookingCalls{users: b.client.URBGetAllByRId, bookings: func(ctx context.Context, in *booking.ListReqById) ([]*booking.GeneralBook, int64, error) {
+			response, err := b.client.URBGetAllByUId(ctx, in)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.UserRestaurant, response.Count, nil
+		}}, nil
+	case entity.EstablishmentTypeAttraction:
+		return bookingCalls{users: b.client.UABGetAllByAId, bookings: func(ctx context.Context, in *booking.ListReqById) ([]*booking.GeneralBook, int64, error) {
+			response, err := b.client.UABGetAllByUId(ctx, in)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.UserAttraction, response.Count, nil
+		}}, nil
+	}
+	return bookingCalls{}, fmt.Errorf("unknown establishment type %q", establishment_type)
+}
+
+// ListBookings asks the booking service for the users who booked the
+// establishment, it only returns their ids, and then for the bookings of
+// each user to find the ones of the establishment
+func (b *bookingService) ListBookings(ctx context.Context, establishment *entity.EstablishmentRef) ([]*entity.Booking, error) {
+	calls, err := b.calls(establishment.EstablishmentType)
+	if err != nil {
+		return nil, err
+	}
+
+	var user_ids []string
+	seen := make(map[string]bool)
+	for offset := uint64(0); len(user_ids) < MaxBookingUsers; offset += bookingPageSize {
+		response, err := calls.users(ctx, &booking.ListReqById{Limit: bookingPageSize, Offset: offset, Id: &booking.Id{Id: establishment.EstablishmentId}})
+		if err != nil {
+			return nil, serviceError("booking service", "list users of "+establishment.EstablishmentId, err)
+		}
+		for _, id := range response.UserId {
+			if id != nil && !seen[id.Id] && len(user_ids) < MaxBookingUsers {
+				seen[id.Id] = true
+				user_ids = append(user_ids, id.Id)
+			}
+		}
+		if len(response.UserId) < bookingPageSize || offset+bookingPageSize >= uint64(response.Count) {
+			break
+		}
+	}
+
+	// the users are listed by a few workers, the first failure stops them
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	found := make([][]*entity.Booking, len(user_ids))
+	next := make(chan int)
+
+	for i := 0; i < MaxConcurrentCalls && i < len(user_ids); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				bookings, err := b.userBookings(ctx, calls, establishment, user_ids[i])
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					cancel()
+					continue
+				}
+				found[i] = bookings
+			}
+		}()
+	}
+
+	sent := 0
+	for ; sent < len(user_ids) && ctx.Err() == nil; sent++ {
+		next <- sent
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if sent < len(user_ids) {
+		return nil, serviceError("booking service", "list bookings of "+establishment.EstablishmentId, status.FromContextError(ctx.Err()).Err())
+	}
+
+	var bookings []*entity.Booking
+	for _, user_bookings := range found {
+		bookings = append(bookings, user_bookings...)
+	}
+	return bookings, nil
+}
+
+// userBookings pages through the bookings of a user and keeps the ones of
+// the establishment
+func (b *bookingService) userBookings(ctx context.Context, calls bookingCalls, establishment *entity.EstablishmentRef, user_id string) ([]*entity.Booking, error) {
+	var bookings []*entity.Booking
+	for offset := uint64(0); ; offset += bookingPageSize {
+		page, count, err := calls.bookings(ctx, &booking.ListReqById{Limit: bookingPageSize, Offset: offset, Id: &booking.Id{Id: user_id}})
+		if err != nil {
+			return nil, serviceError("booking service", "list bookings of "+user_id, err)
+		}
+		for _, book := range page {
+			if book.HraId != establishment.EstablishmentId || book.DeletedAt != "" {
+				continue
+			}
+			found, err := bookingFromProto(book)
+			if err != nil {
+				return nil, err
+			}
+			bookings = append(bookings, found)
+		}
+		if len(page) < bookingPageSize || offset+bookingPageSize >= uint64(count) {
+			return bookings, nil
+		}
+	}
+}
+
+func bookingFromProto(book *booking.GeneralBook) (*entity.Booking, error) {
+	found := &entity.Booking{
+		BookingId:       book.Id,
+		EstablishmentId: book.HraId,
+		UserId:          book.UserId,
+		Canceled:        book.IsCanceled,
+		Guests:          book.NumberOfPeople,
+	}
+
+	var err error
+	if found.ArriveAt, err = entity.ParseBookingTime(book.WillArrive); err != nil {
+		return nil, fmt.Errorf("invalid will_arrive of booking %s: %w", book.Id, err)
+	}
+	if book.WillLeave != "" {
+		if found.LeaveAt, err = entity.ParseBookingTime(book.WillLeave); err != nil {
+			return nil, fmt.Errorf("invalid will_leave of booking %s: %w", book.Id, err)
+		}
+	}
+	if book.CreatedAt != "" {
+		if found.BookedAt, err = entity.ParseBookingTime(book.CreatedAt); err != nil {
+			return nil, fmt.Errorf("invalid created_at of booking %s: %w", book.Id, err)
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,180 @@
+package grpc_service_clients
+
+import (
+	booking "Booking/establishment-service-booking/genproto/booking-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeBookingService serves hotel bookings from memory
+type fakeBookingService struct {
+	booking.UnimplementedBookingServiceServer
+	fakeService
+
+	bookings []*booking.GeneralBook
+}
+
+func page[T any](items []T, in *booking.ListReqById) []T {
+	if in.Offset >= uint64(len(items)) {
+		return nil
+	}
+	end := in.Offset + in.Limit
+	if end > uint64(len(items)) {
+		end = uint64(len(items))
+	}
+	return items[in.Offset:end]
+}
+
+func (f *fakeBookingService) UHBGetAllByHId(ctx context.Context, in *booking.ListReqById) (*booking.UserId, error) {
+	if err := f.failure(); err != nil {
+		return nil, err
+	}
+
+	// a user is listed for every booking
+	var users []*booking.Id
+	for _, book := range f.bookings {
+		if book.HraId == in.Id.Id && book.DeletedAt == "" {
+			users = append(users, &booking.Id{Id: book.UserId})
+		}
+	}
+	return &booking.UserId{UserId: page(users, in), Count: int64(len(users))}, nil
+}
+
+func (f *fakeBookingService) UHBGetAllByUId(ctx context.Context, in *booking.ListReqById) (*booking.ListUserHotelRes, error) {
+	if err := f.failure(); err != nil {
+		return nil, err
+	}
+
+	var bookings []*booking.GeneralBook
+	for _, book := range f.bookings {
+		if book.UserId == in.Id.Id {
+			bookings = append(bookings, book)
+		}
+	}
+	return &booking.ListUserHotelRes{UserHotel: page(bookings, in), Count: int64(len(bookings))}, nil
+}
+
+func startBookingService(t *testing.T, fake *fakeBookingService) *config.Config {
+	address := serve(t, func(server *grpc.Server) {
+		booking.RegisterBookingServiceServer(server, fake)
+	})
+
+	cfg := config.New()
+	cfg.BookingService.Address = address
+	cfg.BookingService.TLS = "false"
+	cfg.BookingService.Timeout = "200ms"
+	cfg.BookingService.Retries = "1"
+	cfg.BookingService.BreakerFailures = "2"
+	cfg.BookingService.BreakerCooldown = "1h"
+	cfg.ServiceClients.MinBackoff = "10ms"
+	cfg.ServiceClients.MaxBackoff = "40ms"
+	return cfg
+}
+
+func TestBookingService(t *testing.T) {
+	hotel := &entity.EstablishmentRef{EstablishmentType: entity.EstablishmentTypeHotel, EstablishmentId: "h1"}
+
+	fake := &fakeBookingService{}
+	// more users and bookings than fit a page
+	for i := 0; i < bookingPageSize+20; i++ {
+		fake.bookings = append(fake.bookings, &booking.GeneralBook{
+			Id: fmt.Sprintf("b%d", i), UserId: fmt.Sprintf("u%d", i), HraId: "h1",
+			WillArrive: "2024-05-10", WillLeave: "2024-05-12", NumberOfPeople: 2, CreatedAt: "2024-05-01 10:00:00",
+		})
+	}
+	fake.bookings = append(fake.bookings,
+		&booking.GeneralBook{Id: "b-other", UserId: "u0", HraId: "h2", WillArrive: "2024-05-10"},
+		&booking.GeneralBook{Id: "b-canceled", UserId: "u0", HraId: "h1", WillArrive: "2024-05-11", IsCanceled: true},
+		&booking.GeneralBook{Id: "b-deleted", UserId: "u1", HraId: "h1", WillArrive: "2024-05-11", DeletedAt: "2024-05-02"},
+	)
+	bookings := newTestClients(t, startBookingService(t, fake)).BookingService()
+
+	found, err := bookings.ListBookings(context.Background(), hotel)
+	assert.NoError(t, err)
+	assert.Len(t, found, bookingPageSize+21)
+	assert.Equal(t, &entity.Booking{
+		BookingId:       "b0",
+		EstablishmentId: "h1",
+		UserId:          "u0",
+		BookedAt:        time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		ArriveAt:        time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+		LeaveAt:         time.Date(2024, 5, 12, 0, 0, 0, 0, time.UTC),
+		Guests:          2,
+	}, found[0])
+	assert.True(t, found[1].Canceled)
+}
+
+func TestBookingServiceMaxUsers(t *testing.T) {
+	hotel := &entity.EstablishmentRef{EstablishmentType: entity.EstablishmentTypeHotel, EstablishmentId: "h1"}
+
+	fake := &fakeBookingService{}
+	for i := 0; i < MaxBookingUsers+10; i++ {
+		fake.bookings = append(fake.bookings, &booking.GeneralBook{
+			Id: fmt.Sprintf("b%d", i), UserId: fmt.Sprintf("u%d", i), HraId: "h1", WillArrive: "2024-05-10",
+		})
+	}
+	bookings := newTestClients(t, startBookingService(t, fake)).BookingService()
+
+	// the users past the cap are left out, the others keep their order
+	found, err := bookings.ListBookings(context.Background(), hotel)
+	assert.NoError(t, err)
+	if assert.Len(t, found, MaxBookingUsers) {
+		assert.Equal(t, "b0", found[0].BookingId)
+		assert.Equal(t, fmt.Sprintf("b%d", MaxBookingUsers-1), found[MaxBookingUsers-1].BookingId)
+	}
+
+	// a canceled request does not return a part of the bookings
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bookings.ListBookings(ctx, hotel)
+	assert.Error(t, err)
+}
+
+func TestBookingServiceBreaker(t *testing.T) {
+	hotel := &entity.EstablishmentRef{EstablishmentType: entity.EstablishmentTypeHotel, EstablishmentId: "h1"}
+
+	fake := &fakeBookingService{fakeService: fakeService{down: status.Error(codes.Unavailable, "down")}}
+	bookings := newTestClients(t, startBookingService(t, fake)).BookingService()
+	ctx := context.Background()
+
+	var errUnavailable *entity.ErrUnavailable
+	for i := 0; i < 2; i++ {
+		_, err := bookings.ListBookings(ctx, hotel)
+		assert.True(t, errors.As(err, &errUnavailable))
+	}
+	// every failed call was retried once
+	assert.Equal(t, 4, fake.count())
+
+	// the open breaker fails fast without calling the service
+	fake.down = nil
+	_, err := bookings.ListBookings(ctx, hotel)
+	assert.True(t, errors.As(err, &errUnavailable))
+	assert.Equal(t, 4, fake.count())
+
+	// other failures are not hidden as unavailable
+	_, err = bookings.ListBookings(ctx, &entity.EstablishmentRef{EstablishmentType: "museum", EstablishmentId: "m1"})
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &errUnavailable))
+}
+
+func TestBookingServiceConfig(t *testing.T) {
+	cfg := config.New()
+	cfg.UserService.Address = ""
+	cfg.BookingService.Address = ""
+	assert.Nil(t, newTestClients(t, cfg).BookingService())
+
+	cfg.BookingService.Address = "localhost:1"
+	cfg.BookingService.BreakerFailures = "-1"
+	_, err := New(cfg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package grpc_service_clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errBreakerOpen is returned without calling a service that keeps failing
+var errBreakerOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// breaker stops calling a service that keeps failing: after the given
+// consecutive failures calls fail fast, once the cooldown passed a single
+// call probes the service and closes the breaker when it succeeds
+type breaker struct {
+	failures int
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	failed   int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(failures int, cooldown time.Duration) *breaker {
+	return &breaker{
+		failures: failures,
+		cooldown: cooldown,
+		now:      time.Now,
+	}
+}
+
+// allow reports if a call may go to the service
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failed < b.failures {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record counts the result of an allowed call, only the service being down
+// counts as a failure
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	switch status.Code(err) {
+	case codes.Canceled:
+	case codes.Unavailable, codes.DeadlineExceeded:
+		b.failed++
+		if b.failed >= b.failures {
+			b.openedAt = b.now()
+		}
+	default:
+		b.failed = 0
+	}
+}
+
+// breakerInterceptor fails calls fast while the breaker is open, it goes
+// before the retries so a retried call counts once
+func breakerInterceptor(b *breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return errBreakerOpen
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
@@ -0,0 +1,46 @@
+package grpc_service_clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	down := status.Error(codes.Unavailable, "down")
+
+	// other errors do not count and reset the failures
+	assert.True(t, b.allow())
+	b.record(down)
+	assert.True(t, b.allow())
+	b.record(status.Error(codes.NotFound, "not found"))
+	assert.True(t, b.allow())
+	b.record(down)
+	assert.True(t, b.allow())
+
+	// consecutive failures open it
+	b.record(status.Error(codes.DeadlineExceeded, "slow"))
+	assert.False(t, b.allow())
+
+	// after the cooldown a single call probes
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	assert.False(t, b.allow())
+
+	// a failed probe opens it again
+	b.record(down)
+	assert.False(t, b.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	b.record(nil)
+	assert.True(t, b.allow())
+	assert.True(t, b.allow())
+}
@@ -0,0 +1,169 @@
+package grpc_service_clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+
+	// registers the client side health checking
+	_ "google.golang.org/grpc/health"
+)
+
+// healthCheckConfig spreads calls over the addresses of a service reporting
+// SERVING in grpc.health.v1, servers without the health service count as
+// serving
+const healthCheckConfig = `{"loadBalancingConfig": [{"round_robin": {}}], "healthCheckConfig": {"serviceName": ""}}`
+
+// endpoint is how a service is reached, as configured
+type endpoint struct {
+	name                string
+	address             string
+	tls                 string
+	caFile              string
+	serverName          string
+	timeout             string
+	methodTimeouts      []string
+	retries             string
+	minBackoff          string
+	maxBackoff          string
+	reconnectMaxBackoff string
+	breakerFailures     string
+	breakerCooldown     string
+	healthCheck         string
+}
+
+// options are the parsed settings of the calls to a service
+type options struct {
+	name                string
+	address             string
+	credentials         credentials.TransportCredentials
+	timeout             time.Duration
+	methodTimeouts      map[string]time.Duration
+	retries             int
+	minBackoff          time.Duration
+	maxBackoff          time.Duration
+	reconnectMaxBackoff time.Duration
+	breakerFailures     int
+	breakerCooldown     time.Duration
+	healthCheck         bool
+}
+
+func (e endpoint) options() (*options, error) {
+	opts := &options{
+		name:           e.name,
+		address:        e.address,
+		methodTimeouts: make(map[string]time.Duration),
+	}
+
+	var err error
+	if opts.timeout, err = parseDuration(e.name, "timeout", e.timeout); err != nil {
+		return nil, err
+	}
+	if opts.minBackoff, err = parseDuration(e.name, "min backoff", e.minBackoff); err != nil {
+		return nil, err
+	}
+	if opts.maxBackoff, err = parseDuration(e.name, "max backoff", e.maxBackoff); err != nil {
+		return nil, err
+	}
+	if opts.reconnectMaxBackoff, err = parseDuration(e.name, "reconnect max backoff", e.reconnectMaxBackoff); err != nil {
+		return nil, err
+	}
+	if opts.breakerCooldown, err = parseDuration(e.name, "breaker cooldown", e.breakerCooldown); err != nil {
+		return nil, err
+	}
+
+	for _, pair := range e.methodTimeouts {
+		method, value, ok := strings.Cut(pair, "=")
+		timeout, err := time.ParseDuration(value)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("error during parse %s method timeout %q: must be Method=duration", e.name, pair)
+		}
+		opts.methodTimeouts[strings.TrimSpace(method)] = timeout
+	}
+
+	if opts.retries, err = strconv.Atoi(e.retries); err != nil || opts.retries < 0 {
+		return nil, fmt.Errorf("error during parse %s retries %q: must be a number", e.name, e.retries)
+	}
+	if opts.breakerFailures, err = strconv.Atoi(e.breakerFailures); err != nil || opts.breakerFailures < 0 {
+		return nil, fmt.Errorf("error during parse %s breaker failures %q: must be a number", e.name, e.breakerFailures)
+	}
+	if opts.healthCheck, err = strconv.ParseBool(e.healthCheck); err != nil {
+		return nil, fmt.Errorf("error during parse %s health check %q: %w", e.name, e.healthCheck, err)
+	}
+
+	useTLS, err := strconv.ParseBool(e.tls)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse %s TLS %q: %w", e.name, e.tls, err)
+	}
+	if opts.credentials, err = transportCredentials(useTLS, e.caFile, e.serverName); err != nil {
+		return nil, fmt.Errorf("error during loading %s TLS: %w", e.name, err)
+	}
+
+	return opts, nil
+}
+
+func parseDuration(name, field, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("error during parse duration for %s %s: %w", name, field, err)
+	}
+	return duration, nil
+}
+
+// dialOptions make every call go through the breaker, then the retries with
+// a deadline per attempt and then the tracing, so every attempt is a span
+func (o *options) dialOptions() []grpc.DialOption {
+	var interceptors []grpc.UnaryClientInterceptor
+	if o.breakerFailures > 0 {
+		interceptors = append(interceptors, breakerInterceptor(newBreaker(o.breakerFailures, o.breakerCooldown)))
+	}
+	interceptors = append(interceptors, retryInterceptor(o), otelgrpc.UnaryClientInterceptor())
+
+	reconnect := backoff.DefaultConfig
+	reconnect.MaxDelay = o.reconnectMaxBackoff
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(o.credentials),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: reconnect, MinConnectTimeout: 20 * time.Second}),
+	}
+	if o.healthCheck {
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(healthCheckConfig))
+	}
+	return dialOptions
+}
+
+// transportCredentials uses the system roots unless a CA file is given
+func transportCredentials(useTLS bool, caFile, serverName string) (credentials.TransportCredentials, error) {
+	if !useTLS {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,114 @@
+package grpc_service_clients
+
+import (
+	user "Booking/establishment-service-booking/genproto/user-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// tracedUserService remembers the trace context calls arrive with
+type tracedUserService struct {
+	fakeUserService
+	traceparent string
+}
+
+func (f *tracedUserService) Get(ctx context.Context, filter *user.Filter) (*user.GetUser, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("traceparent")) != 0 {
+		f.traceparent = md.Get("traceparent")[0]
+	}
+	return f.fakeUserService.Get(ctx, filter)
+}
+
+func TestMethodTimeouts(t *testing.T) {
+	fake := &fakeUserService{users: map[string]*user.User{"u1": {Id: "u1"}}, delay: 100 * time.Millisecond}
+	cfg := startUserService(t, fake)
+	cfg.UserService.Timeout = "50ms"
+	cfg.UserService.Retries = "0"
+
+	// the default timeout is too short for the slow server
+	var errUnavailable *entity.ErrUnavailable
+	_, err := newTestClients(t, cfg).UserService().GetUser(context.Background(), "u1")
+	assert.True(t, errors.As(err, &errUnavailable))
+
+	cfg.UserService.MethodTimeouts = []string{"Get=1s"}
+	_, err = newTestClients(t, cfg).UserService().GetUser(context.Background(), "u1")
+	assert.NoError(t, err)
+
+	cfg.UserService.MethodTimeouts = []string{"Get"}
+	_, err = New(cfg)
+	assert.Error(t, err)
+}
+
+func TestHealthAwareCalls(t *testing.T) {
+	fake := &fakeUserService{users: map[string]*user.User{"u1": {Id: "u1"}}}
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	cfg := startUserService(t, fake, func(server *grpc.Server) {
+		healthpb.RegisterHealthServer(server, healthServer)
+	})
+	cfg.UserService.Retries = "0"
+	users := newTestClients(t, cfg).UserService()
+
+	// a server that is not serving gets no calls
+	var errUnavailable *entity.ErrUnavailable
+	_, err := users.GetUser(context.Background(), "u1")
+	assert.True(t, errors.As(err, &errUnavailable))
+	assert.Equal(t, 0, fake.count())
+
+	clients := newTestClients(t, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, clients.Check(ctx))
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	assert.Eventually(t, func() bool {
+		_, err := users.GetUser(context.Background(), "u1")
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return clients.Check(context.Background()) == nil
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestCallsCarryTraceContext(t *testing.T) {
+	otlp.SetPropagator()
+
+	fake := &tracedUserService{fakeUserService: fakeUserService{users: map[string]*user.User{"u1": {Id: "u1"}}}}
+	cfg := startUserService(t, fake)
+
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	_, err := newTestClients(t, cfg).UserService().GetUser(ctx, "u1")
+	assert.NoError(t, err)
+	assert.Contains(t, fake.traceparent, traceId.String())
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, backoffDelay(100*time.Millisecond, time.Second, 0))
+	assert.Equal(t, 400*time.Millisecond, backoffDelay(100*time.Millisecond, time.Second, 2))
+	assert.Equal(t, time.Second, backoffDelay(100*time.Millisecond, time.Second, 10))
+
+	for i := 0; i < 100; i++ {
+		delay := jitter(time.Second)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, time.Second)
+	}
+}
@@ -0,0 +1,84 @@
+package grpc_service_clients
+
+import (
+	"context"
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryInterceptor limits every attempt of a call to the timeout of its
+// method and retries failed attempts that did not change anything:
+// unavailable servers always, timeouts only for reads
+func retryInterceptor(opts *options) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		timeout := opts.timeout
+		if methodTimeout, ok := opts.methodTimeouts[path.Base(method)]; ok {
+			timeout = methodTimeout
+		}
+
+		for attempt := 0; ; attempt++ {
+			callCtx, cancel := context.WithTimeout(ctx, timeout)
+			err := invoker(callCtx, method, req, reply, cc, callOpts...)
+			cancel()
+
+			if err == nil || attempt >= opts.retries || ctx.Err() != nil || !retryable(method, err) {
+				return err
+			}
+
+			timer := time.NewTimer(jitter(backoffDelay(opts.minBackoff, opts.maxBackoff, attempt)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// backoffDelay doubles from min for every attempt up to max
+func backoffDelay(min, max time.Duration, attempt int) time.Duration {
+	delay := min
+	for i := 0; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// jitter picks a delay between the half and the whole of the given one, so
+// clients failed together do not retry together
+func jitter(delay time.Duration) time.Duration {
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+func retryable(method string, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return true
+	case codes.DeadlineExceeded:
+		return readOnly(method)
+	}
+	return false
+}
+
+// readOnly reports methods safe to call again after a timeout, the booking
+// service prefixes its methods with the kind of booking, e.g. UHBGetAllByHId
+func readOnly(method string) bool {
+	name := path.Base(method)
+	for _, prefix := range []string{"Get", "List", "Exists", "Check"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(name, "Get") || strings.Contains(name, "BGetAll") || strings.Contains(name, "BList")
+}
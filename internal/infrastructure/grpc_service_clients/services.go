@@ -1,27 +1,153 @@
 package grpc_service_clients
 
 import (
+	"Booking/establishment-service-booking/internal/entity"
 	"Booking/establishment-service-booking/internal/pkg/config"
+	"context"
+	"errors"
+	"fmt"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 )
 
+// MaxConcurrentCalls caps the calls one request makes to a service at once
+const MaxConcurrentCalls = 8
+
 type ServiceClients interface {
-	Close()
+	// UserService returns nil when no address of the user service is configured
+	UserService() UserService
+	// BookingService returns nil when no address of the booking service is configured
+	BookingService() BookingService
+	// Check reports an error when a configured service can not be reached
+	Check(ctx context.Context) error
+	Close() error
 }
 
 type serviceClients struct {
-	services []*grpc.ClientConn
+	userService    UserService
+	bookingService BookingService
+	services       map[string]*grpc.ClientConn
 }
 
 func New(config *config.Config) (ServiceClients, error) {
-	return &serviceClients{
-		services: []*grpc.ClientConn{},
-	}, nil
+	clients := &serviceClients{
+		services: map[string]*grpc.ClientConn{},
+	}
+
+	if config.UserService.Address != "" {
+		conn, err := dial(endpoint{
+			name:                "user service",
+			address:             config.UserService.Address,
+			tls:                 config.UserService.TLS,
+			caFile:              config.UserService.CAFile,
+			serverName:          config.UserService.ServerName,
+			timeout:             config.UserService.Timeout,
+			methodTimeouts:      config.UserService.MethodTimeouts,
+			retries:             config.UserService.Retries,
+			minBackoff:          config.ServiceClients.MinBackoff,
+			maxBackoff:          config.ServiceClients.MaxBackoff,
+			reconnectMaxBackoff: config.ServiceClients.ReconnectMaxBackoff,
+			breakerFailures:     config.UserService.BreakerFailures,
+			breakerCooldown:     config.UserService.BreakerCooldown,
+			healthCheck:         config.ServiceClients.HealthCheck,
+		})
+		if err != nil {
+			clients.Close()
+			return nil, err
+		}
+		clients.services["user service"] = conn
+		clients.userService = NewUserService(conn)
+	}
+
+	if config.BookingService.Address != "" {
+		conn, err := dial(endpoint{
+			name:                "booking service",
+			address:             config.BookingService.Address,
+			tls:                 config.BookingService.TLS,
+			caFile:              config.BookingService.CAFile,
+			serverName:          config.BookingService.ServerName,
+			timeout:             config.BookingService.Timeout,
+			methodTimeouts:      config.BookingService.MethodTimeouts,
+			retries:             config.BookingService.Retries,
+			minBackoff:          config.ServiceClients.MinBackoff,
+			maxBackoff:          config.ServiceClients.MaxBackoff,
+			reconnectMaxBackoff: config.ServiceClients.ReconnectMaxBackoff,
+			breakerFailures:     config.BookingService.BreakerFailures,
+			breakerCooldown:     config.BookingService.BreakerCooldown,
+			healthCheck:         config.ServiceClients.HealthCheck,
+		})
+		if err != nil {
+			clients.Close()
+			return nil, err
+		}
+		clients.services["booking service"] = conn
+		clients.bookingService = NewBookingService(conn)
+	}
+
+	return clients, nil
+}
+
+// dial connects to the service, the connection is made in the background
+// and remade whenever it breaks
+func dial(endpoint endpoint) (*grpc.ClientConn, error) {
+	opts, err := endpoint.options()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(opts.address, opts.dialOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("error during dial %s %s: %w", opts.name, opts.address, err)
+	}
+	return conn, nil
+}
+
+// serviceError hides the statuses of a service from the clients of this
+// service, a service that is down is reported as unavailable
+func serviceError(service, action string, err error) error {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Errorf("failed to %s: %w", action, entity.NewErrUnavailable(service))
+	}
+	return fmt.Errorf("failed to %s in %s: %v", action, service, err)
+}
+
+func (s *serviceClients) UserService() UserService {
+	return s.userService
+}
+
+func (s *serviceClients) BookingService() BookingService {
+	return s.bookingService
+}
+
+// Check connects idle connections and waits for connecting ones, a service
+// with no server reporting SERVING fails the check
+func (s *serviceClients) Check(ctx context.Context) error {
+	for name, conn := range s.services {
+		for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+			switch state {
+			case connectivity.Idle:
+				conn.Connect()
+			case connectivity.TransientFailure, connectivity.Shutdown:
+				return fmt.Errorf("%s is %s", name, state)
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("%s is %s: %w", name, state, ctx.Err())
+			}
+		}
+	}
+	return nil
 }
 
-func (s *serviceClients) Close() {
-	for _, conn := range s.services {
-		conn.Close()
+func (s *serviceClients) Close() error {
+	var errs []error
+	for name, conn := range s.services {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("error during close %s connection: %w", name, err))
+		}
 	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,71 @@
+package grpc_service_clients
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// fakeService counts the calls of a fake server, the first calls fail with
+// fail and every later call with down while it is set
+type fakeService struct {
+	mu    sync.Mutex
+	fail  []error
+	down  error
+	calls int
+}
+
+// count returns the calls made so far
+func (f *fakeService) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *fakeService) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = 0
+}
+
+// failure counts a call and returns the error it fails with
+func (f *fakeService) failure() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if len(f.fail) != 0 {
+		err := f.fail[0]
+		f.fail = f.fail[1:]
+		return err
+	}
+	return f.down
+}
+
+// serve starts a server with the registered services on a local port and
+// returns its address
+func serve(t *testing.T, register func(server *grpc.Server)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	server := grpc.NewServer()
+	register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func newTestClients(t *testing.T, cfg *config.Config) ServiceClients {
+	clients, err := New(cfg)
+	if err != nil {
+		t.Fatalf("failed to create service clients: %v", err)
+	}
+	t.Cleanup(func() { clients.Close() })
+	return clients
+}
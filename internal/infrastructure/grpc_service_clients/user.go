@@ -0,0 +1,56 @@
+package grpc_service_clients
+
+import (
+	user "Booking/establishment-service-booking/genproto/user-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UserService is the part of the user service this service uses
+type UserService interface {
+	// GetUser returns an *entity.ErrNotFound when the user does not exist
+	GetUser(ctx context.Context, user_id string) (*entity.User, error)
+	// LinkEstablishment registers the user as the owner of the establishment,
+	// linking it again succeeds
+	LinkEstablishment(ctx context.Context, user_id, establishment_id string) error
+}
+
+type userService struct {
+	client user.UserServiceClient
+}
+
+func NewUserService(conn *grpc.ClientConn) UserService {
+	return &userService{client: user.NewUserServiceClient(conn)}
+}
+
+func (u *userService) GetUser(ctx context.Context, user_id string) (*entity.User, error) {
+	response, err := u.client.Get(ctx, &user.Filter{Filter: map[string]string{"id": user_id}})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, entity.NewErrNotFound("user")
+		}
+		return nil, serviceError("user service", "get user "+user_id, err)
+	}
+	if response.User == nil || response.User.Id == "" || response.User.DeletedAt != "" {
+		return nil, entity.NewErrNotFound("user")
+	}
+
+	return &entity.User{
+		UserId:     response.User.Id,
+		FullName:   response.User.FullName,
+		ProfileImg: response.User.ProfileImg,
+		Role:       response.User.Role,
+	}, nil
+}
+
+func (u *userService) LinkEstablishment(ctx context.Context, user_id, establishment_id string) error {
+	// a retried link finds the first one
+	if _, err := u.client.UserEstablishmentCreate(ctx, &user.UE{UserId: user_id, EstablishmentId: establishment_id}); err != nil && status.Code(err) != codes.AlreadyExists {
+		return serviceError("user service", "link establishment "+establishment_id+" to user "+user_id, err)
+	}
+	return nil
+}
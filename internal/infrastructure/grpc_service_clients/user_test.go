@@ -0,0 +1,174 @@
+package grpc_service_clients
+
+import (
+	user "Booking/establishment-service-booking/genproto/user-proto"
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeUserService serves users from memory
+type fakeUserService struct {
+	user.UnimplementedUserServiceServer
+	fakeService
+
+	users map[string]*user.User
+	links []*user.UE
+	delay time.Duration
+}
+
+func (f *fakeUserService) Get(ctx context.Context, filter *user.Filter) (*user.GetUser, error) {
+	if err := f.failure(); err != nil {
+		return nil, err
+	}
+	if f.delay != 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(f.delay):
+		}
+	}
+
+	found, ok := f.users[filter.Filter["id"]]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &user.GetUser{User: found}, nil
+}
+
+func (f *fakeUserService) UserEstablishmentCreate(ctx context.Context, link *user.UE) (*user.UE, error) {
+	if err := f.failure(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, existing := range f.links {
+		if existing.UserId == link.UserId && existing.EstablishmentId == link.EstablishmentId {
+			return nil, status.Error(codes.AlreadyExists, "already linked")
+		}
+	}
+	f.links = append(f.links, link)
+	return link, nil
+}
+
+// startUserService serves the fake and the other services registered on a
+// local port and returns a config pointing to it
+func startUserService(t *testing.T, fake user.UserServiceServer, register ...func(server *grpc.Server)) *config.Config {
+	address := serve(t, func(server *grpc.Server) {
+		user.RegisterUserServiceServer(server, fake)
+		for _, r := range register {
+			r(server)
+		}
+	})
+
+	cfg := config.New()
+	cfg.UserService.Address = address
+	cfg.UserService.TLS = "false"
+	cfg.UserService.Timeout = "200ms"
+	cfg.UserService.Retries = "2"
+	cfg.ServiceClients.MinBackoff = "10ms"
+	cfg.ServiceClients.MaxBackoff = "40ms"
+	return cfg
+}
+
+func TestUserService(t *testing.T) {
+	fake := &fakeUserService{users: map[string]*user.User{
+		"u1": {Id: "u1", FullName: "Alisher Navoi", ProfileImg: "https://cdn/u1.png", Role: entity.RoleOwner},
+		"u2": {Id: "u2", FullName: "Deleted", DeletedAt: "2024-05-01"},
+	}}
+	users := newTestClients(t, startUserService(t, fake)).UserService()
+	ctx := context.Background()
+
+	found, err := users.GetUser(ctx, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.User{UserId: "u1", FullName: "Alisher Navoi", ProfileImg: "https://cdn/u1.png", Role: entity.RoleOwner}, found)
+
+	var errNotFound *entity.ErrNotFound
+	_, err = users.GetUser(ctx, "u3")
+	assert.True(t, errors.As(err, &errNotFound))
+
+	_, err = users.GetUser(ctx, "u2")
+	assert.True(t, errors.As(err, &errNotFound))
+
+	assert.NoError(t, users.LinkEstablishment(ctx, "u1", "h1"))
+	// a retried link succeeds
+	assert.NoError(t, users.LinkEstablishment(ctx, "u1", "h1"))
+	if assert.Len(t, fake.links, 1) {
+		assert.Equal(t, "u1", fake.links[0].UserId)
+		assert.Equal(t, "h1", fake.links[0].EstablishmentId)
+	}
+}
+
+func TestUserServiceRetries(t *testing.T) {
+	fake := &fakeUserService{users: map[string]*user.User{"u1": {Id: "u1"}}}
+	users := newTestClients(t, startUserService(t, fake)).UserService()
+	ctx := context.Background()
+
+	// unavailable servers are retried up to the retries
+	fake.fail = []error{status.Error(codes.Unavailable, "restarting"), status.Error(codes.Unavailable, "restarting")}
+	_, err := users.GetUser(ctx, "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fake.count())
+
+	fake.reset()
+	fake.fail = []error{status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down")}
+	var errUnavailable *entity.ErrUnavailable
+	_, err = users.GetUser(ctx, "u1")
+	assert.True(t, errors.As(err, &errUnavailable))
+	assert.Equal(t, 3, fake.count())
+
+	// other errors are not retried, their status is not passed on
+	fake.reset()
+	fake.fail = []error{status.Error(codes.PermissionDenied, "denied")}
+	err = users.LinkEstablishment(ctx, "u1", "h1")
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &errUnavailable))
+	_, isStatus := status.FromError(err)
+	assert.False(t, isStatus)
+	assert.Equal(t, 1, fake.count())
+}
+
+func TestUserServiceTimeout(t *testing.T) {
+	fake := &fakeUserService{users: map[string]*user.User{"u1": {Id: "u1"}}, delay: time.Second}
+	users := newTestClients(t, startUserService(t, fake)).UserService()
+
+	// every attempt of a read times out, then it is retried
+	started := time.Now()
+	var errUnavailable *entity.ErrUnavailable
+	_, err := users.GetUser(context.Background(), "u1")
+	assert.True(t, errors.As(err, &errUnavailable))
+	assert.Equal(t, 3, fake.count())
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func TestServiceClientsWithoutUserService(t *testing.T) {
+	cfg := config.New()
+	cfg.UserService.Address = ""
+
+	assert.Nil(t, newTestClients(t, cfg).UserService())
+
+	cfg.UserService.Address = "localhost:1"
+	cfg.UserService.Timeout = "soon"
+	_, err := New(cfg)
+	assert.Error(t, err)
+}
+
+func TestReadOnly(t *testing.T) {
+	assert.True(t, readOnly("/user.UserService/Get"))
+	assert.True(t, readOnly("/user.UserService/Exists"))
+	assert.True(t, readOnly("/user.UserService/UserEstablishmentGet"))
+	assert.False(t, readOnly("/user.UserService/UserEstablishmentCreate"))
+	assert.True(t, readOnly("/booking.BookingService/UHBGetAllByHId"))
+	assert.True(t, readOnly("/booking.BookingService/UABList"))
+	assert.False(t, readOnly("/booking.BookingService/URBCreate"))
+	assert.False(t, readOnly("/user.UserService/Update"))
+}
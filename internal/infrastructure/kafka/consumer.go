@@ -1,10 +1,14 @@
 package kafka
 
 import (
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/metrics"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"Booking/establishment-service-booking/internal/usecase/event"
 	"context"
-	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
@@ -13,19 +17,48 @@ import (
 const (
 	MinBytes = 10e3 // 10KB
 	MaxBytes = 10e6 // 10MB
+
+	// DeadLetterSuffix names the dead letter topic of a topic by default
+	DeadLetterSuffix = ".dlq"
 )
 
 type HandlerFunc func(ctx context.Context, key, value []byte) error
 
+// messageWriter is the part of the kafka writer the consumer uses
+type messageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
 type consumer struct {
 	logger          *zap.Logger
+	metrics         *metrics.Metrics
+	retry           event.RetryPolicy
+	deadLetters     messageWriter
 	consumerConfigs []event.ConsumerConfig
-	readers         []*kafka.Reader
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
-func NewConsumer(logger *zap.Logger) *consumer {
+// NewConsumer creates a consumer retrying failed messages with the policy
+// unless their config has one
+func NewConsumer(config *config.Config, logger *zap.Logger, metrics *metrics.Metrics, retry event.RetryPolicy) *consumer {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &consumer{
-		logger: logger,
+		logger:  logger,
+		metrics: metrics,
+		retry:   retry,
+		// the topic is set by every message
+		deadLetters: &kafka.Writer{
+			Addr:                   kafka.TCP(config.Kafka.Address...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+		ctx:    ctx,
+		cancel: cancel,
 	}
 }
 
@@ -35,111 +68,198 @@ func (c *consumer) RegisterConsumer(consumerConfig event.ConsumerConfig) {
 
 func (c *consumer) Run() {
 	for _, consumerConfig := range c.consumerConfigs {
+		c.wg.Add(1)
+		go c.runReader(consumerConfig)
+	}
+}
+
+func (c *consumer) Close() error {
+	c.cancel()
+	c.wg.Wait()
+
+	if err := c.deadLetters.Close(); err != nil {
+		return fmt.Errorf("error during close dead letter writer: %w", err)
+	}
+	return nil
+}
+
+func (c *consumer) retryPolicy(consumerConfig event.ConsumerConfig) event.RetryPolicy {
+	if policy := consumerConfig.GetRetryPolicy(); policy != nil {
+		return *policy
+	}
+	return c.retry
+}
+
+// runReader reads the topic until the consumer is closed, the reader is
+// created again after a failure
+func (c *consumer) runReader(consumerConfig event.ConsumerConfig) {
+	defer c.wg.Done()
+
+	topic := consumerConfig.GetTopic()
+	policy := c.retryPolicy(consumerConfig)
+
+	for failures := 0; ; {
 		r := kafka.NewReader(kafka.ReaderConfig{
 			Brokers:  consumerConfig.GetBrokers(),
-			Topic:    consumerConfig.GetTopic(),
+			Topic:    topic,
 			GroupID:  consumerConfig.GetGroupID(),
 			MinBytes: MinBytes,
 			MaxBytes: MaxBytes,
 		})
-		c.readers = append(c.readers, r)
-		go runReader(r, consumerConfig, c.logger)
+
+		read, err := c.read(r, consumerConfig)
+		if err := r.Close(); err != nil {
+			c.logger.Error("consumer reader close", zap.String("topic", topic), zap.Error(err))
+		}
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		if read != 0 {
+			failures = 0
+		}
+		failures++
+
+		delay := policy.Backoff(failures)
+		c.logger.Error("consumer reader failed, reconnecting", zap.String("topic", topic), zap.Duration("delay", delay), zap.Error(err))
+		if !sleep(c.ctx, delay) {
+			return
+		}
 	}
 }
 
-func (c *consumer) Close() {
-	for _, reader := range c.readers {
-		if err := reader.Close(); err != nil {
-			c.logger.Error("consumer reader close", zap.Error(err))
+// read handles messages until fetching or committing fails and returns how
+// many were handled
+func (c *consumer) read(r *kafka.Reader, consumerConfig event.ConsumerConfig) (int, error) {
+	topic := consumerConfig.GetTopic()
+	for read := 0; ; read++ {
+		m, err := r.FetchMessage(c.ctx)
+		if err != nil {
+			if c.ctx.Err() == nil {
+				c.metrics.ConsumeFailed(topic, metrics.StageFetch)
+			}
+			return read, fmt.Errorf("failed to fetch message: %w", err)
+		}
+
+		if err := c.handle(c.ctx, consumerConfig, m); err != nil {
+			return read, err
 		}
+
+		if err := r.CommitMessages(c.ctx, m); err != nil {
+			if c.ctx.Err() == nil {
+				c.metrics.ConsumeFailed(topic, metrics.StageCommit)
+			}
+			return read, fmt.Errorf("failed to commit message: %w", err)
+		}
+		c.metrics.Consumed(topic, m.Partition, m.Offset, m.HighWaterMark)
 	}
 }
 
-func getTraceAndSpanId(msg kafka.Message) (string, string, error) {
+// handle runs the handler until it succeeds, the error is permanent or the
+// attempts run out, then the message goes to the dead letter topic. An error
+// is returned only when the consumer is closed before the message is done.
+func (c *consumer) handle(ctx context.Context, consumerConfig event.ConsumerConfig, m kafka.Message) error {
 	var (
-		spanId, traceId string
+		topic    = consumerConfig.GetTopic()
+		policy   = c.retryPolicy(consumerConfig)
+		otlpName = fmt.Sprintf("KafkaConsumer:%s", topic)
 	)
 
-	for _, header := range msg.Headers {
-		switch header.Key {
-		case "trace_id":
-			traceId = string(header.Value)
-		case "span_id":
-			spanId = string(header.Value)
-		default:
-			return "", "", errors.New(fmt.Sprintf("unknown header key: %s", header.Key))
+	// the span of the handler and the logs continue the trace of the producer,
+	// other headers are ignored
+	ctx = otlp.Extract(ctx, headerCarrier{headers: &m.Headers})
+
+	attempt := 1
+	for ; ; attempt++ {
+		err := c.process(ctx, otlpName, consumerConfig.GetHandler(), m)
+		if err == nil {
+			return nil
 		}
-	}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.metrics.ConsumeFailed(topic, metrics.StageHandle)
 
-	if len(traceId) == 0 {
-		return "", "", errors.New("missing trace_id field in kafka message header")
+		if event.IsPermanent(err) || attempt >= policy.MaxAttempts {
+			c.logger.Error("consumer failed to handle message:", append(otlp.LogFields(ctx),
+				zap.ByteString("value", m.Value), zap.String("topic", topic), zap.Int("attempts", attempt), zap.Error(err))...)
+			return c.deadLetter(ctx, consumerConfig, m, attempt, err)
+		}
+
+		delay := policy.Backoff(attempt)
+		c.logger.Warn("consumer failed to handle message, retrying", append(otlp.LogFields(ctx),
+			zap.String("topic", topic), zap.Int("attempt", attempt), zap.Duration("delay", delay), zap.Error(err))...)
+		if !sleep(ctx, delay) {
+			return ctx.Err()
+		}
 	}
+}
+
+// process runs the handler once in a span of its own
+func (c *consumer) process(ctx context.Context, otlpName string, handler func(ctx context.Context, key, value []byte) error, m kafka.Message) error {
+	ctx, span := otlp.Start(ctx, otlpName, "RunReaderRoutine")
 
-	if len(spanId) == 0 {
-		return "", "", errors.New("missing span_id field in kafka message header")
+	if err := handler(ctx, m.Key, m.Value); err != nil {
+		span.EndError(err)
+		return err
 	}
 
-	return traceId, spanId, nil
+	span.End()
+	return nil
 }
 
-func runReader(r *kafka.Reader, consumerConfig event.ConsumerConfig, logger *zap.Logger) {
-	var (
-		topic   = consumerConfig.GetTopic()
-		handler = consumerConfig.GetHandler()
-		// otlpName = fmt.Sprintf("KafkaConsumer:%s", topic)
-	)
-	for {
-		ctx := context.Background()
-		m, err := r.FetchMessage(ctx)
-		if err != nil {
-			logger.Error("consumer failed to fetch message:", zap.String("topic", topic), zap.Error(err))
-			break
-		}
+// deadLetter sends the message to the dead letter topic, retrying until the
+// broker stores it so the message is not committed before
+func (c *consumer) deadLetter(ctx context.Context, consumerConfig event.ConsumerConfig, m kafka.Message, attempts int, cause error) error {
+	deadLetterTopic := consumerConfig.GetDeadLetterTopic()
+	if deadLetterTopic == "" {
+		c.logger.Error("consumer dropped message without dead letter topic", zap.String("topic", m.Topic), zap.Int64("offset", m.Offset))
+		return nil
+	}
+
+	message := deadLetterMessage(m, deadLetterTopic, consumerConfig.GetGroupID(), attempts, cause, time.Now().UTC())
+	policy := c.retryPolicy(consumerConfig)
 
-		// traceId, spanId, err := getTraceAndSpanId(m)
-		// if err != nil {
-		// 	logger.Error(
-		// 		"failed to get span_id or trace_id of a kafka message",
-		// 		zap.Error(err),
-		// 		zap.String("topic", topic),
-		// 		zap.ByteString("value", m.Value),
-		// 	)
-		// }
-
-		// ctxOtlp, span, err := otlp.RestoreTraceContext(traceId, spanId)
-		// if err != nil {
-		// 	logger.Error(
-		// 		"failed to form context from trace_id and span_id",
-		// 		zap.Error(err),
-		// 		zap.String("topic", topic),
-		// 		zap.ByteString("value", m.Value),
-		// 	)
-		// } else {
-		// 	ctx, span = otlp.Start(ctxOtlp, otlpName, "RunReaderRoutine")
-		// }
-
-		if err := handler(ctx, m.Key, m.Value); err != nil {
-			logger.Error("consumer failed to handler message:", zap.ByteString("value", m.Value), zap.String("topic", topic), zap.Error(err))
-			continue
+	for attempt := 1; ; attempt++ {
+		err := c.deadLetters.WriteMessages(ctx, message)
+		if err == nil {
+			return nil
 		}
+		c.metrics.ConsumeFailed(m.Topic, metrics.StageDeadLetter)
 
-		if err := r.CommitMessages(ctx, m); err != nil {
-			logger.Error("consumer failed to commit messages:", zap.String("topic", topic), zap.Error(err))
+		delay := policy.Backoff(attempt)
+		c.logger.Error("consumer failed to write dead letter, retrying",
+			zap.String("topic", deadLetterTopic), zap.Duration("delay", delay), zap.Error(err))
+		if !sleep(ctx, delay) {
+			return ctx.Err()
 		}
+	}
+}
 
-		// if span != nil {
-		// 	span.End()
-		// }
+// sleep waits for the delay and returns false when the context is done first
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
 }
 
 type ConsumerConfig struct {
-	brokers []string
-	topic   string
-	groupID string
-	handler HandlerFunc
+	brokers         []string
+	topic           string
+	groupID         string
+	handler         HandlerFunc
+	retryPolicy     *event.RetryPolicy
+	deadLetterTopic string
 }
 
+// NewConsumerConfig creates a config using the retry policy of the consumer
+// and the topic with DeadLetterSuffix as its dead letter topic
 func NewConsumerConfig(
 	brokers []string,
 	topic string,
@@ -147,13 +267,27 @@ func NewConsumerConfig(
 	handler HandlerFunc,
 ) *ConsumerConfig {
 	return &ConsumerConfig{
-		brokers: brokers,
-		topic:   topic,
-		groupID: groupID,
-		handler: handler,
+		brokers:         brokers,
+		topic:           topic,
+		groupID:         groupID,
+		handler:         handler,
+		deadLetterTopic: topic + DeadLetterSuffix,
 	}
 }
 
+// WithRetryPolicy overrides the retry policy of the consumer
+func (c *ConsumerConfig) WithRetryPolicy(policy event.RetryPolicy) *ConsumerConfig {
+	c.retryPolicy = &policy
+	return c
+}
+
+// WithDeadLetterTopic overrides the dead letter topic, messages are dropped
+// after the last attempt when it is empty
+func (c *ConsumerConfig) WithDeadLetterTopic(topic string) *ConsumerConfig {
+	c.deadLetterTopic = topic
+	return c
+}
+
 func (c *ConsumerConfig) GetBrokers() []string {
 	return c.brokers
 }
@@ -169,3 +303,11 @@ func (c *ConsumerConfig) GetGroupID() string {
 func (c *ConsumerConfig) GetHandler() func(ctx context.Context, key, value []byte) error {
 	return c.handler
 }
+
+func (c *ConsumerConfig) GetRetryPolicy() *event.RetryPolicy {
+	return c.retryPolicy
+}
+
+func (c *ConsumerConfig) GetDeadLetterTopic() string {
+	return c.deadLetterTopic
+}
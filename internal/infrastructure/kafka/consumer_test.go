@@ -0,0 +1,179 @@
+package kafka
+
+import (
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// memoryWriter keeps written messages, failing the first writes with fail
+type memoryWriter struct {
+	messages []kafka.Message
+	fail     []error
+}
+
+func (w *memoryWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	if len(w.fail) != 0 {
+		err := w.fail[0]
+		w.fail = w.fail[1:]
+		return err
+	}
+	w.messages = append(w.messages, messages...)
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	return nil
+}
+
+func newTestConsumer(writer *memoryWriter) *consumer {
+	return &consumer{
+		logger:      zap.NewNop(),
+		retry:       event.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond},
+		deadLetters: writer,
+	}
+}
+
+// failingHandler fails the first calls with the errors
+func failingHandler(calls *int, errs ...error) HandlerFunc {
+	return func(ctx context.Context, key, value []byte) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+var testMessage = kafka.Message{
+	Topic:     "user.service",
+	Partition: 2,
+	Offset:    42,
+	Key:       []byte("u1"),
+	Value:     []byte(`{"user_id":"u1"}`),
+	Headers:   []kafka.Header{{Key: "traceparent", Value: []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")}},
+}
+
+func TestConsumerRetry(t *testing.T) {
+	writer := &memoryWriter{}
+	c := newTestConsumer(writer)
+
+	var calls int
+	config := NewConsumerConfig(nil, "user.service", "establishment", failingHandler(&calls, errors.New("db is down"), errors.New("db is down")))
+
+	assert.NoError(t, c.handle(context.Background(), config, testMessage))
+	assert.Equal(t, 3, calls)
+	assert.Empty(t, writer.messages)
+}
+
+func TestConsumerDeadLetter(t *testing.T) {
+	writer := &memoryWriter{fail: []error{errors.New("broker is down")}}
+	c := newTestConsumer(writer)
+
+	var calls int
+	failed := errors.New("db is down")
+	config := NewConsumerConfig(nil, "user.service", "establishment", failingHandler(&calls, failed, failed, failed))
+
+	// the dead letter is written once the broker is back
+	assert.NoError(t, c.handle(context.Background(), config, testMessage))
+	assert.Equal(t, 3, calls)
+	if !assert.Len(t, writer.messages, 1) {
+		return
+	}
+
+	deadLetter := writer.messages[0]
+	carrier := headerCarrier{headers: &deadLetter.Headers}
+	assert.Equal(t, "user.service.dlq", deadLetter.Topic)
+	assert.Equal(t, testMessage.Key, deadLetter.Key)
+	assert.Equal(t, testMessage.Value, deadLetter.Value)
+	assert.Equal(t, string(testMessage.Headers[0].Value), carrier.Get("traceparent"))
+	assert.Equal(t, "user.service", carrier.Get(HeaderDeadLetterTopic))
+	assert.Equal(t, "2", carrier.Get(HeaderDeadLetterPartition))
+	assert.Equal(t, "42", carrier.Get(HeaderDeadLetterOffset))
+	assert.Equal(t, "establishment", carrier.Get(HeaderDeadLetterGroup))
+	assert.Equal(t, "3", carrier.Get(HeaderDeadLetterAttempts))
+	assert.Equal(t, "db is down", carrier.Get(HeaderDeadLetterError))
+	assert.NotEmpty(t, carrier.Get(HeaderDeadLetterFailedAt))
+
+	// replaying restores the original message
+	replayed, err := replayMessage(deadLetter, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "user.service", replayed.Topic)
+	assert.Equal(t, testMessage.Key, replayed.Key)
+	assert.Equal(t, testMessage.Headers, replayed.Headers)
+
+	replayed, err = replayMessage(deadLetter, "user.service.retry")
+	assert.NoError(t, err)
+	assert.Equal(t, "user.service.retry", replayed.Topic)
+
+	_, err = replayMessage(kafka.Message{Value: testMessage.Value}, "")
+	assert.Error(t, err)
+}
+
+func TestConsumerPoisonMessage(t *testing.T) {
+	writer := &memoryWriter{}
+	c := newTestConsumer(writer)
+
+	var calls int
+	config := NewConsumerConfig(nil, "user.service", "establishment", failingHandler(&calls, event.Permanent(errors.New("invalid json"))))
+
+	// permanent errors are not retried
+	assert.NoError(t, c.handle(context.Background(), config, testMessage))
+	assert.Equal(t, 1, calls)
+	if assert.Len(t, writer.messages, 1) {
+		carrier := headerCarrier{headers: &writer.messages[0].Headers}
+		assert.Equal(t, "1", carrier.Get(HeaderDeadLetterAttempts))
+		assert.Equal(t, "invalid json", carrier.Get(HeaderDeadLetterError))
+	}
+}
+
+func TestConsumerConfigOverrides(t *testing.T) {
+	writer := &memoryWriter{}
+	c := newTestConsumer(writer)
+
+	var calls int
+	failed := errors.New("db is down")
+	config := NewConsumerConfig(nil, "user.service", "establishment", failingHandler(&calls, failed, failed, failed)).
+		WithRetryPolicy(event.RetryPolicy{MaxAttempts: 1}).
+		WithDeadLetterTopic("")
+
+	// the message is dropped after its only attempt
+	assert.NoError(t, c.handle(context.Background(), config, testMessage))
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, writer.messages)
+}
+
+func TestConsumerClosed(t *testing.T) {
+	writer := &memoryWriter{}
+	c := newTestConsumer(writer)
+	c.retry.MinBackoff = time.Hour
+	c.retry.MaxBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	config := NewConsumerConfig(nil, "user.service", "establishment", func(ctx context.Context, key, value []byte) error {
+		calls++
+		cancel()
+		return errors.New("db is down")
+	})
+
+	// the message is not committed, it is redelivered after a restart
+	assert.ErrorIs(t, c.handle(ctx, config, testMessage), context.Canceled)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, writer.messages)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := event.RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 8*time.Second, policy.Backoff(4))
+	assert.Equal(t, 10*time.Second, policy.Backoff(5))
+}
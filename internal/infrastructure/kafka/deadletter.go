@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// headers a dead letter carries besides the ones of the original message
+const (
+	headerDeadLetterPrefix    = "x-dlq-"
+	HeaderDeadLetterTopic     = headerDeadLetterPrefix + "original-topic"
+	HeaderDeadLetterPartition = headerDeadLetterPrefix + "original-partition"
+	HeaderDeadLetterOffset    = headerDeadLetterPrefix + "original-offset"
+	HeaderDeadLetterGroup     = headerDeadLetterPrefix + "consumer-group"
+	HeaderDeadLetterAttempts  = headerDeadLetterPrefix + "attempts"
+	HeaderDeadLetterError     = headerDeadLetterPrefix + "error"
+	HeaderDeadLetterFailedAt  = headerDeadLetterPrefix + "failed-at"
+)
+
+// deadLetterMessage keeps the key, the value and the headers of the message
+// and adds where it came from and why it failed
+func deadLetterMessage(m kafka.Message, topic, groupID string, attempts int, cause error, failedAt time.Time) kafka.Message {
+	headers := make([]kafka.Header, 0, len(m.Headers)+7)
+	for _, header := range m.Headers {
+		// a replayed message failing again gets new error metadata
+		if !strings.HasPrefix(header.Key, headerDeadLetterPrefix) {
+			headers = append(headers, header)
+		}
+	}
+
+	headers = append(headers,
+		kafka.Header{Key: HeaderDeadLetterTopic, Value: []byte(m.Topic)},
+		kafka.Header{Key: HeaderDeadLetterPartition, Value: []byte(strconv.Itoa(m.Partition))},
+		kafka.Header{Key: HeaderDeadLetterOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+		kafka.Header{Key: HeaderDeadLetterGroup, Value: []byte(groupID)},
+		kafka.Header{Key: HeaderDeadLetterAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDeadLetterError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDeadLetterFailedAt, Value: []byte(failedAt.Format(time.RFC3339Nano))},
+	)
+
+	return kafka.Message{
+		Topic:   topic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	}
+}
+
+// replayMessage restores a dead letter as it was sent to its original topic,
+// or to topic when it is not empty
+func replayMessage(m kafka.Message, topic string) (kafka.Message, error) {
+	headers := make([]kafka.Header, 0, len(m.Headers))
+	for _, header := range m.Headers {
+		if header.Key == HeaderDeadLetterTopic && topic == "" {
+			topic = string(header.Value)
+		}
+		if !strings.HasPrefix(header.Key, headerDeadLetterPrefix) {
+			headers = append(headers, header)
+		}
+	}
+
+	if topic == "" {
+		return kafka.Message{}, fmt.Errorf("dead letter at offset %d has no %s header", m.Offset, HeaderDeadLetterTopic)
+	}
+
+	return kafka.Message{
+		Topic:   topic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	}, nil
+}
+
+// Replayer sends the messages of a dead letter topic back to the topics they
+// failed on. It reads with its own consumer group, so replayed messages are
+// committed and not replayed twice.
+type Replayer struct {
+	logger *zap.Logger
+	reader *kafka.Reader
+	writer messageWriter
+	topic  string
+}
+
+// NewReplayer creates a replayer of the dead letter topic, messages go to
+// topic instead of their original topic when it is not empty
+func NewReplayer(config *config.Config, logger *zap.Logger, deadLetterTopic, groupID, topic string) *Replayer {
+	return &Replayer{
+		logger: logger,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  config.Kafka.Address,
+			Topic:    deadLetterTopic,
+			GroupID:  groupID,
+			MinBytes: MinBytes,
+			MaxBytes: MaxBytes,
+		}),
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(config.Kafka.Address...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+		topic: topic,
+	}
+}
+
+// Replay sends up to limit messages, all of them when it is 0, and stops
+// once no message arrives for idle. It returns how many were replayed.
+func (r *Replayer) Replay(ctx context.Context, limit int, idle time.Duration) (int, error) {
+	replayed := 0
+	for limit == 0 || replayed < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, idle)
+		m, err := r.reader.FetchMessage(fetchCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				return replayed, nil
+			}
+			return replayed, fmt.Errorf("failed to fetch dead letter: %w", err)
+		}
+
+		message, err := replayMessage(m, r.topic)
+		if err != nil {
+			return replayed, err
+		}
+
+		if err := r.writer.WriteMessages(ctx, message); err != nil {
+			return replayed, fmt.Errorf("failed to replay dead letter to %s: %w", message.Topic, err)
+		}
+
+		if err := r.reader.CommitMessages(ctx, m); err != nil {
+			return replayed, fmt.Errorf("failed to commit dead letter: %w", err)
+		}
+
+		r.logger.Info("replayed dead letter", zap.String("topic", message.Topic), zap.Int64("offset", m.Offset))
+		replayed++
+	}
+	return replayed, nil
+}
+
+func (r *Replayer) Close() {
+	if err := r.reader.Close(); err != nil {
+		r.logger.Error("replayer reader close", zap.Error(err))
+	}
+	if err := r.writer.Close(); err != nil {
+		r.logger.Error("replayer writer close", zap.Error(err))
+	}
+}
@@ -1,74 +1,89 @@
 package kafka
 
 import (
-	"Booking/establishment-service-booking/internal/entity"
 	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/metrics"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/usecase/event"
 	"context"
+	"fmt"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
+// headerTraceparent is the W3C trace context header
+const headerTraceparent = "traceparent"
+
 type producer struct {
-	logger            *zap.Logger
-	investmentCreated *kafka.Writer
+	logger              *zap.Logger
+	metrics             *metrics.Metrics
+	addresses           []string
+	establishmentEvents *kafka.Writer
 }
 
-func NewProducer(config *config.Config, logger *zap.Logger) *producer {
+func NewProducer(config *config.Config, logger *zap.Logger, metrics *metrics.Metrics) *producer {
 	return &producer{
-		logger: logger,
-		investmentCreated: &kafka.Writer{
+		logger:    logger,
+		metrics:   metrics,
+		addresses: config.Kafka.Address,
+		// writes are synchronous, the outbox relay retries failed messages
+		establishmentEvents: &kafka.Writer{
 			Addr:                   kafka.TCP(config.Kafka.Address...),
-			Topic:                  config.Kafka.Topic.UserService,
+			Topic:                  config.Kafka.Topic.EstablishmentEvents,
 			Balancer:               &kafka.Hash{},
 			RequiredAcks:           kafka.RequireAll,
 			AllowAutoTopicCreation: true,
-			Async:                  true, // make the writer asynchronous
-			Completion: func(messages []kafka.Message, err error) {
-				if err != nil {
-					logger.Error("kafka investmentCreated", zap.Error(err))
-				}
-				for _, message := range messages {
-					logger.Sugar().Info(
-						"kafka investmentCreated message",
-						zap.Int("partition", message.Partition),
-						zap.Int64("offset", message.Offset),
-						zap.String("key", string(message.Key)),
-						zap.String("value", string(message.Value)),
-					)
-				}
-			},
 		},
 	}
 }
 
-func (p *producer) buildMessageWithTracing(key string, value []byte) kafka.Message {
+// buildMessageWithTracing carries the trace context in the headers, messages
+// from the outbox already hold the one of the request that stored them
+func (p *producer) buildMessageWithTracing(ctx context.Context, message *event.Message) kafka.Message {
+	headers := make([]kafka.Header, 0, len(message.Headers)+2)
+	for key, value := range message.Headers {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	carrier := headerCarrier{headers: &headers}
+	if carrier.Get(headerTraceparent) == "" {
+		otlp.Inject(ctx, carrier)
+	}
+
 	return kafka.Message{
-		Key:   []byte(key),
-		Value: value,
-		// Headers: []kafka.Header{
-		// 	{
-		// 		Key:   "trace_id",
-		// 		Value: []byte(otlpSpan.SpanContext().TraceID().String()),
-		// 	},
-		// 	// {
-		// 	// 	Key:   "span_id",
-		// 	// 	Value: []byte(otlpSpan.SpanContext().SpanID().String()),
-		// 	// },
-		// },
+		Key:     []byte(message.Key),
+		Value:   message.Value,
+		Headers: headers,
 	}
 }
 
-func (p *producer) ProduceContent(ctx context.Context, key string, value *entity.Attraction) error {
-	// tracing
-	// ctx, span := otlp.Start(ctx, "kafka producer", "ContentProducer")
-	// defer span.End()
+func (p *producer) Publish(ctx context.Context, message *event.Message) error {
+	err := p.establishmentEvents.WriteMessages(ctx, p.buildMessageWithTracing(ctx, message))
+	p.metrics.Published(p.establishmentEvents.Topic, err)
+	if err != nil {
+		return err
+	}
 
+	p.logger.Debug("kafka establishmentEvents message", zap.String("key", message.Key))
 	return nil
 }
 
-func (p *producer) Close() {
-	if err := p.investmentCreated.Close(); err != nil {
-		p.logger.Error("error during close writer userCategoryCreated", zap.Error(err))
+// Ping connects to the brokers until one answers
+func (p *producer) Ping(ctx context.Context) error {
+	var err error
+	for _, address := range p.addresses {
+		var conn *kafka.Conn
+		if conn, err = kafka.DialContext(ctx, "tcp", address); err == nil {
+			return conn.Close()
+		}
 	}
+	return err
+}
+
+func (p *producer) Close() error {
+	if err := p.establishmentEvents.Close(); err != nil {
+		return fmt.Errorf("error during close writer establishmentEvents: %w", err)
+	}
+	return nil
 }
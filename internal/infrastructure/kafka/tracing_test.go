@@ -0,0 +1,74 @@
+package kafka
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(t *testing.T) trace.SpanContext {
+	traceId, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.NoError(t, err)
+	spanId, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	assert.NoError(t, err)
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+func TestTracePropagation(t *testing.T) {
+	otlp.SetPropagator()
+	spanContext := testSpanContext(t)
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	p := &producer{}
+	message := p.buildMessageWithTracing(ctx, &event.Message{
+		Key:     "h1",
+		Value:   []byte("{}"),
+		Headers: map[string]string{"event_type": "hotel.created"},
+	})
+
+	// unrelated headers are kept and ignored on consume
+	carrier := headerCarrier{headers: &message.Headers}
+	assert.Equal(t, "hotel.created", carrier.Get("event_type"))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", carrier.Get("traceparent"))
+
+	restored := trace.SpanContextFromContext(otlp.Extract(context.Background(), carrier))
+	assert.Equal(t, spanContext.TraceID(), restored.TraceID())
+	assert.Equal(t, spanContext.SpanID(), restored.SpanID())
+	assert.True(t, restored.IsRemote())
+}
+
+func TestTracePropagationFromOutbox(t *testing.T) {
+	otlp.SetPropagator()
+
+	// the outbox stored the trace of the request, the relay does not replace it
+	headers := map[string]string{"event_type": "hotel.updated"}
+	otlp.Inject(trace.ContextWithSpanContext(context.Background(), testSpanContext(t)), propagation.MapCarrier(headers))
+
+	p := &producer{}
+	message := p.buildMessageWithTracing(context.Background(), &event.Message{Key: "h1", Headers: headers})
+
+	restored := trace.SpanContextFromContext(otlp.Extract(context.Background(), headerCarrier{headers: &message.Headers}))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", restored.TraceID().String())
+}
+
+func TestHeaderCarrierWithoutTrace(t *testing.T) {
+	otlp.SetPropagator()
+
+	headers := []kafka.Header{{Key: "trace_id", Value: []byte("garbage")}, {Key: "source", Value: []byte("user-service")}}
+	ctx := otlp.Extract(context.Background(), headerCarrier{headers: &headers})
+
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.ElementsMatch(t, []string{"trace_id", "source"}, headerCarrier{headers: &headers}.Keys())
+}
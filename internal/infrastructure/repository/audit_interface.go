@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+)
+
+type Audit interface {
+	CreateAuditEvent(ctx context.Context, event *entity.AuditEvent) error
+	// ListAuditEvents returns a page of the events matching the filter, newest first, and their total count
+	ListAuditEvents(ctx context.Context, filter *entity.AuditFilter, offset, limit uint64) ([]*entity.AuditEvent, uint64, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+	"time"
+)
+
+// Booking keeps the bookings of the booking service and the number of
+// bookings of every establishment per day
+type Booking interface {
+	GetBooking(ctx context.Context, booking_id string) (*entity.Booking, error)
+	SaveBooking(ctx context.Context, booking *entity.Booking) error
+	// AddBookingCount changes the bookings of the establishment on the day of at
+	AddBookingCount(ctx context.Context, establishment_id string, at time.Time, delta int64) error
+	// CountBookings sums the bookings of the days from the one of since
+	CountBookings(ctx context.Context, establishment_id string, since time.Time) (int64, error)
+}
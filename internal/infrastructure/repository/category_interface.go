@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+)
+
+type Category interface {
+	ListCategories(ctx context.Context, establishment_type string) ([]*entity.Category, error)
+	ListEstablishmentCategories(ctx context.Context, establishment_id string) ([]*entity.Category, error)
+	SetEstablishmentCategories(ctx context.Context, establishment_type, establishment_id string, codes []string) ([]*entity.Category, error)
+}
@@ -7,6 +7,7 @@ import (
 
 type Favourite interface {
 	AddToFavourites(ctx context.Context, favourite *entity.Favourite) (*entity.Favourite, error)
+	GetFavourite(ctx context.Context, favourite_id string) (*entity.Favourite, error)
 	RemoveFromFavourites(ctx context.Context, favourite_id string) error
 	ListFavouritesByUserId(ctx context.Context, user_id string) ([]*entity.Favourite, error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+)
+
+type Idempotency interface {
+	// ReserveIdempotencyKey stores the record unless a live one exists for
+	// the key, the existing record is returned in that case
+	ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
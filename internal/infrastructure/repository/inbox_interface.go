@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Inbox remembers the events of other services already handled
+type Inbox interface {
+	// MarkEventProcessed returns false when the event was processed before,
+	// called in the transaction of the changes the event makes
+	MarkEventProcessed(ctx context.Context, event_id, event_type string, processed_at time.Time) (bool, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+	"time"
+)
+
+type Outbox interface {
+	AddOutboxMessage(ctx context.Context, message *entity.OutboxMessage) error
+	// LockOutbox takes the relay lock until the end of the transaction,
+	// false means another relay holds it
+	LockOutbox(ctx context.Context) (bool, error)
+	// ListPendingOutboxMessages returns the oldest pending message of every
+	// key if it is due, so a message waiting for a retry holds back the later
+	// messages of its key
+	ListPendingOutboxMessages(ctx context.Context, now time.Time, limit uint64) ([]*entity.OutboxMessage, error)
+	// ClaimOutboxMessages makes the messages due only at until, a relay
+	// sending them outside of its transaction keeps other relays off them
+	ClaimOutboxMessages(ctx context.Context, message_ids []int64, until time.Time) error
+	MarkOutboxMessageSent(ctx context.Context, message_id int64, sent_at time.Time) error
+	RetryOutboxMessage(ctx context.Context, message *entity.OutboxMessage) error
+	DeleteSentOutboxMessages(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+)
+
+type Ownership interface {
+	GetOwnerId(ctx context.Context, entity_id string) (string, error)
+	GetEstablishmentRef(ctx context.Context, establishment_id string) (*entity.EstablishmentRef, error)
+	ListEstablishmentsByOwner(ctx context.Context, owner_id string) ([]*entity.EstablishmentRef, error)
+}
@@ -43,6 +43,7 @@ func (p *attractionRepo) AttractionSelectQueryPrefix() squirrel.SelectBuilder {
 		"website_url",
 		"created_at",
 		"updated_at",
+		"version",
 	).From(p.tableName)
 }
 
@@ -62,19 +63,19 @@ func (p attractionRepo) CreateAttraction(ctx context.Context, attraction *entity
 		"country":          attraction.Location.Country,
 		"city":             attraction.Location.City,
 		"state_province":   attraction.Location.StateProvince,
-		"category":         attraction.Location.Category,
+		"category":         entity.EstablishmentTypeAttraction,
 		"created_at":       attraction.Location.CreatedAt,
 		"updated_at":       attraction.Location.UpdatedAt,
 	}
 
 	query, args, err := p.db.Sq.Builder.Insert(locationTableName).SetMap(dataL).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for creating attraction' location part: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for creating attraction' location part: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for creating attraction's location: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for creating attraction's location: %w", p.db.Error(err))
 	}
 
 	// insert images to image_table
@@ -83,6 +84,7 @@ func (p attractionRepo) CreateAttraction(ctx context.Context, attraction *entity
 			"image_id":         image.ImageId,
 			"establishment_id": attraction.AttractionId,
 			"image_url":        image.ImageUrl,
+			"caption":          image.Caption,
 			"category":         image.Category,
 			"created_at":       image.CreatedAt,
 			"updated_at":       image.UpdatedAt,
@@ -90,12 +92,12 @@ func (p attractionRepo) CreateAttraction(ctx context.Context, attraction *entity
 
 		query, args, err := p.db.Sq.Builder.Insert(imageTableName).SetMap(dataI).ToSql()
 		if err != nil {
-			return nil, fmt.Errorf("failed to build SQL query for creating image: %v", err)
+			return nil, fmt.Errorf("failed to build SQL query for creating image: %w", err)
 		}
 
 		_, err = p.db.Exec(ctx, query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to execute SQL query for creating image: %v", err)
+			return nil, fmt.Errorf("failed to execute SQL query for creating image: %w", p.db.Error(err))
 		}
 	}
 
@@ -114,14 +116,29 @@ func (p attractionRepo) CreateAttraction(ctx context.Context, attraction *entity
 	}
 	query, args, err = p.db.Sq.Builder.Insert(p.tableName).SetMap(data).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for creating attraction: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for creating attraction: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for creating attraction: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for creating attraction: %w", p.db.Error(err))
 	}
 
+	// link categories of the attraction
+	if len(attraction.Categories) != 0 {
+		codes := make([]string, 0, len(attraction.Categories))
+		for _, category := range attraction.Categories {
+			codes = append(codes, category.Code)
+		}
+
+		attraction.Categories, err = replaceEstablishmentCategories(ctx, p.db, entity.EstablishmentTypeAttraction, attraction.AttractionId, codes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attraction.Version = initialVersion
+
 	return attraction, nil
 }
 
@@ -139,7 +156,7 @@ func (p attractionRepo) GetAttraction(ctx context.Context, attraction_id string)
 	// Get the SQL query and arguments from the query builder
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for getting attraction: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for getting attraction: %w", err)
 	}
 
 	// Execute the query to fetch attraction details
@@ -154,8 +171,9 @@ func (p attractionRepo) GetAttraction(ctx context.Context, attraction_id string)
 		&attraction.WebsiteUrl,
 		&attraction.CreatedAt,
 		&attraction.UpdatedAt,
+		&attraction.Version,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get attraction: %v", err)
+		return nil, fmt.Errorf("failed to get attraction: %w", p.db.Error(err))
 	}
 
 	// Fetch location information
@@ -172,14 +190,14 @@ func (p attractionRepo) GetAttraction(ctx context.Context, attraction_id string)
 		&attraction.Location.CreatedAt,
 		&attraction.Location.UpdatedAt,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get location for attraction: %v", err)
+		return nil, fmt.Errorf("failed to get location for attraction: %w", p.db.Error(err))
 	}
 
 	// Fetch images information
-	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 	rows, err := p.db.Query(ctx, imagesQuery, attraction_id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get images for attraction: %v", err)
+		return nil, fmt.Errorf("failed to get images for attraction: %w", p.db.Error(err))
 	}
 	defer rows.Close()
 
@@ -190,22 +208,29 @@ func (p attractionRepo) GetAttraction(ctx context.Context, attraction_id string)
 			&image.ImageId,
 			&image.EstablishmentId,
 			&image.ImageUrl,
+			&image.Caption,
 			&image.CreatedAt,
 			&image.UpdatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan image row: %v", err)
+			return nil, fmt.Errorf("failed to scan image row: %w", p.db.Error(err))
 		}
 		attraction.Images = append(attraction.Images, &image)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error encountered while iterating over image rows: %v", err)
+		return nil, fmt.Errorf("error encountered while iterating over image rows: %w", p.db.Error(err))
+	}
+
+	// Fetch categories information
+	attraction.Categories, err = selectEstablishmentCategories(ctx, p.db, attraction.AttractionId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &attraction, nil
 }
 
 // get a list of attractions
-func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64) ([]*entity.Attraction, uint64, error) {
+func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Attraction, uint64, error) {
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, attractionSpanRepoPrefix+"List")
 	defer span.End()
@@ -213,9 +238,11 @@ func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64
 	var attractions []*entity.Attraction
 
 	queryBuilder := p.AttractionSelectQueryPrefix()
+	queryBuilder = applyFilter(queryBuilder, "attraction_id", filter)
+	queryBuilder = applySort(queryBuilder, "attraction_id", filter)
 
 	if limit != 0 {
-		queryBuilder = queryBuilder.Limit(uint64(limit)).Offset(uint64(offset)).Where(p.db.Sq.Equal("deleted_at", nil)).OrderBy("rating DESC")
+		queryBuilder = queryBuilder.Limit(uint64(limit)).Offset(uint64(offset)).Where(p.db.Sq.Equal("deleted_at", nil))
 	}
 
 	query, args, err := queryBuilder.ToSql()
@@ -225,7 +252,7 @@ func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64
 
 	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, p.db.Error(err)
 	}
 	defer rows.Close()
 
@@ -243,8 +270,9 @@ func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64
 			&attraction.WebsiteUrl,
 			&attraction.CreatedAt,
 			&attraction.UpdatedAt,
+			&attraction.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch location information for the attraction
@@ -261,14 +289,14 @@ func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64
 			&attraction.Location.CreatedAt,
 			&attraction.Location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch images information for the attraction
-		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 		imageRows, err := p.db.Query(ctx, imagesQuery, attraction.AttractionId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Iterate over the image rows and populate the Images slice for the attraction
@@ -279,29 +307,42 @@ func (p attractionRepo) ListAttractions(ctx context.Context, offset, limit int64
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 			attraction.Images = append(attraction.Images, &image)
 		}
 		if err := imageRows.Err(); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Append the attraction to the attractions slice
+		// Fetch categories information for the attraction
+		attraction.Categories, err = selectEstablishmentCategories(ctx, p.db, attraction.AttractionId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		attractions = append(attractions, &attraction)
 	}
 
 	var overall uint64
 
-	queryC := `SELECT COUNT(*) FROM attraction_table WHERE deleted_at IS NULL`
+	countBuilder := p.db.Sq.Builder.Select("COUNT(*)").From(p.tableName).Where(p.db.Sq.Equal("deleted_at", nil))
+	countBuilder = applyFilter(countBuilder, "attraction_id", filter)
 
-	if err := p.db.QueryRow(ctx, queryC).Scan(&overall); err != nil {
+	queryC, argsC, err := countBuilder.ToSql()
+	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&overall); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
 	return attractions, overall, nil
 }
 
@@ -318,24 +359,26 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 		"contact_number":  request.ContactNumber,
 		"licence_url":     request.LicenceUrl,
 		"website_url":     request.WebsiteUrl,
-		"updated_at":      time.Now().Local(),
+		"updated_at":      request.UpdatedAt,
+		"version":         squirrel.Expr("version + 1"),
 	}
 
 	sqlStr, args, err := p.db.Sq.Builder.Update(p.tableName).
 		SetMap(clauses).
 		Where(p.db.Sq.Equal("attraction_id", request.AttractionId), p.db.Sq.Equal("deleted_at", nil)).
+		Where(p.db.Sq.Equal("version", request.Version)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for updating attracation: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for updating attracation: %w", err)
 	}
 
 	commandTag, err := p.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for updating attraction: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for updating attraction: %w", p.db.Error(err))
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return nil, fmt.Errorf("no rows affected while updating attraction")
+		return nil, versionMismatch(ctx, p.db, p.tableName, "attraction_id", request.AttractionId, "attraction")
 	}
 
 	clausesL := map[string]interface{}{
@@ -345,7 +388,7 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 		"country":        request.Location.Country,
 		"city":           request.Location.City,
 		"state_province": request.Location.StateProvince,
-		"updated_at":     time.Now().Local(),
+		"updated_at":     request.UpdatedAt,
 	}
 
 	sqlStrL, args, err := p.db.Sq.Builder.Update("location_table").
@@ -353,16 +396,28 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 		Where(p.db.Sq.Equal("establishment_id", request.AttractionId), p.db.Sq.Equal("deleted_at", nil)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for updating location: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for updating location: %w", err)
 	}
 
 	commandTagL, err := p.db.Exec(ctx, sqlStrL, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for updating attraction: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for updating attraction: %w", p.db.Error(err))
 	}
 
 	if commandTagL.RowsAffected() == 0 {
-		return nil, fmt.Errorf("no rows affected while updating attraction")
+		return nil, entity.NewErrNotFound("attraction")
+	}
+
+	// replace categories of the attraction when they are given
+	if request.Categories != nil {
+		codes := make([]string, 0, len(request.Categories))
+		for _, category := range request.Categories {
+			codes = append(codes, category.Code)
+		}
+
+		if _, err := replaceEstablishmentCategories(ctx, p.db, entity.EstablishmentTypeAttraction, request.AttractionId, codes); err != nil {
+			return nil, err
+		}
 	}
 
 	var attraction entity.Attraction
@@ -373,14 +428,14 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 	// Get the SQL query and arguments from the query builder
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for getting attraction: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for getting attraction: %w", err)
 	}
 
 	// Execute the query to fetch attraction details
 	if err := p.db.QueryRow(ctx, query, args...).Scan(
 		&attraction.AttractionId,
-		&attraction.AttractionName,
 		&attraction.OwnerId,
+		&attraction.AttractionName,
 		&attraction.Description,
 		&attraction.Rating,
 		&attraction.ContactNumber,
@@ -388,8 +443,9 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 		&attraction.WebsiteUrl,
 		&attraction.CreatedAt,
 		&attraction.UpdatedAt,
+		&attraction.Version,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get attraction: %v", err)
+		return nil, fmt.Errorf("failed to get attraction: %w", p.db.Error(err))
 	}
 
 	// Fetch location information
@@ -406,14 +462,14 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 		&attraction.Location.CreatedAt,
 		&attraction.Location.UpdatedAt,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get location for attraction: %v", err)
+		return nil, fmt.Errorf("failed to get location for attraction: %w", p.db.Error(err))
 	}
 
 	// Fetch images information
-	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 	rows, err := p.db.Query(ctx, imagesQuery, request.AttractionId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get images for attraction: %v", err)
+		return nil, fmt.Errorf("failed to get images for attraction: %w", p.db.Error(err))
 	}
 	defer rows.Close()
 
@@ -424,51 +480,59 @@ func (p attractionRepo) UpdateAttraction(ctx context.Context, request *entity.At
 			&image.ImageId,
 			&image.EstablishmentId,
 			&image.ImageUrl,
+			&image.Caption,
 			&image.CreatedAt,
 			&image.UpdatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan image row: %v", err)
+			return nil, fmt.Errorf("failed to scan image row: %w", p.db.Error(err))
 		}
 		attraction.Images = append(attraction.Images, &image)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error encountered while iterating over image rows: %v", err)
+		return nil, fmt.Errorf("error encountered while iterating over image rows: %w", p.db.Error(err))
+	}
+
+	// Fetch categories information
+	attraction.Categories, err = selectEstablishmentCategories(ctx, p.db, attraction.AttractionId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &attraction, nil
 }
 
 // delete an attraction softly
-func (p attractionRepo) DeleteAttraction(ctx context.Context, attraction_id string) error {
+func (p attractionRepo) DeleteAttraction(ctx context.Context, attraction_id string, version int64, deleted_at time.Time) error {
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, attractionSpanRepoPrefix+"Delete")
 	defer span.End()
 
 	// Build the SQL query
 	sqlStr, args, err := p.db.Sq.Builder.Update(p.tableName).
-		Set("deleted_at", time.Now().Local()).
+		Set("deleted_at", deleted_at).
 		Where(p.db.Sq.Equal("attraction_id", attraction_id)).
+		Where(p.db.Sq.Equal("deleted_at", nil), p.db.Sq.Equal("version", version)).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build SQL query for deleting attraction: %v", err)
+		return fmt.Errorf("failed to build SQL query for deleting attraction: %w", err)
 	}
 
 	// Execute the SQL query
 	commandTag, err := p.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return fmt.Errorf("failed to execute SQL query for deleting attraction: %v", err)
+		return fmt.Errorf("failed to execute SQL query for deleting attraction: %w", p.db.Error(err))
 	}
 
 	// Check if any rows were affected
 	if commandTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows affected while deleting attraction")
+		return versionMismatch(ctx, p.db, p.tableName, "attraction_id", attraction_id, "attraction")
 	}
 
 	return nil
 }
 
 // list attractions by location
-func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Attraction, int64, error) {
+func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Attraction, int64, error) {
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, attractionSpanRepoPrefix+"ListL")
 	defer span.End()
@@ -477,11 +541,22 @@ func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, l
 	cityStr := "%"+city+"%"
 	stateStr := "%"+state_province+"%"
 
-	queryL := fmt.Sprintf("SELECT establishment_id FROM location_table WHERE country LIKE '%s' and city LIKE '%s' and state_province LIKE '%s' and category = 'attraction' and deleted_at IS NULL LIMIT $1 OFFSET $2",countryStr, cityStr, stateStr)
-	rows, err := p.db.Query(ctx, queryL, limit, offset)
+	// establishments of this type only, the location category is not trusted
+	locationBuilder := p.db.Sq.Builder.Select("establishment_id").From(locationTableName).
+		Where(squirrel.Like{"country": countryStr, "city": cityStr, "state_province": stateStr}).
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		Where(squirrel.Expr("establishment_id IN (SELECT attraction_id FROM attraction_table WHERE deleted_at IS NULL)"))
+	locationBuilder = applyFilter(locationBuilder, "establishment_id", filter)
+
+	queryL, argsL, err := locationBuilder.Limit(limit).Offset(offset).ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
+
+	rows, err := p.db.Query(ctx, queryL, argsL...)
+	if err != nil {
+		return nil, 0, p.db.Error(err)
+	}
 	defer rows.Close()
 
 	var attractions []*entity.Attraction
@@ -491,12 +566,12 @@ func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, l
 		var establishment_id string
 
 		if err := rows.Scan(&establishment_id); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		var attraction entity.Attraction
 
-		queryA := `SELECT attraction_id, owner_id, attraction_name, description, rating, contact_number, licence_url, website_url, created_at, updated_at FROM attraction_table WHERE attraction_id = $1`
+		queryA := `SELECT attraction_id, owner_id, attraction_name, description, rating, contact_number, licence_url, website_url, created_at, updated_at, version FROM attraction_table WHERE attraction_id = $1`
 
 		if err := p.db.QueryRow(ctx, queryA, establishment_id).Scan(
 			&attraction.AttractionId,
@@ -509,8 +584,9 @@ func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, l
 			&attraction.WebsiteUrl,
 			&attraction.CreatedAt,
 			&attraction.UpdatedAt,
+			&attraction.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		var location entity.Location
@@ -529,16 +605,16 @@ func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, l
 			&location.CreatedAt,
 			&location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		attraction.Location = location
 
-		queryI := `SELECT image_id, establishment_id, image_url, created_at, updated_at FROM image_table WHERE establishment_id = $1`
+		queryI := `SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM image_table WHERE establishment_id = $1`
 
 		rowsI, err := p.db.Query(ctx, queryI, attraction.AttractionId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 		defer rowsI.Close()
 
@@ -551,10 +627,11 @@ func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, l
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 
 			images = append(images, &image)
@@ -562,48 +639,51 @@ func (p attractionRepo) ListAttractionsByLocation(ctx context.Context, offset, l
 
 		attraction.Images = images
 
+		// Fetch categories information for the attraction
+		attraction.Categories, err = selectEstablishmentCategories(ctx, p.db, attraction.AttractionId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		attractions = append(attractions, &attraction)
 	}
 
 	var count int64
 
-	queryC := `SELECT COUNT(*) establishment_id FROM location_table where category = 'attraction'`
-
-	if err := p.db.QueryRow(ctx, queryC).Scan(&count); err != nil {
+	queryC, argsC, err := locationBuilder.RemoveColumns().Columns("COUNT(*)").ToSql()
+	if err != nil {
 		return attractions, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&count); err != nil {
+		return attractions, 0, p.db.Error(err)
+	}
+
 	return attractions, count, nil
 }
 
 // find attractions by name
-func (p attractionRepo) FindAttractionsByName(ctx context.Context, name string) ([]*entity.Attraction, uint64, error) {
+func (p attractionRepo) FindAttractionsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Attraction, uint64, error) {
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, attractionSpanRepoPrefix+"Find")
 	defer span.End()
 
 	var attractions []*entity.Attraction
 
-	query := `SELECT
-  attraction_id,
-  attraction_name,
-  owner_id,
-  description,
-  rating,
-  contact_number,
-  licence_url,
-  website_url,
-  created_at,
-  updated_at
-  FROM attraction_table
-  WHERE deleted_at IS NULL
-  AND attraction_name ILIKE '%' || $1 || '%'
-  ORDER BY rating DESC`
-
-	rows, err := p.db.Query(ctx, query, name)
+	findBuilder := p.AttractionSelectQueryPrefix().
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		Where(p.db.Sq.ILike("attraction_name", "%"+name+"%"))
+	findBuilder = applyFilter(findBuilder, "attraction_id", filter)
+
+	query, args, err := applySort(findBuilder, "attraction_id", filter).ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, p.db.Error(err)
+	}
 	defer rows.Close()
 
 	// Iterate over the rows to fetch each attraction's details
@@ -620,8 +700,9 @@ func (p attractionRepo) FindAttractionsByName(ctx context.Context, name string)
 			&attraction.WebsiteUrl,
 			&attraction.CreatedAt,
 			&attraction.UpdatedAt,
+			&attraction.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch location information for the attraction
@@ -638,14 +719,14 @@ func (p attractionRepo) FindAttractionsByName(ctx context.Context, name string)
 			&attraction.Location.CreatedAt,
 			&attraction.Location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch images information for the attraction
-		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 		imageRows, err := p.db.Query(ctx, imagesQuery, attraction.AttractionId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Iterate over the image rows and populate the Images slice for the attraction
@@ -656,28 +737,38 @@ func (p attractionRepo) FindAttractionsByName(ctx context.Context, name string)
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 			attraction.Images = append(attraction.Images, &image)
 		}
 		if err := imageRows.Err(); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Append the attraction to the attractions slice
+		// Fetch categories information for the attraction
+		attraction.Categories, err = selectEstablishmentCategories(ctx, p.db, attraction.AttractionId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		attractions = append(attractions, &attraction)
 	}
 
 	var overall uint64
 
-	queryC := `SELECT COUNT(*) FROM attraction_table WHERE attraction_name ILIKE '%' || $1 || '%' and deleted_at IS NULL`
-
-	if err := p.db.QueryRow(ctx, queryC, name).Scan(&overall); err != nil {
+	queryC, argsC, err := findBuilder.RemoveColumns().Columns("COUNT(*)").ToSql()
+	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&overall); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
 	return attractions, overall, nil
 }
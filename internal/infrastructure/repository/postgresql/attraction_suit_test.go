@@ -338,10 +338,14 @@ func TestUpdateAttraction(t *testing.T) {
 	ctx, cancel = context.WithTimeout(context.Background(), time.Second*7)
 	defer cancel()
 
+	attraction.Version = 1
+	attraction.UpdatedAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
 	updatedAttraction, err := repo.UpdateAttraction(ctx, attraction)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, updatedAttraction)
+	assert.True(t, attraction.UpdatedAt.Equal(updatedAttraction.UpdatedAt))
+	assert.Equal(t, int64(2), updatedAttraction.Version)
 	assert.Equal(t, attraction.AttractionId, updatedAttraction.AttractionId)
 	assert.Equal(t, attraction.OwnerId, updatedAttraction.OwnerId)
 	assert.Equal(t, attraction.AttractionName, updatedAttraction.AttractionName)
@@ -431,7 +435,7 @@ func TestDeleteAttraction(t *testing.T) {
 	ctx, cancel = context.WithTimeout(context.Background(), time.Second*7)
 	defer cancel()
 
-	err = repo.DeleteAttraction(ctx, attractionID)
+	err = repo.DeleteAttraction(ctx, attractionID, 1, time.Now().UTC())
 
 	assert.NoError(t, err)
 }
\ No newline at end of file
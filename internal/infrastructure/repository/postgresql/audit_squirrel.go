@@ -0,0 +1,162 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	auditTableName      = "audit_event_table"
+	auditServiceName    = "auditService"
+	auditSpanRepoPrefix = "auditRepo"
+)
+
+type auditRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewAuditRepo(db *postgres.PostgresDB) *auditRepo {
+	return &auditRepo{
+		tableName: auditTableName,
+		db:        db,
+	}
+}
+
+// events are only ever inserted, the table rejects updates and deletes
+func (p auditRepo) CreateAuditEvent(ctx context.Context, event *entity.AuditEvent) error {
+
+	ctx, span := otlp.Start(ctx, auditServiceName, auditSpanRepoPrefix+"Create")
+	defer span.End()
+
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode changes of audit event: %w", err)
+	}
+
+	data := map[string]interface{}{
+		"event_id":         event.EventId,
+		"establishment_id": event.EstablishmentId,
+		"entity_type":      event.EntityType,
+		"entity_id":        event.EntityId,
+		"action":           event.Action,
+		"actor_id":         event.ActorId,
+		"method":           event.Method,
+		"changes":          changes,
+		"created_at":       event.CreatedAt,
+	}
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).SetMap(data).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for creating audit event: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for creating audit event: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+// filter matches events of the establishment and the actor within [From, To)
+func (p auditRepo) filter(builder squirrel.SelectBuilder, filter *entity.AuditFilter) squirrel.SelectBuilder {
+	if filter == nil {
+		return builder
+	}
+	if filter.EstablishmentId != "" {
+		builder = builder.Where(p.db.Sq.Equal("establishment_id", filter.EstablishmentId))
+	}
+	if filter.ActorId != "" {
+		builder = builder.Where(p.db.Sq.Equal("actor_id", filter.ActorId))
+	}
+	if !filter.From.IsZero() {
+		builder = builder.Where(squirrel.GtOrEq{"created_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		builder = builder.Where(p.db.Sq.Lt("created_at", filter.To))
+	}
+	return builder
+}
+
+func (p auditRepo) ListAuditEvents(ctx context.Context, filter *entity.AuditFilter, offset, limit uint64) ([]*entity.AuditEvent, uint64, error) {
+
+	ctx, span := otlp.Start(ctx, auditServiceName, auditSpanRepoPrefix+"List")
+	defer span.End()
+
+	queryBuilder := p.filter(p.db.Sq.Builder.Select(
+		"event_id",
+		"establishment_id",
+		"entity_type",
+		"entity_id",
+		"action",
+		"actor_id",
+		"method",
+		"changes",
+		"created_at",
+	).From(p.tableName), filter).OrderBy("created_at DESC", "event_id")
+
+	if limit != 0 {
+		queryBuilder = queryBuilder.Limit(limit).Offset(offset)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build SQL query for listing audit events: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	var events []*entity.AuditEvent
+
+	for rows.Next() {
+		var (
+			event   entity.AuditEvent
+			changes []byte
+		)
+
+		if err := rows.Scan(
+			&event.EventId,
+			&event.EstablishmentId,
+			&event.EntityType,
+			&event.EntityId,
+			&event.Action,
+			&event.ActorId,
+			&event.Method,
+			&changes,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, 0, p.db.Error(err)
+		}
+
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode changes of audit event: %w", err)
+		}
+
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
+	query, args, err = p.filter(p.db.Sq.Builder.Select("COUNT(*)").From(p.tableName), filter).ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build SQL query for counting audit events: %w", err)
+	}
+
+	var count uint64
+	if err := p.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", p.db.Error(err))
+	}
+
+	return events, count, nil
+}
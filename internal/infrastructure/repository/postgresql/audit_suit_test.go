@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEvents(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewAuditRepo(db)
+	ctx := context.Background()
+
+	establishment_id := uuid.New().String()
+	actor_id := uuid.New().String()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	created := &entity.AuditEvent{
+		EventId:         uuid.New().String(),
+		EstablishmentId: establishment_id,
+		EntityType:      entity.EstablishmentTypeHotel,
+		EntityId:        establishment_id,
+		Action:          entity.AuditActionCreate,
+		ActorId:         actor_id,
+		Method:          "CreateHotel",
+		Changes:         map[string]entity.FieldChange{"contact_number": {After: "+998 90 123 45 67"}},
+		CreatedAt:       now.Add(-time.Hour),
+	}
+	updated := &entity.AuditEvent{
+		EventId:         uuid.New().String(),
+		EstablishmentId: establishment_id,
+		EntityType:      entity.EstablishmentTypeHotel,
+		EntityId:        establishment_id,
+		Action:          entity.AuditActionUpdate,
+		ActorId:         uuid.New().String(),
+		Method:          "UpdateHotel",
+		Changes:         map[string]entity.FieldChange{"contact_number": {Before: "+998 90 123 45 67", After: "+998 90 765 43 21"}},
+		CreatedAt:       now,
+	}
+	assert.NoError(t, repo.CreateAuditEvent(ctx, created))
+	assert.NoError(t, repo.CreateAuditEvent(ctx, updated))
+
+	// newest first
+	events, count, err := repo.ListAuditEvents(ctx, &entity.AuditFilter{EstablishmentId: establishment_id}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, updated.EventId, events[0].EventId)
+		assert.Equal(t, "UpdateHotel", events[0].Method)
+		assert.Equal(t, updated.Changes, events[0].Changes)
+		assert.Equal(t, created.EventId, events[1].EventId)
+	}
+
+	events, count, err = repo.ListAuditEvents(ctx, &entity.AuditFilter{EstablishmentId: establishment_id, ActorId: actor_id}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, created.EventId, events[0].EventId)
+	}
+
+	// the range includes its start and excludes its end
+	events, _, err = repo.ListAuditEvents(ctx, &entity.AuditFilter{EstablishmentId: establishment_id, From: now.Add(-time.Hour), To: now}, 0, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, created.EventId, events[0].EventId)
+	}
+
+	// events are append-only
+	_, err = db.Exec(ctx, "DELETE FROM audit_event_table WHERE event_id = $1", created.EventId)
+	assert.Error(t, err)
+}
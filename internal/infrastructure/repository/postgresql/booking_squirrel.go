@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	bookingTableName      = "booking_record_table"
+	bookingCountTableName = "booking_count_table"
+	bookingServiceName    = "bookingService"
+	bookingSpanRepoPrefix = "bookingRepo"
+
+	// bookingDayLayout is the layout of the days of the counters
+	bookingDayLayout = "2006-01-02"
+)
+
+type bookingRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewBookingRepo(db *postgres.PostgresDB) *bookingRepo {
+	return &bookingRepo{
+		tableName: bookingTableName,
+		db:        db,
+	}
+}
+
+func (p bookingRepo) GetBooking(ctx context.Context, booking_id string) (*entity.Booking, error) {
+
+	ctx, span := otlp.Start(ctx, bookingServiceName, bookingSpanRepoPrefix+"Get")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Select("booking_id", "establishment_id", "booked_on", "canceled").
+		From(p.tableName).
+		Where(p.db.Sq.Equal("booking_id", booking_id)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for getting booking: %w", err)
+	}
+
+	var booking entity.Booking
+	if err := p.db.QueryRow(ctx, query, args...).Scan(
+		&booking.BookingId,
+		&booking.EstablishmentId,
+		&booking.BookedAt,
+		&booking.Canceled,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.NewErrNotFound("booking")
+		}
+		return nil, fmt.Errorf("failed to get booking: %w", p.db.Error(err))
+	}
+
+	return &booking, nil
+}
+
+// store the booking or whether an existing one is canceled
+func (p bookingRepo) SaveBooking(ctx context.Context, booking *entity.Booking) error {
+
+	ctx, span := otlp.Start(ctx, bookingServiceName, bookingSpanRepoPrefix+"Save")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).
+		SetMap(map[string]interface{}{
+			"booking_id":       booking.BookingId,
+			"establishment_id": booking.EstablishmentId,
+			"booked_on":        booking.BookedAt.Format(bookingDayLayout),
+			"canceled":         booking.Canceled,
+			"updated_at":       time.Now().UTC(),
+		}).
+		Suffix("ON CONFLICT (booking_id) DO UPDATE SET canceled = EXCLUDED.canceled, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for saving booking: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for saving booking: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+func (p bookingRepo) AddBookingCount(ctx context.Context, establishment_id string, at time.Time, delta int64) error {
+
+	ctx, span := otlp.Start(ctx, bookingServiceName, bookingSpanRepoPrefix+"AddCount")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Insert(bookingCountTableName).
+		SetMap(map[string]interface{}{
+			"establishment_id": establishment_id,
+			"day":              at.Format(bookingDayLayout),
+			"bookings":         delta,
+		}).
+		Suffix("ON CONFLICT (establishment_id, day) DO UPDATE SET bookings = " + bookingCountTableName + ".bookings + EXCLUDED.bookings").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for counting booking: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for counting booking: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+func (p bookingRepo) CountBookings(ctx context.Context, establishment_id string, since time.Time) (int64, error) {
+
+	ctx, span := otlp.Start(ctx, bookingServiceName, bookingSpanRepoPrefix+"Count")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Select("COALESCE(SUM(bookings), 0)").
+		From(bookingCountTableName).
+		Where(p.db.Sq.Equal("establishment_id", establishment_id)).
+		Where(squirrel.GtOrEq{"day": since.Format(bookingDayLayout)}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build SQL query for counting bookings: %w", err)
+	}
+
+	var count int64
+	if err := p.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count bookings: %w", p.db.Error(err))
+	}
+
+	return count, nil
+}
+
+// applySort orders establishments by the order of the filter, popularity
+// is the number of bookings in entity.PopularityWindow
+func applySort(builder squirrel.SelectBuilder, column string, filter *entity.Filter) squirrel.SelectBuilder {
+	if filter != nil && filter.Sort == entity.SortPopularity {
+		popularity := fmt.Sprintf(
+			"(SELECT COALESCE(SUM(b.bookings), 0) FROM %s b WHERE b.establishment_id = %s AND b.day >= CURRENT_DATE - %d) DESC",
+			bookingCountTableName, column, int(entity.PopularityWindow.Hours()/24),
+		)
+		return builder.OrderBy(popularity, "rating DESC")
+	}
+	return builder.OrderBy("rating DESC")
+}
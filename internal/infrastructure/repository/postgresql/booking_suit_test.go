@@ -0,0 +1,105 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooking(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewBookingRepo(db)
+	ctx := context.Background()
+
+	establishment_id := uuid.New().String()
+	now := time.Now().UTC()
+
+	booking := &entity.Booking{
+		BookingId:       uuid.New().String(),
+		EstablishmentId: establishment_id,
+		BookedAt:        now,
+	}
+
+	_, err = repo.GetBooking(ctx, booking.BookingId)
+	assert.Error(t, err)
+
+	assert.NoError(t, repo.SaveBooking(ctx, booking))
+	assert.NoError(t, repo.AddBookingCount(ctx, establishment_id, now, 1))
+	assert.NoError(t, repo.AddBookingCount(ctx, establishment_id, now, 1))
+	assert.NoError(t, repo.AddBookingCount(ctx, establishment_id, now.AddDate(0, -2, 0), 1))
+
+	booking.Canceled = true
+	assert.NoError(t, repo.SaveBooking(ctx, booking))
+
+	saved, err := repo.GetBooking(ctx, booking.BookingId)
+	assert.NoError(t, err)
+	assert.True(t, saved.Canceled)
+	assert.Equal(t, now.Format(bookingDayLayout), saved.BookedAt.Format(bookingDayLayout))
+
+	// the window counts the days from the one of since
+	count, err := repo.CountBookings(ctx, establishment_id, now.Add(-entity.PopularityWindow))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = repo.CountBookings(ctx, establishment_id, now.AddDate(-1, 0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestSortByPopularity(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	hotelRepo := NewHotelRepo(db)
+	ctx := context.Background()
+
+	name := "popular hotel " + uuid.New().String()
+	var ids []string
+	for _, rating := range []float32{5, 3} {
+		hotel_id := uuid.New().String()
+		if _, err := hotelRepo.CreateHotel(ctx, &entity.Hotel{
+			HotelId:   hotel_id,
+			OwnerId:   uuid.New().String(),
+			HotelName: name,
+			Rating:    rating,
+			Location:  entity.Location{LocationId: uuid.New().String(), EstablishmentId: hotel_id},
+		}); err != nil {
+			t.Fatalf("failed to insert hotel for testing: %v", err)
+		}
+		ids = append(ids, hotel_id)
+	}
+
+	// the lower rated hotel is booked more
+	assert.NoError(t, NewBookingRepo(db).AddBookingCount(ctx, ids[1], time.Now(), 3))
+
+	hotels, _, err := hotelRepo.FindHotelsByName(ctx, name, &entity.Filter{})
+	assert.NoError(t, err)
+	if assert.Len(t, hotels, 2) {
+		assert.Equal(t, ids[0], hotels[0].HotelId)
+	}
+
+	hotels, _, err = hotelRepo.FindHotelsByName(ctx, name, &entity.Filter{Sort: entity.SortPopularity})
+	assert.NoError(t, err)
+	if assert.Len(t, hotels, 2) {
+		assert.Equal(t, ids[1], hotels[0].HotelId)
+	}
+}
@@ -0,0 +1,249 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	categoryTableName              = "category_table"
+	establishmentCategoryTableName = "establishment_category_table"
+	categoryServiceName            = "categoryService"
+	categorySpanRepoPrefix         = "categoryRepo"
+)
+
+type categoryRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewCategoryRepo(db *postgres.PostgresDB) *categoryRepo {
+	return &categoryRepo{
+		tableName: categoryTableName,
+		db:        db,
+	}
+}
+
+func categorySelectQueryPrefix(db *postgres.PostgresDB) squirrel.SelectBuilder {
+	return db.Sq.Builder.Select(
+		"c.category_id",
+		"COALESCE(c.parent_id::text, '')",
+		"c.establishment_type",
+		"c.code",
+		"c.labels",
+		"c.created_at",
+		"c.updated_at",
+	).From(categoryTableName + " c")
+}
+
+func scanCategories(rows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}) ([]*entity.Category, error) {
+	var categories []*entity.Category
+
+	for rows.Next() {
+		var (
+			category entity.Category
+			labels   []byte
+		)
+
+		if err := rows.Scan(
+			&category.CategoryId,
+			&category.ParentId,
+			&category.EstablishmentType,
+			&category.Code,
+			&labels,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if len(labels) != 0 {
+			if err := json.Unmarshal(labels, &category.Labels); err != nil {
+				return nil, fmt.Errorf("failed to decode labels of category %s: %v", category.Code, err)
+			}
+		}
+
+		categories = append(categories, &category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// categoryCondition restricts the establishment column to establishments linked
+// to any of the given category codes or to any of their descendants
+func categoryCondition(column string, codes []string) squirrel.Sqlizer {
+	return squirrel.Expr(column+` IN (
+  SELECT ec.establishment_id FROM `+establishmentCategoryTableName+` ec
+  WHERE ec.category_id IN (
+    WITH RECURSIVE tree AS (
+      SELECT category_id FROM `+categoryTableName+` WHERE code = ANY(?) AND deleted_at IS NULL
+      UNION
+      SELECT c.category_id FROM `+categoryTableName+` c JOIN tree t ON c.parent_id = t.category_id WHERE c.deleted_at IS NULL
+    )
+    SELECT category_id FROM tree
+  )
+)`, codes)
+}
+
+// applyFilter adds the conditions of the filter to the select builder
+func applyFilter(builder squirrel.SelectBuilder, column string, filter *entity.Filter) squirrel.SelectBuilder {
+	if filter == nil {
+		return builder
+	}
+
+	if len(filter.Categories) != 0 {
+		builder = builder.Where(categoryCondition(column, filter.Categories))
+	}
+
+	return builder
+}
+
+// selectEstablishmentCategories fetches categories linked to the establishment
+func selectEstablishmentCategories(ctx context.Context, db *postgres.PostgresDB, establishment_id string) ([]*entity.Category, error) {
+	query, args, err := categorySelectQueryPrefix(db).
+		Join(establishmentCategoryTableName + " ec ON ec.category_id = c.category_id").
+		Where(db.Sq.Equal("ec.establishment_id", establishment_id)).
+		Where(db.Sq.Equal("c.deleted_at", nil)).
+		OrderBy("c.code").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for getting establishment's categories: %w", err)
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get establishment's categories: %w", db.Error(err))
+	}
+	defer rows.Close()
+
+	return scanCategories(rows)
+}
+
+// replaceEstablishmentCategories links the establishment to exactly the given category codes
+func replaceEstablishmentCategories(ctx context.Context, db *postgres.PostgresDB, establishment_type, establishment_id string, codes []string) ([]*entity.Category, error) {
+	query, args, err := categorySelectQueryPrefix(db).
+		Where(squirrel.Expr("c.code = ANY(?)", codes)).
+		Where(db.Sq.Equal("c.establishment_type", establishment_type)).
+		Where(db.Sq.Equal("c.deleted_at", nil)).
+		OrderBy("c.code").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for getting categories: %w", err)
+	}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", db.Error(err))
+	}
+	categories, err := scanCategories(rows)
+	rows.Close()
+	if err != nil {
+		return nil, db.Error(err)
+	}
+
+	found := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		found[category.Code] = true
+	}
+	for _, code := range codes {
+		if !found[code] {
+			return nil, entity.NewErrNotFound("category " + code)
+		}
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, db.Error(err)
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err = db.Sq.Builder.Delete(establishmentCategoryTableName).
+		Where(db.Sq.Equal("establishment_id", establishment_id)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for unlinking categories: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to unlink categories: %w", db.Error(err))
+	}
+
+	if len(categories) != 0 {
+		insert := db.Sq.Builder.Insert(establishmentCategoryTableName).Columns("establishment_id", "category_id")
+		for _, category := range categories {
+			insert = insert.Values(establishment_id, category.CategoryId)
+		}
+
+		query, args, err = insert.ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build SQL query for linking categories: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to link categories: %w", db.Error(err))
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, db.Error(err)
+	}
+
+	return categories, nil
+}
+
+// list categories of an establishment type, all of them when the type is empty
+func (p categoryRepo) ListCategories(ctx context.Context, establishment_type string) ([]*entity.Category, error) {
+
+	ctx, span := otlp.Start(ctx, categoryServiceName, categorySpanRepoPrefix+"List")
+	defer span.End()
+
+	queryBuilder := categorySelectQueryPrefix(p.db).Where(p.db.Sq.Equal("c.deleted_at", nil)).OrderBy("c.establishment_type", "c.code")
+
+	if establishment_type != "" {
+		queryBuilder = queryBuilder.Where(p.db.Sq.Equal("c.establishment_type", establishment_type))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for listing categories: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	return scanCategories(rows)
+}
+
+// list categories linked to an establishment
+func (p categoryRepo) ListEstablishmentCategories(ctx context.Context, establishment_id string) ([]*entity.Category, error) {
+
+	ctx, span := otlp.Start(ctx, categoryServiceName, categorySpanRepoPrefix+"ListE")
+	defer span.End()
+
+	return selectEstablishmentCategories(ctx, p.db, establishment_id)
+}
+
+// replace categories linked to an establishment
+func (p categoryRepo) SetEstablishmentCategories(ctx context.Context, establishment_type, establishment_id string, codes []string) ([]*entity.Category, error) {
+
+	ctx, span := otlp.Start(ctx, categoryServiceName, categorySpanRepoPrefix+"Set")
+	defer span.End()
+
+	return replaceEstablishmentCategories(ctx, p.db, establishment_type, establishment_id, codes)
+}
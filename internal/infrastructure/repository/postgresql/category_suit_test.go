@@ -0,0 +1,75 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListHotelsByCategory(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewHotelRepo(db)
+
+	hotel_id := uuid.New().String()
+
+	hotel := &entity.Hotel{
+		HotelId:     hotel_id,
+		OwnerId:     uuid.New().String(),
+		HotelName:   "test hotel name " + hotel_id,
+		Description: "Test description",
+		Rating:      4.9,
+		Categories: []*entity.Category{
+			{Code: "star_5"},
+		},
+		Location: entity.Location{
+			LocationId:      uuid.New().String(),
+			EstablishmentId: hotel_id,
+			Address:         "test address",
+			Country:         "Test country",
+			City:            "Test city",
+			StateProvince:   "Test state province",
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	defer cancel()
+
+	createdHotel, err := repo.CreateHotel(ctx, hotel)
+	if err != nil {
+		t.Fatalf("failed to insert hotel for testing: %v", err)
+	}
+
+	assert.Len(t, createdHotel.Categories, 1)
+	assert.Equal(t, "star_5", createdHotel.Categories[0].Code)
+	assert.Equal(t, "5 stars", createdHotel.Categories[0].Label("en"))
+
+	// the parent category matches its children
+	hotels, _, err := repo.FindHotelsByName(ctx, hotel.HotelName, &entity.Filter{Categories: []string{"star_class"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, hotels, 1)
+
+	hotels, _, err = repo.FindHotelsByName(ctx, hotel.HotelName, &entity.Filter{Categories: []string{"star_1"}})
+
+	assert.NoError(t, err)
+	assert.Len(t, hotels, 0)
+
+	// categories of another establishment type are rejected
+	_, err = NewCategoryRepo(db).SetEstablishmentCategories(ctx, entity.EstablishmentTypeHotel, hotel_id, []string{"museum"})
+
+	assert.Error(t, err)
+}
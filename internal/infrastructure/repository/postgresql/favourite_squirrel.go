@@ -5,9 +5,11 @@ import (
 	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"Booking/establishment-service-booking/internal/pkg/postgres"
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
@@ -47,8 +49,8 @@ func (f *favouriteRepo) AddToFavourites(ctx context.Context, favourite *entity.F
 		"favourite_id":     favourite.FavouriteId,
 		"establishment_id": favourite.EstablishmentId,
 		"user_id":          favourite.UserId,
-		"created_at":       time.Now().Local(),
-		"updated_at":       time.Now().Local(),
+		"created_at":       favourite.CreatedAt,
+		"updated_at":       favourite.UpdatedAt,
 	}
 
 	query, args, err := f.db.Sq.Builder.Insert(favouriteTableName).SetMap(data).ToSql()
@@ -58,7 +60,7 @@ func (f *favouriteRepo) AddToFavourites(ctx context.Context, favourite *entity.F
 
 	_, err = f.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, f.db.Error(err)
 	}
 
 	var respFavourite entity.Favourite
@@ -77,12 +79,43 @@ func (f *favouriteRepo) AddToFavourites(ctx context.Context, favourite *entity.F
 		&respFavourite.CreatedAt,
 		&respFavourite.UpdatedAt,
 	); err != nil {
-		return nil, err
+		return nil, f.db.Error(err)
 	}
 
 	return &respFavourite, nil
 }
 
+func (f *favouriteRepo) GetFavourite(ctx context.Context, favourite_id string) (*entity.Favourite, error) {
+
+	ctx, span := otlp.Start(ctx, favouriteServiceName, favouriteSpanRepoPrefix+"Get")
+	defer span.End()
+
+	query, args, err := f.FavouriteSelectQueryPrefix().
+		Where(f.db.Sq.Equal("favourite_id", favourite_id)).
+		Where(f.db.Sq.Equal("deleted_at", nil)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var favourite entity.Favourite
+
+	if err := f.db.QueryRow(ctx, query, args...).Scan(
+		&favourite.FavouriteId,
+		&favourite.EstablishmentId,
+		&favourite.UserId,
+		&favourite.CreatedAt,
+		&favourite.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.NewErrNotFound("favourite")
+		}
+		return nil, f.db.Error(err)
+	}
+
+	return &favourite, nil
+}
+
 func (f *favouriteRepo) RemoveFromFavourites(ctx context.Context, favourite_id string) error {
 	
 	ctx, span := otlp.Start(ctx, favouriteServiceName, favouriteSpanRepoPrefix+"Delete")
@@ -100,12 +133,12 @@ func (f *favouriteRepo) RemoveFromFavourites(ctx context.Context, favourite_id s
 	// Execute the SQL query
 	commandTag, err := f.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return err
+		return f.db.Error(err)
 	}
 
 	// Check if any rows were affected
 	if commandTag.RowsAffected() == 0 {
-		return err
+		return entity.NewErrNotFound("favourite")
 	}
 
 	return nil
@@ -129,7 +162,7 @@ func (f *favouriteRepo) ListFavouritesByUserId(ctx context.Context, user_id stri
 
 	rows, err := f.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, f.db.Error(err)
 	}
 	defer rows.Close()
 
@@ -143,7 +176,7 @@ func (f *favouriteRepo) ListFavouritesByUserId(ctx context.Context, user_id stri
 			&favourite.CreatedAt,
 			&favourite.UpdatedAt,
 		); err != nil {
-			return nil, err
+			return nil, f.db.Error(err)
 		}
 		favourites = append(favourites, &favourite)
 	}
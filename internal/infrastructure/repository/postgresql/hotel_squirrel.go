@@ -43,6 +43,7 @@ func (p *hotelRepo) HotelSelectQueryPrefix() squirrel.SelectBuilder {
 		"website_url",
 		"created_at",
 		"updated_at",
+		"version",
 	).From(p.tableName)
 }
 
@@ -62,19 +63,19 @@ func (p hotelRepo) CreateHotel(ctx context.Context, hotel *entity.Hotel) (*entit
 		"country":          hotel.Location.Country,
 		"city":             hotel.Location.City,
 		"state_province":   hotel.Location.StateProvince,
-		"category":         hotel.Location.Category,
+		"category":         entity.EstablishmentTypeHotel,
 		"created_at":       hotel.Location.CreatedAt,
 		"updated_at":       hotel.Location.UpdatedAt,
 	}
 
 	query, args, err := p.db.Sq.Builder.Insert(locationTableName).SetMap(dataL).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for creating hotel's location part: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for creating hotel's location part: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for creating hotel's location part: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for creating hotel's location part: %w", p.db.Error(err))
 	}
 
 	// insert images to image_table
@@ -83,6 +84,7 @@ func (p hotelRepo) CreateHotel(ctx context.Context, hotel *entity.Hotel) (*entit
 			"image_id":         image.ImageId,
 			"establishment_id": image.EstablishmentId,
 			"image_url":        image.ImageUrl,
+			"caption":          image.Caption,
 			"category":         image.Category,
 			"created_at":       image.CreatedAt,
 			"updated_at":       image.UpdatedAt,
@@ -90,12 +92,12 @@ func (p hotelRepo) CreateHotel(ctx context.Context, hotel *entity.Hotel) (*entit
 
 		query, args, err := p.db.Sq.Builder.Insert(imageTableName).SetMap(dataI).ToSql()
 		if err != nil {
-			return nil, fmt.Errorf("failed to build SQL query for creating image: %v", err)
+			return nil, fmt.Errorf("failed to build SQL query for creating image: %w", err)
 		}
 
 		_, err = p.db.Exec(ctx, query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to execute SQL query for creating image: %v", err)
+			return nil, fmt.Errorf("failed to execute SQL query for creating image: %w", p.db.Error(err))
 		}
 	}
 
@@ -114,14 +116,29 @@ func (p hotelRepo) CreateHotel(ctx context.Context, hotel *entity.Hotel) (*entit
 	}
 	query, args, err = p.db.Sq.Builder.Insert(p.tableName).SetMap(data).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for creating hotel: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for creating hotel: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for creating hotel: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for creating hotel: %w", p.db.Error(err))
 	}
 
+	// link categories of the hotel
+	if len(hotel.Categories) != 0 {
+		codes := make([]string, 0, len(hotel.Categories))
+		for _, category := range hotel.Categories {
+			codes = append(codes, category.Code)
+		}
+
+		hotel.Categories, err = replaceEstablishmentCategories(ctx, p.db, entity.EstablishmentTypeHotel, hotel.HotelId, codes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hotel.Version = initialVersion
+
 	return hotel, nil
 }
 
@@ -139,7 +156,7 @@ func (p hotelRepo) GetHotel(ctx context.Context, hotel_id string) (*entity.Hotel
 	// Get the SQL query and arguments from the query builder
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for getting hotel: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for getting hotel: %w", err)
 	}
 
 	// Execute the query to fetch hotel details
@@ -154,8 +171,9 @@ func (p hotelRepo) GetHotel(ctx context.Context, hotel_id string) (*entity.Hotel
 		&hotel.WebsiteUrl,
 		&hotel.CreatedAt,
 		&hotel.UpdatedAt,
+		&hotel.Version,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get hotel: %v", err)
+		return nil, fmt.Errorf("failed to get hotel: %w", p.db.Error(err))
 	}
 
 	// Fetch location information
@@ -172,14 +190,14 @@ func (p hotelRepo) GetHotel(ctx context.Context, hotel_id string) (*entity.Hotel
 		&hotel.Location.CreatedAt,
 		&hotel.Location.UpdatedAt,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get location for hotel: %v", err)
+		return nil, fmt.Errorf("failed to get location for hotel: %w", p.db.Error(err))
 	}
 
 	// Fetch images information
-	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 	rows, err := p.db.Query(ctx, imagesQuery, hotel_id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get images for hotel: %v", err)
+		return nil, fmt.Errorf("failed to get images for hotel: %w", p.db.Error(err))
 	}
 	defer rows.Close()
 
@@ -190,22 +208,29 @@ func (p hotelRepo) GetHotel(ctx context.Context, hotel_id string) (*entity.Hotel
 			&image.ImageId,
 			&image.EstablishmentId,
 			&image.ImageUrl,
+			&image.Caption,
 			&image.CreatedAt,
 			&image.UpdatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan image row: %v", err)
+			return nil, fmt.Errorf("failed to scan image row: %w", p.db.Error(err))
 		}
 		hotel.Images = append(hotel.Images, &image)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error encountered while iterating over image rows: %v", err)
+		return nil, fmt.Errorf("error encountered while iterating over image rows: %w", p.db.Error(err))
+	}
+
+	// Fetch categories information
+	hotel.Categories, err = selectEstablishmentCategories(ctx, p.db, hotel.HotelId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &hotel, nil
 }
 
 // get a list of hotels
-func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64) ([]*entity.Hotel, uint64, error) {
+func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Hotel, uint64, error) {
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, hotelSpanRepoPrefix+"List")
 	defer span.End()
@@ -213,9 +238,11 @@ func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64) ([]*enti
 	var hotels []*entity.Hotel
 
 	queryBuilder := p.HotelSelectQueryPrefix()
+	queryBuilder = applyFilter(queryBuilder, "hotel_id", filter)
+	queryBuilder = applySort(queryBuilder, "hotel_id", filter)
 
 	if limit != 0 {
-		queryBuilder = queryBuilder.Limit(uint64(limit)).Offset(uint64(offset)).Where(p.db.Sq.Equal("deleted_at", nil)).OrderBy("rating DESC")
+		queryBuilder = queryBuilder.Limit(uint64(limit)).Offset(uint64(offset)).Where(p.db.Sq.Equal("deleted_at", nil))
 	}
 
 	query, args, err := queryBuilder.ToSql()
@@ -225,7 +252,7 @@ func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64) ([]*enti
 
 	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, p.db.Error(err)
 	}
 	defer rows.Close()
 
@@ -243,8 +270,9 @@ func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64) ([]*enti
 			&hotel.WebsiteUrl,
 			&hotel.CreatedAt,
 			&hotel.UpdatedAt,
+			&hotel.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch location information for the hotel
@@ -266,14 +294,14 @@ func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64) ([]*enti
 			&hotel.Location.CreatedAt,
 			&hotel.Location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch images information for the attraction
-		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 		imageRows, err := p.db.Query(ctx, imagesQuery, hotel.HotelId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Iterate over the image rows and populate the Images slice for the hotel
@@ -284,29 +312,42 @@ func (p hotelRepo) ListHotels(ctx context.Context, offset, limit int64) ([]*enti
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 			hotel.Images = append(hotel.Images, &image)
 		}
 		if err := imageRows.Err(); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Append the attraction to the hotels slice
+		// Fetch categories information for the hotel
+		hotel.Categories, err = selectEstablishmentCategories(ctx, p.db, hotel.HotelId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		hotels = append(hotels, &hotel)
 	}
 
 	var overall uint64
 
-	queryC := `SELECT COUNT(*) FROM hotel_table WHERE deleted_at IS NULL`
+	countBuilder := p.db.Sq.Builder.Select("COUNT(*)").From(p.tableName).Where(p.db.Sq.Equal("deleted_at", nil))
+	countBuilder = applyFilter(countBuilder, "hotel_id", filter)
 
-	if err := p.db.QueryRow(ctx, queryC).Scan(&overall); err != nil {
+	queryC, argsC, err := countBuilder.ToSql()
+	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&overall); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
 	return hotels, overall, nil
 }
 
@@ -323,24 +364,26 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 		"contact_number": request.ContactNumber,
 		"licence_url":    request.LicenceUrl,
 		"website_url":    request.WebsiteUrl,
-		"updated_at":     time.Now().Local(),
+		"updated_at":     request.UpdatedAt,
+		"version":        squirrel.Expr("version + 1"),
 	}
 
 	sqlStr, args, err := p.db.Sq.Builder.Update(p.tableName).
 		SetMap(clauses).
 		Where(p.db.Sq.Equal("hotel_id", request.HotelId), p.db.Sq.Equal("deleted_at", nil)).
+		Where(p.db.Sq.Equal("version", request.Version)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for updating hotel: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for updating hotel: %w", err)
 	}
 
 	commandTag, err := p.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for updating hotel: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for updating hotel: %w", p.db.Error(err))
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return nil, fmt.Errorf("no rows affected while updating hotel")
+		return nil, versionMismatch(ctx, p.db, p.tableName, "hotel_id", request.HotelId, "hotel")
 	}
 
 	clausesL := map[string]interface{}{
@@ -350,7 +393,7 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 		"country":        request.Location.Country,
 		"city":           request.Location.City,
 		"state_province": request.Location.StateProvince,
-		"updated_at":     time.Now().Local(),
+		"updated_at":     request.UpdatedAt,
 	}
 
 	sqlStrL, args, err := p.db.Sq.Builder.Update("location_table").
@@ -358,16 +401,28 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 		Where(p.db.Sq.Equal("establishment_id", request.HotelId), p.db.Sq.Equal("deleted_at", nil)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for updating location: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for updating location: %w", err)
 	}
 
 	commandTagL, err := p.db.Exec(ctx, sqlStrL, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for updating location: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for updating location: %w", p.db.Error(err))
 	}
 
 	if commandTagL.RowsAffected() == 0 {
-		return nil, fmt.Errorf("no rows affected while updating hotel")
+		return nil, entity.NewErrNotFound("hotel")
+	}
+
+	// replace categories of the hotel when they are given
+	if request.Categories != nil {
+		codes := make([]string, 0, len(request.Categories))
+		for _, category := range request.Categories {
+			codes = append(codes, category.Code)
+		}
+
+		if _, err := replaceEstablishmentCategories(ctx, p.db, entity.EstablishmentTypeHotel, request.HotelId, codes); err != nil {
+			return nil, err
+		}
 	}
 
 	var hotel entity.Hotel
@@ -378,14 +433,14 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 	// Get the SQL query and arguments from the query builder
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for getting hotel: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for getting hotel: %w", err)
 	}
 
 	// Execute the query to fetch hotel details
 	if err := p.db.QueryRow(ctx, query, args...).Scan(
 		&hotel.HotelId,
-		&hotel.HotelName,
 		&hotel.OwnerId,
+		&hotel.HotelName,
 		&hotel.Description,
 		&hotel.Rating,
 		&hotel.ContactNumber,
@@ -393,8 +448,9 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 		&hotel.WebsiteUrl,
 		&hotel.CreatedAt,
 		&hotel.UpdatedAt,
+		&hotel.Version,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get hotel: %v", err)
+		return nil, fmt.Errorf("failed to get hotel: %w", p.db.Error(err))
 	}
 
 	// Fetch location information
@@ -411,14 +467,14 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 		&hotel.Location.CreatedAt,
 		&hotel.Location.UpdatedAt,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get location for hotel: %v", err)
+		return nil, fmt.Errorf("failed to get location for hotel: %w", p.db.Error(err))
 	}
 
 	// Fetch images information
-	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 	rows, err := p.db.Query(ctx, imagesQuery, request.HotelId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get images for hotel: %v", err)
+		return nil, fmt.Errorf("failed to get images for hotel: %w", p.db.Error(err))
 	}
 	defer rows.Close()
 
@@ -429,51 +485,59 @@ func (p hotelRepo) UpdateHotel(ctx context.Context, request *entity.Hotel) (*ent
 			&image.ImageId,
 			&image.EstablishmentId,
 			&image.ImageUrl,
+			&image.Caption,
 			&image.CreatedAt,
 			&image.UpdatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan image row: %v", err)
+			return nil, fmt.Errorf("failed to scan image row: %w", p.db.Error(err))
 		}
 		hotel.Images = append(hotel.Images, &image)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error encountered while iterating over image rows: %v", err)
+		return nil, fmt.Errorf("error encountered while iterating over image rows: %w", p.db.Error(err))
+	}
+
+	// Fetch categories information
+	hotel.Categories, err = selectEstablishmentCategories(ctx, p.db, hotel.HotelId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &hotel, nil
 }
 
 // delete a hotel softly
-func (p hotelRepo) DeleteHotel(ctx context.Context, hotel_id string) error {
+func (p hotelRepo) DeleteHotel(ctx context.Context, hotel_id string, version int64, deleted_at time.Time) error {
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, hotelSpanRepoPrefix+"Delete")
 	defer span.End()
 
 	// Build the SQL query
 	sqlStr, args, err := p.db.Sq.Builder.Update(p.tableName).
-		Set("deleted_at", time.Now().Local()).
+		Set("deleted_at", deleted_at).
 		Where(p.db.Sq.Equal("hotel_id", hotel_id)).
+		Where(p.db.Sq.Equal("deleted_at", nil), p.db.Sq.Equal("version", version)).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build SQL query for deleting hotel: %v", err)
+		return fmt.Errorf("failed to build SQL query for deleting hotel: %w", err)
 	}
 
 	// Execute the SQL query
 	commandTag, err := p.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return fmt.Errorf("failed to execute SQL query for deleting hotel: %v", err)
+		return fmt.Errorf("failed to execute SQL query for deleting hotel: %w", p.db.Error(err))
 	}
 
 	// Check if any rows were affected
 	if commandTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows affected while deleting hotel")
+		return versionMismatch(ctx, p.db, p.tableName, "hotel_id", hotel_id, "hotel")
 	}
 
 	return nil
 }
 
 // list hotels by location
-func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Hotel, int64, error) {
+func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Hotel, int64, error) {
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, hotelSpanRepoPrefix+"ListL")
 	defer span.End()
@@ -483,11 +547,22 @@ func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint6
 	stateStr := "%"+state_province+"%"
 
 	// println("\n\n chech \n")
-	queryL := fmt.Sprintf("SELECT establishment_id FROM location_table WHERE country LIKE '%s' and city LIKE '%s' and state_province LIKE '%s' and category = 'hotel' and deleted_at IS NULL LIMIT $1 OFFSET $2", countryStr, cityStr, stateStr)
-	rows, err := p.db.Query(ctx, queryL, limit, offset)
+	// establishments of this type only, the location category is not trusted
+	locationBuilder := p.db.Sq.Builder.Select("establishment_id").From(locationTableName).
+		Where(squirrel.Like{"country": countryStr, "city": cityStr, "state_province": stateStr}).
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		Where(squirrel.Expr("establishment_id IN (SELECT hotel_id FROM hotel_table WHERE deleted_at IS NULL)"))
+	locationBuilder = applyFilter(locationBuilder, "establishment_id", filter)
+
+	queryL, argsL, err := locationBuilder.Limit(limit).Offset(offset).ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
+
+	rows, err := p.db.Query(ctx, queryL, argsL...)
+	if err != nil {
+		return nil, 0, p.db.Error(err)
+	}
 	defer rows.Close()
 
 	var hotels []*entity.Hotel
@@ -497,12 +572,12 @@ func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint6
 		var establishment_id string
 
 		if err := rows.Scan(&establishment_id); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		var hotel entity.Hotel
 
-		queryA := `SELECT hotel_id, owner_id, hotel_name, description, rating, contact_number, licence_url, website_url, created_at, updated_at FROM hotel_table WHERE hotel_id = $1`
+		queryA := `SELECT hotel_id, owner_id, hotel_name, description, rating, contact_number, licence_url, website_url, created_at, updated_at, version FROM hotel_table WHERE hotel_id = $1`
 
 		if err := p.db.QueryRow(ctx, queryA, establishment_id).Scan(
 			&hotel.HotelId,
@@ -515,8 +590,9 @@ func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint6
 			&hotel.WebsiteUrl,
 			&hotel.CreatedAt,
 			&hotel.UpdatedAt,
+			&hotel.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		var location entity.Location
@@ -535,16 +611,16 @@ func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint6
 			&location.CreatedAt,
 			&location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		hotel.Location = location
 
-		queryI := `SELECT image_id, establishment_id, image_url, created_at, updated_at FROM image_table WHERE establishment_id = $1`
+		queryI := `SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM image_table WHERE establishment_id = $1`
 
 		rowsI, err := p.db.Query(ctx, queryI, hotel.HotelId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 		defer rowsI.Close()
 
@@ -557,10 +633,11 @@ func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint6
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 
 			images = append(images, &image)
@@ -568,48 +645,51 @@ func (p hotelRepo) ListHotelsByLocation(ctx context.Context, offset, limit uint6
 
 		hotel.Images = images
 
+		// Fetch categories information for the hotel
+		hotel.Categories, err = selectEstablishmentCategories(ctx, p.db, hotel.HotelId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		hotels = append(hotels, &hotel)
 	}
 
 	var count int64
 
-	queryC := fmt.Sprintf("SELECT COUNT(*) establishment_id FROM location_table WHERE country LIKE '%s' and city LIKE '%s' and state_province LIKE '%s' and category = 'hotel' and deleted_at IS NULL", countryStr, cityStr, stateStr)
-
-	if err := p.db.QueryRow(ctx, queryC).Scan(&count); err != nil {
+	queryC, argsC, err := locationBuilder.RemoveColumns().Columns("COUNT(*)").ToSql()
+	if err != nil {
 		return hotels, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&count); err != nil {
+		return hotels, 0, p.db.Error(err)
+	}
+
 	return hotels, count, nil
 }
 
 // find hotels by name
-func (p hotelRepo) FindHotelsByName(ctx context.Context, name string) ([]*entity.Hotel, uint64, error) {
+func (p hotelRepo) FindHotelsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Hotel, uint64, error) {
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, hotelSpanRepoPrefix+"Find")
 	defer span.End()
 
 	var hotels []*entity.Hotel
 
-	query := `SELECT
-  hotel_id,
-  owner_id,
-  hotel_name,
-  description,
-  rating,
-  contact_number,
-  licence_url,
-  website_url,
-  created_at,
-  updated_at
-  FROM hotel_table
-  WHERE deleted_at IS NULL
-  AND hotel_name ILIKE '%' || $1 || '%'
-  ORDER BY rating DESC`
-
-	rows, err := p.db.Query(ctx, query, name)
+	findBuilder := p.HotelSelectQueryPrefix().
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		Where(p.db.Sq.ILike("hotel_name", "%"+name+"%"))
+	findBuilder = applyFilter(findBuilder, "hotel_id", filter)
+
+	query, args, err := applySort(findBuilder, "hotel_id", filter).ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, p.db.Error(err)
+	}
 	defer rows.Close()
 
 	// Iterate over the rows to fetch each hotel's details
@@ -626,8 +706,9 @@ func (p hotelRepo) FindHotelsByName(ctx context.Context, name string) ([]*entity
 			&hotel.WebsiteUrl,
 			&hotel.CreatedAt,
 			&hotel.UpdatedAt,
+			&hotel.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch location information for the hotel
@@ -644,14 +725,14 @@ func (p hotelRepo) FindHotelsByName(ctx context.Context, name string) ([]*entity
 			&hotel.Location.CreatedAt,
 			&hotel.Location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch images information for the hotel
-		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 		imageRows, err := p.db.Query(ctx, imagesQuery, hotel.HotelId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Iterate over the image rows and populate the Images slice for the hotel
@@ -662,28 +743,38 @@ func (p hotelRepo) FindHotelsByName(ctx context.Context, name string) ([]*entity
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 			hotel.Images = append(hotel.Images, &image)
 		}
 		if err := imageRows.Err(); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Append the hotel to the hotels slice
+		// Fetch categories information for the hotel
+		hotel.Categories, err = selectEstablishmentCategories(ctx, p.db, hotel.HotelId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		hotels = append(hotels, &hotel)
 	}
 
 	var overall uint64
 
-	queryC := `SELECT COUNT(*) FROM hotel_table WHERE hotel_name ILIKE '%' || $1 || '%' and deleted_at IS NULL`
-
-	if err := p.db.QueryRow(ctx, queryC, name).Scan(&overall); err != nil {
+	queryC, argsC, err := findBuilder.RemoveColumns().Columns("COUNT(*)").ToSql()
+	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&overall); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
 	return hotels, overall, nil
 }
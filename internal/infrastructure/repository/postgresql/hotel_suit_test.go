@@ -335,10 +335,14 @@ func TestUpdateHotel(t *testing.T) {
 	ctx, cancel = context.WithTimeout(context.Background(), time.Second*7)
 	defer cancel()
 
+	hotel.Version = 1
+	hotel.UpdatedAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
 	updatedHotel, err := repo.UpdateHotel(ctx, hotel)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, updatedHotel)
+	assert.True(t, hotel.UpdatedAt.Equal(updatedHotel.UpdatedAt))
+	assert.Equal(t, int64(2), updatedHotel.Version)
 	assert.Equal(t, hotel.HotelId, updatedHotel.HotelId)
 	assert.Equal(t, hotel.OwnerId, updatedHotel.OwnerId)
 	assert.Equal(t, hotel.HotelName, updatedHotel.HotelName)
@@ -367,6 +371,15 @@ func TestUpdateHotel(t *testing.T) {
 		assert.Equal(t, expectedImage.EstablishmentId, updatedHotel.Images[i].EstablishmentId)
 		assert.Equal(t, expectedImage.ImageUrl, updatedHotel.Images[i].ImageUrl)
 	}
+
+	// a write based on the old version is rejected
+	_, err = repo.UpdateHotel(ctx, hotel)
+	var errVersion *entity.ErrVersionMismatch
+	assert.ErrorAs(t, err, &errVersion)
+	assert.Equal(t, int64(2), errVersion.Current)
+
+	err = repo.DeleteHotel(ctx, hotel.HotelId, 1, time.Now().UTC())
+	assert.ErrorAs(t, err, &errVersion)
 }
 
 func TestDeleteHotel(t *testing.T) {
@@ -427,7 +440,7 @@ func TestDeleteHotel(t *testing.T) {
 	ctx, cancel = context.WithTimeout(context.Background(), time.Second*7)
 	defer cancel()
 
-	err = repo.DeleteHotel(ctx, hotel_id)
+	err = repo.DeleteHotel(ctx, hotel_id, 1, time.Now().UTC())
 
 	assert.NoError(t, err)
 }
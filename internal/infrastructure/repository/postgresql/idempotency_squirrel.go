@@ -0,0 +1,182 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	idempotencyTableName      = "idempotency_key_table"
+	idempotencyServiceName    = "idempotencyService"
+	idempotencySpanRepoPrefix = "idempotencyRepo"
+)
+
+type idempotencyRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewIdempotencyRepo(db *postgres.PostgresDB) *idempotencyRepo {
+	return &idempotencyRepo{
+		tableName: idempotencyTableName,
+		db:        db,
+	}
+}
+
+func (p idempotencyRepo) keyEqual(record *entity.IdempotencyRecord) map[string]interface{} {
+	return map[string]interface{}{
+		"idempotency_key": record.Key,
+		"user_id":         record.UserId,
+		"method":          record.Method,
+	}
+}
+
+// reserve the key for the request, an expired record of the key or one in
+// progress whose lease is over is replaced
+func (p idempotencyRepo) ReserveIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) (*entity.IdempotencyRecord, error) {
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, idempotencySpanRepoPrefix+"Reserve")
+	defer span.End()
+
+	data := map[string]interface{}{
+		"idempotency_key": record.Key,
+		"user_id":         record.UserId,
+		"method":          record.Method,
+		"request_hash":    record.RequestHash,
+		"created_at":      record.CreatedAt,
+		"expires_at":      record.ExpiresAt,
+		"locked_until":    record.LockedUntil,
+	}
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).SetMap(data).
+		Suffix("ON CONFLICT (idempotency_key, user_id, method) DO UPDATE SET " +
+			"request_hash = EXCLUDED.request_hash, response_type = '', response = NULL, " +
+			"created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until " +
+			"WHERE " + idempotencyTableName + ".expires_at <= EXCLUDED.created_at " +
+			"OR (" + idempotencyTableName + ".response IS NULL AND " + idempotencyTableName + ".locked_until <= EXCLUDED.created_at) " +
+			"RETURNING idempotency_key").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for reserving idempotency key: %w", err)
+	}
+
+	var key string
+	err = p.db.QueryRow(ctx, query, args...).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to execute SQL query for reserving idempotency key: %w", p.db.Error(err))
+	}
+
+	// a live record holds the key
+	query, args, err = p.db.Sq.Builder.Select(
+		"idempotency_key",
+		"user_id",
+		"method",
+		"request_hash",
+		"response_type",
+		"response",
+		"created_at",
+		"expires_at",
+		"locked_until",
+	).From(p.tableName).Where(p.db.Sq.EqualMany(p.keyEqual(record))).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for getting idempotency key: %w", err)
+	}
+
+	var existing entity.IdempotencyRecord
+	if err := p.db.QueryRow(ctx, query, args...).Scan(
+		&existing.Key,
+		&existing.UserId,
+		&existing.Method,
+		&existing.RequestHash,
+		&existing.ResponseType,
+		&existing.Response,
+		&existing.CreatedAt,
+		&existing.ExpiresAt,
+		&existing.LockedUntil,
+	); err != nil {
+		return nil, fmt.Errorf("failed to execute SQL query for getting idempotency key: %w", p.db.Error(err))
+	}
+
+	return &existing, nil
+}
+
+// store the response of the request
+func (p idempotencyRepo) CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, idempotencySpanRepoPrefix+"Complete")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Update(p.tableName).
+		Set("response_type", record.ResponseType).
+		Set("response", record.Response).
+		Where(p.db.Sq.EqualMany(p.keyEqual(record))).
+		Where(p.db.Sq.Equal("request_hash", record.RequestHash)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for completing idempotency key: %w", err)
+	}
+
+	result, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute SQL query for completing idempotency key: %w", p.db.Error(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.NewErrNotFound("idempotency key")
+	}
+
+	return nil
+}
+
+// release the key of a failed request so that it can be retried
+func (p idempotencyRepo) ReleaseIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, idempotencySpanRepoPrefix+"Release")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Delete(p.tableName).
+		Where(p.db.Sq.EqualMany(p.keyEqual(record))).
+		Where(p.db.Sq.Equal("request_hash", record.RequestHash)).
+		Where(p.db.Sq.Equal("response", nil)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for releasing idempotency key: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for releasing idempotency key: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+// delete records whose TTL is over
+func (p idempotencyRepo) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, idempotencySpanRepoPrefix+"DeleteExpired")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Delete(p.tableName).
+		Where(p.db.Sq.Lt("expires_at", time.Now().UTC())).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build SQL query for deleting expired idempotency keys: %w", err)
+	}
+
+	result, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute SQL query for deleting expired idempotency keys: %w", p.db.Error(err))
+	}
+
+	return result.RowsAffected(), nil
+}
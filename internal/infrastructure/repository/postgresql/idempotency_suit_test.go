@@ -0,0 +1,108 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewIdempotencyRepo(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	record := &entity.IdempotencyRecord{
+		Key:         uuid.New().String(),
+		UserId:      uuid.New().String(),
+		Method:      "/establishment_service.EstablishmentService/CreateHotel",
+		RequestHash: "first",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+		LockedUntil: now.Add(time.Minute),
+	}
+
+	// the first request reserves the key
+	existing, err := repo.ReserveIdempotencyKey(ctx, record)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// a retry in progress sees the reservation
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	assert.NoError(t, err)
+	assert.NotNil(t, existing)
+	assert.False(t, existing.Completed())
+
+	record.ResponseType = "establishment_service.Hotel"
+	record.Response = []byte{1, 2, 3}
+	assert.NoError(t, repo.CompleteIdempotencyKey(ctx, record))
+
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", existing.RequestHash)
+	assert.Equal(t, "establishment_service.Hotel", existing.ResponseType)
+	assert.Equal(t, []byte{1, 2, 3}, existing.Response)
+
+	// completed records are not released
+	assert.NoError(t, repo.ReleaseIdempotencyKey(ctx, record))
+	existing, err = repo.ReserveIdempotencyKey(ctx, record)
+	assert.NoError(t, err)
+	assert.True(t, existing.Completed())
+
+	// an expired record is replaced by the next request
+	expired := *record
+	expired.Key = uuid.New().String()
+	expired.ExpiresAt = now.Add(-time.Hour)
+	expired.CreatedAt = now.Add(-2 * time.Hour)
+	_, err = repo.ReserveIdempotencyKey(ctx, &expired)
+	assert.NoError(t, err)
+
+	retry := expired
+	retry.RequestHash = "second"
+	retry.CreatedAt = now
+	retry.ExpiresAt = now.Add(time.Hour)
+	existing, err = repo.ReserveIdempotencyKey(ctx, &retry)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// a retry takes over the key of a request that died in progress
+	died := *record
+	died.Key = uuid.New().String()
+	died.Response = nil
+	died.CreatedAt = now.Add(-2 * time.Minute)
+	died.LockedUntil = now.Add(-time.Minute)
+	_, err = repo.ReserveIdempotencyKey(ctx, &died)
+	assert.NoError(t, err)
+
+	takeover := died
+	takeover.CreatedAt = now
+	takeover.LockedUntil = now.Add(time.Minute)
+	existing, err = repo.ReserveIdempotencyKey(ctx, &takeover)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// while the lease holds the retry waits
+	existing, err = repo.ReserveIdempotencyKey(ctx, &takeover)
+	assert.NoError(t, err)
+	if assert.NotNil(t, existing) {
+		assert.False(t, existing.Completed())
+	}
+
+	deleted, err := repo.DeleteExpiredIdempotencyKeys(ctx)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(0))
+}
@@ -29,6 +29,7 @@ func (p imageRepo) CreateImage(ctx context.Context, image *entity.Image) error {
 		"image_id":      image.ImageId,
 		"establishment_id": image.EstablishmentId,
 		"image_url": image.ImageUrl,
+		"caption":   image.Caption,
 		"category": image.Category,
 		"created_at": image.CreatedAt,
 		"updated_at": image.UpdatedAt,
@@ -37,12 +38,12 @@ func (p imageRepo) CreateImage(ctx context.Context, image *entity.Image) error {
 
 	query, args, err := p.db.Sq.Builder.Insert(imageTableName).SetMap(dataI).ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build SQL query for creating establishment's image: %v", err)
+		return fmt.Errorf("failed to build SQL query for creating establishment's image: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("failed to execute SQL query for creating establishment's image: %v", err)
+		return fmt.Errorf("failed to execute SQL query for creating establishment's image: %w", p.db.Error(err))
 	}
 
 	return nil
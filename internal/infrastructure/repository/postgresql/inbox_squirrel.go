@@ -0,0 +1,53 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	inboxTableName      = "inbox_table"
+	inboxServiceName    = "inboxService"
+	inboxSpanRepoPrefix = "inboxRepo"
+)
+
+type inboxRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewInboxRepo(db *postgres.PostgresDB) *inboxRepo {
+	return &inboxRepo{
+		tableName: inboxTableName,
+		db:        db,
+	}
+}
+
+// store the id of the event unless it is there already
+func (p inboxRepo) MarkEventProcessed(ctx context.Context, event_id, event_type string, processed_at time.Time) (bool, error) {
+
+	ctx, span := otlp.Start(ctx, inboxServiceName, inboxSpanRepoPrefix+"Mark")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).
+		SetMap(map[string]interface{}{
+			"event_id":     event_id,
+			"event_type":   event_type,
+			"processed_at": processed_at,
+		}).
+		Suffix("ON CONFLICT (event_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build SQL query for marking event processed: %w", err)
+	}
+
+	result, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute SQL query for marking event processed: %w", p.db.Error(err))
+	}
+
+	return result.RowsAffected() != 0, nil
+}
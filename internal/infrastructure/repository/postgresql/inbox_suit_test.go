@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInbox(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewInboxRepo(db)
+	ctx := context.Background()
+
+	event_id := uuid.New().String()
+
+	processed, err := repo.MarkEventProcessed(ctx, event_id, entity.UserEventDeleted, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	// a redelivered event is skipped
+	processed, err = repo.MarkEventProcessed(ctx, event_id, entity.UserEventDeleted, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, processed)
+
+	// nothing is stored when the transaction of the changes fails
+	other_id := uuid.New().String()
+	err = db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := repo.MarkEventProcessed(ctx, other_id, entity.UserEventDeleted, time.Now()); err != nil {
+			return err
+		}
+		return entity.NewErrNotFound("user")
+	})
+	assert.Error(t, err)
+
+	processed, err = repo.MarkEventProcessed(ctx, other_id, entity.UserEventDeleted, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, processed)
+}
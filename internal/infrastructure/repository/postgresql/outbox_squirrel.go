@@ -0,0 +1,246 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	outboxTableName      = "outbox_table"
+	outboxServiceName    = "outboxService"
+	outboxSpanRepoPrefix = "outboxRepo"
+
+	// outboxLockId is the advisory lock a single relay holds while sending
+	outboxLockId = 7_310_045_001
+)
+
+type outboxRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewOutboxRepo(db *postgres.PostgresDB) *outboxRepo {
+	return &outboxRepo{
+		tableName: outboxTableName,
+		db:        db,
+	}
+}
+
+// store the message, called in the transaction of the change it describes
+func (p outboxRepo) AddOutboxMessage(ctx context.Context, message *entity.OutboxMessage) error {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"Add")
+	defer span.End()
+
+	headers, err := json.Marshal(message.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode headers of outbox message: %w", err)
+	}
+
+	data := map[string]interface{}{
+		"message_key":     message.Key,
+		"payload":         message.Payload,
+		"headers":         headers,
+		"created_at":      message.CreatedAt,
+		"next_attempt_at": message.CreatedAt,
+	}
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).SetMap(data).Suffix("RETURNING message_id").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for adding outbox message: %w", err)
+	}
+
+	if err := p.db.QueryRow(ctx, query, args...).Scan(&message.MessageId); err != nil {
+		return fmt.Errorf("failed to execute SQL query for adding outbox message: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+func (p outboxRepo) LockOutbox(ctx context.Context) (bool, error) {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"Lock")
+	defer span.End()
+
+	var locked bool
+	if err := p.db.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxLockId).Scan(&locked); err != nil {
+		return false, fmt.Errorf("failed to lock outbox: %w", p.db.Error(err))
+	}
+
+	return locked, nil
+}
+
+func (p outboxRepo) ListPendingOutboxMessages(ctx context.Context, now time.Time, limit uint64) ([]*entity.OutboxMessage, error) {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"ListPending")
+	defer span.End()
+
+	// the head of every key, later messages wait for it to be sent
+	heads := p.db.Sq.Builder.Select(
+		"DISTINCT ON (message_key) message_id",
+		"message_key",
+		"payload",
+		"headers",
+		"attempts",
+		"last_error",
+		"created_at",
+		"next_attempt_at",
+	).From(p.tableName).
+		Where(p.db.Sq.Equal("sent_at", nil)).
+		OrderBy("message_key", "message_id")
+
+	query, args, err := p.db.Sq.Builder.Select("*").
+		FromSelect(heads, "heads").
+		Where(squirrel.LtOrEq{"next_attempt_at": now}).
+		OrderBy("message_id").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for listing pending outbox messages: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending outbox messages: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	var messages []*entity.OutboxMessage
+
+	for rows.Next() {
+		var (
+			message entity.OutboxMessage
+			headers []byte
+		)
+
+		if err := rows.Scan(
+			&message.MessageId,
+			&message.Key,
+			&message.Payload,
+			&headers,
+			&message.Attempts,
+			&message.LastError,
+			&message.CreatedAt,
+			&message.NextAttemptAt,
+		); err != nil {
+			return nil, p.db.Error(err)
+		}
+
+		if err := json.Unmarshal(headers, &message.Headers); err != nil {
+			return nil, fmt.Errorf("failed to decode headers of outbox message: %w", err)
+		}
+
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, p.db.Error(err)
+	}
+
+	return messages, nil
+}
+
+// postpone the next attempt of the messages a relay is about to send
+func (p outboxRepo) ClaimOutboxMessages(ctx context.Context, message_ids []int64, until time.Time) error {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"Claim")
+	defer span.End()
+
+	if len(message_ids) == 0 {
+		return nil
+	}
+
+	query, args, err := p.db.Sq.Builder.Update(p.tableName).
+		Set("next_attempt_at", until).
+		Where(squirrel.Eq{"message_id": message_ids}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for claiming outbox messages: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for claiming outbox messages: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+func (p outboxRepo) MarkOutboxMessageSent(ctx context.Context, message_id int64, sent_at time.Time) error {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"MarkSent")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Update(p.tableName).
+		Set("sent_at", sent_at).
+		Where(p.db.Sq.Equal("message_id", message_id)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for marking outbox message sent: %w", err)
+	}
+
+	result, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute SQL query for marking outbox message sent: %w", p.db.Error(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.NewErrNotFound("outbox message")
+	}
+
+	return nil
+}
+
+// store the attempts, the error and the time of the next attempt of a message
+func (p outboxRepo) RetryOutboxMessage(ctx context.Context, message *entity.OutboxMessage) error {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"Retry")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Update(p.tableName).
+		Set("attempts", message.Attempts).
+		Set("last_error", message.LastError).
+		Set("next_attempt_at", message.NextAttemptAt).
+		Where(p.db.Sq.Equal("message_id", message.MessageId)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for retrying outbox message: %w", err)
+	}
+
+	result, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute SQL query for retrying outbox message: %w", p.db.Error(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.NewErrNotFound("outbox message")
+	}
+
+	return nil
+}
+
+// delete messages sent before the time
+func (p outboxRepo) DeleteSentOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, outboxSpanRepoPrefix+"DeleteSent")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Delete(p.tableName).
+		Where(p.db.Sq.Lt("sent_at", before)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build SQL query for deleting sent outbox messages: %w", err)
+	}
+
+	result, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute SQL query for deleting sent outbox messages: %w", p.db.Error(err))
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,118 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutbox(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewOutboxRepo(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first, second := uuid.New().String(), uuid.New().String()
+	messages := []*entity.OutboxMessage{
+		{Key: first, Payload: []byte(`{"type":"hotel.created"}`), Headers: map[string]string{"event_type": "hotel.created"}, CreatedAt: now},
+		{Key: first, Payload: []byte(`{"type":"hotel.updated"}`), Headers: map[string]string{"event_type": "hotel.updated"}, CreatedAt: now},
+		{Key: second, Payload: []byte(`{"type":"review.created"}`), Headers: map[string]string{"event_type": "review.created"}, CreatedAt: now},
+	}
+
+	// a message is stored only with the change it belongs to
+	errRollback := errors.New("rollback")
+	err = db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.AddOutboxMessage(ctx, &entity.OutboxMessage{Key: first, Payload: []byte("{}"), CreatedAt: now}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	assert.ErrorIs(t, err, errRollback)
+
+	assert.NoError(t, db.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, message := range messages {
+			if err := repo.AddOutboxMessage(ctx, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	pending := func() map[string]*entity.OutboxMessage {
+		list, err := repo.ListPendingOutboxMessages(ctx, now, 1000)
+		assert.NoError(t, err)
+
+		heads := make(map[string]*entity.OutboxMessage)
+		for _, message := range list {
+			if message.Key == first || message.Key == second {
+				heads[message.Key] = message
+			}
+		}
+		return heads
+	}
+
+	// only the oldest message of every key is pending
+	heads := pending()
+	if assert.Len(t, heads, 2) {
+		assert.Equal(t, messages[0].MessageId, heads[first].MessageId)
+		assert.Equal(t, messages[0].Payload, heads[first].Payload)
+		assert.Equal(t, "hotel.created", heads[first].Headers["event_type"])
+		assert.Equal(t, messages[2].MessageId, heads[second].MessageId)
+	}
+
+	// a message waiting for a retry holds back its key
+	messages[0].Attempts = 1
+	messages[0].LastError = "broker is down"
+	messages[0].NextAttemptAt = now.Add(time.Minute)
+	assert.NoError(t, repo.RetryOutboxMessage(ctx, messages[0]))
+
+	heads = pending()
+	assert.NotContains(t, heads, first)
+	assert.Contains(t, heads, second)
+
+	// the next message of a key is pending once the previous one is sent
+	assert.NoError(t, repo.MarkOutboxMessageSent(ctx, messages[0].MessageId, now))
+	heads = pending()
+	if assert.Contains(t, heads, first) {
+		assert.Equal(t, messages[1].MessageId, heads[first].MessageId)
+	}
+
+	// a claimed message is not pending until the claim ends
+	assert.NoError(t, repo.ClaimOutboxMessages(ctx, []int64{messages[2].MessageId}, now.Add(time.Minute)))
+	heads = pending()
+	assert.NotContains(t, heads, second)
+	assert.NoError(t, repo.ClaimOutboxMessages(ctx, nil, now))
+
+	// a relay holds the lock until its transaction ends
+	assert.NoError(t, db.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := repo.LockOutbox(ctx)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+
+		return db.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			locked, err := repo.LockOutbox(ctx)
+			assert.NoError(t, err)
+			assert.False(t, locked)
+			return nil
+		})
+	}))
+
+	deleted, err := repo.DeleteSentOutboxMessages(ctx, now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+}
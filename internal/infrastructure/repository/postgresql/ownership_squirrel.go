@@ -0,0 +1,123 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ownershipServiceName    = "ownershipService"
+	ownershipSpanRepoPrefix = "ownershipRepo"
+)
+
+type ownershipRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewOwnershipRepo(db *postgres.PostgresDB) *ownershipRepo {
+	return &ownershipRepo{
+		db: db,
+	}
+}
+
+// ownerQuery resolves an establishment or anything attached to it (image,
+// price, room) to the owner of the establishment
+const ownerQuery = `WITH owners AS (
+  SELECT hotel_id AS establishment_id, owner_id FROM ` + hotelTableName + ` WHERE deleted_at IS NULL
+  UNION ALL
+  SELECT restaurant_id, owner_id FROM ` + restaurantTableName + ` WHERE deleted_at IS NULL
+  UNION ALL
+  SELECT attraction_id, owner_id FROM ` + attractionTableName + ` WHERE deleted_at IS NULL
+), attached AS (
+  SELECT $1::uuid AS establishment_id
+  UNION ALL
+  SELECT establishment_id FROM ` + imageTableName + ` WHERE image_id = $1
+  UNION ALL
+  SELECT establishment_id FROM ` + priceTableName + ` WHERE price_id = $1
+  UNION ALL
+  SELECT hotel_id FROM room_table WHERE room_id = $1
+)
+SELECT o.owner_id FROM owners o JOIN attached a ON a.establishment_id = o.establishment_id LIMIT 1`
+
+// get the owner of an establishment or of an entity attached to it
+func (p ownershipRepo) GetOwnerId(ctx context.Context, entity_id string) (string, error) {
+
+	ctx, span := otlp.Start(ctx, ownershipServiceName, ownershipSpanRepoPrefix+"Get")
+	defer span.End()
+
+	var owner_id string
+	if err := p.db.QueryRow(ctx, ownerQuery, entity_id).Scan(&owner_id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", entity.NewErrNotFound("establishment")
+		}
+		return "", fmt.Errorf("failed to get owner of %s: %w", entity_id, p.db.Error(err))
+	}
+
+	return owner_id, nil
+}
+
+// establishmentQuery resolves an establishment id to its type and owner
+const establishmentQuery = `SELECT '` + entity.EstablishmentTypeHotel + `', owner_id FROM ` + hotelTableName + ` WHERE hotel_id = $1 AND deleted_at IS NULL
+UNION ALL
+SELECT '` + entity.EstablishmentTypeRestaurant + `', owner_id FROM ` + restaurantTableName + ` WHERE restaurant_id = $1 AND deleted_at IS NULL
+UNION ALL
+SELECT '` + entity.EstablishmentTypeAttraction + `', owner_id FROM ` + attractionTableName + ` WHERE attraction_id = $1 AND deleted_at IS NULL
+LIMIT 1`
+
+// get the type and the owner of an establishment
+func (p ownershipRepo) GetEstablishmentRef(ctx context.Context, establishment_id string) (*entity.EstablishmentRef, error) {
+
+	ctx, span := otlp.Start(ctx, ownershipServiceName, ownershipSpanRepoPrefix+"GetRef")
+	defer span.End()
+
+	establishment := entity.EstablishmentRef{EstablishmentId: establishment_id}
+	if err := p.db.QueryRow(ctx, establishmentQuery, establishment_id).Scan(&establishment.EstablishmentType, &establishment.OwnerId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.NewErrNotFound("establishment")
+		}
+		return nil, fmt.Errorf("failed to get establishment %s: %w", establishment_id, p.db.Error(err))
+	}
+
+	return &establishment, nil
+}
+
+// ownedQuery lists the establishments of every type an owner has
+const ownedQuery = `SELECT '` + entity.EstablishmentTypeHotel + `', hotel_id FROM ` + hotelTableName + ` WHERE owner_id = $1 AND deleted_at IS NULL
+UNION ALL
+SELECT '` + entity.EstablishmentTypeRestaurant + `', restaurant_id FROM ` + restaurantTableName + ` WHERE owner_id = $1 AND deleted_at IS NULL
+UNION ALL
+SELECT '` + entity.EstablishmentTypeAttraction + `', attraction_id FROM ` + attractionTableName + ` WHERE owner_id = $1 AND deleted_at IS NULL`
+
+// list the establishments of an owner
+func (p ownershipRepo) ListEstablishmentsByOwner(ctx context.Context, owner_id string) ([]*entity.EstablishmentRef, error) {
+
+	ctx, span := otlp.Start(ctx, ownershipServiceName, ownershipSpanRepoPrefix+"List")
+	defer span.End()
+
+	rows, err := p.db.Query(ctx, ownedQuery, owner_id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list establishments of %s: %w", owner_id, p.db.Error(err))
+	}
+	defer rows.Close()
+
+	var establishments []*entity.EstablishmentRef
+
+	for rows.Next() {
+		establishment := entity.EstablishmentRef{OwnerId: owner_id}
+		if err := rows.Scan(&establishment.EstablishmentType, &establishment.EstablishmentId); err != nil {
+			return nil, p.db.Error(err)
+		}
+		establishments = append(establishments, &establishment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, p.db.Error(err)
+	}
+
+	return establishments, nil
+}
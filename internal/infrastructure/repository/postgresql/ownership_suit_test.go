@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOwnerId(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewOwnershipRepo(db)
+
+	hotel_id := uuid.New().String()
+	owner_id := uuid.New().String()
+	image_id := uuid.New().String()
+
+	hotel := &entity.Hotel{
+		HotelId:   hotel_id,
+		OwnerId:   owner_id,
+		HotelName: "test hotel name",
+		Images: []*entity.Image{
+			{ImageId: image_id, EstablishmentId: hotel_id, ImageUrl: "test image url"},
+		},
+		Location: entity.Location{
+			LocationId:      uuid.New().String(),
+			EstablishmentId: hotel_id,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	defer cancel()
+
+	if _, err := NewHotelRepo(db).CreateHotel(ctx, hotel); err != nil {
+		t.Fatalf("failed to insert hotel for testing: %v", err)
+	}
+
+	owner, err := repo.GetOwnerId(ctx, hotel_id)
+	assert.NoError(t, err)
+	assert.Equal(t, owner_id, owner)
+
+	// images resolve to the owner of their establishment
+	owner, err = repo.GetOwnerId(ctx, image_id)
+	assert.NoError(t, err)
+	assert.Equal(t, owner_id, owner)
+
+	_, err = repo.GetOwnerId(ctx, uuid.New().String())
+	assert.Error(t, err)
+
+	// a malformed id is invalid, not an internal error
+	var errValidation *entity.ErrValidation
+	_, err = repo.GetOwnerId(ctx, "not-a-uuid")
+	assert.ErrorAs(t, err, &errValidation)
+}
+
+func TestListEstablishmentsByOwner(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewOwnershipRepo(db)
+
+	owner_id := uuid.New().String()
+	hotel_id := uuid.New().String()
+	restaurant_id := uuid.New().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	defer cancel()
+
+	if _, err := NewHotelRepo(db).CreateHotel(ctx, &entity.Hotel{
+		HotelId:   hotel_id,
+		OwnerId:   owner_id,
+		HotelName: "test hotel name",
+		Location:  entity.Location{LocationId: uuid.New().String(), EstablishmentId: hotel_id},
+	}); err != nil {
+		t.Fatalf("failed to insert hotel for testing: %v", err)
+	}
+	if _, err := NewRestaurantRepo(db).CreateRestaurant(ctx, &entity.Restaurant{
+		RestaurantId:   restaurant_id,
+		OwnerId:        owner_id,
+		RestaurantName: "test restaurant name",
+		Location:       entity.Location{LocationId: uuid.New().String(), EstablishmentId: restaurant_id},
+	}); err != nil {
+		t.Fatalf("failed to insert restaurant for testing: %v", err)
+	}
+
+	establishment, err := repo.GetEstablishmentRef(ctx, restaurant_id)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.EstablishmentRef{EstablishmentType: entity.EstablishmentTypeRestaurant, EstablishmentId: restaurant_id, OwnerId: owner_id}, establishment)
+
+	establishments, err := repo.ListEstablishmentsByOwner(ctx, owner_id)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*entity.EstablishmentRef{
+		{EstablishmentType: entity.EstablishmentTypeHotel, EstablishmentId: hotel_id, OwnerId: owner_id},
+		{EstablishmentType: entity.EstablishmentTypeRestaurant, EstablishmentId: restaurant_id, OwnerId: owner_id},
+	}, establishments)
+
+	// deleted establishments are not listed
+	assert.NoError(t, NewHotelRepo(db).DeleteHotel(ctx, hotel_id, initialVersion, time.Now().UTC()))
+
+	establishments, err = repo.ListEstablishmentsByOwner(ctx, owner_id)
+	assert.NoError(t, err)
+	assert.Len(t, establishments, 1)
+
+	_, err = repo.GetEstablishmentRef(ctx, hotel_id)
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	priceTableName        = "price_table"
+	currencyRateTableName = "currency_rate_table"
+	priceServiceName      = "priceService"
+	priceSpanRepoPrefix   = "priceRepo"
+)
+
+type priceRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewPriceRepo(db *postgres.PostgresDB) *priceRepo {
+	return &priceRepo{
+		tableName: priceTableName,
+		db:        db,
+	}
+}
+
+func (p *priceRepo) PriceSelectQueryPrefix() squirrel.SelectBuilder {
+	return p.db.Sq.Builder.Select(
+		"price_id",
+		"establishment_id",
+		"item_type",
+		"name",
+		"amount",
+		"currency",
+		"created_at",
+		"updated_at",
+	).From(p.tableName)
+}
+
+// create a price of an establishment's room, ticket or menu
+func (p priceRepo) CreatePrice(ctx context.Context, price *entity.Price) (*entity.Price, error) {
+
+	ctx, span := otlp.Start(ctx, priceServiceName, priceSpanRepoPrefix+"Create")
+	defer span.End()
+
+	price.CreatedAt = time.Now().Local()
+	price.UpdatedAt = price.CreatedAt
+
+	data := map[string]interface{}{
+		"price_id":         price.PriceId,
+		"establishment_id": price.EstablishmentId,
+		"item_type":        price.ItemType,
+		"name":             price.Name,
+		"amount":           price.Price.Amount,
+		"currency":         price.Price.Currency,
+		"created_at":       price.CreatedAt,
+		"updated_at":       price.UpdatedAt,
+	}
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).SetMap(data).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for creating price: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute SQL query for creating price: %w", p.db.Error(err))
+	}
+
+	return price, nil
+}
+
+// list prices of the establishments
+func (p priceRepo) ListPrices(ctx context.Context, establishment_ids []string) ([]*entity.Price, error) {
+
+	ctx, span := otlp.Start(ctx, priceServiceName, priceSpanRepoPrefix+"List")
+	defer span.End()
+
+	if len(establishment_ids) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := p.PriceSelectQueryPrefix().
+		Where(squirrel.Expr("establishment_id = ANY(?)", establishment_ids)).
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		OrderBy("establishment_id", "item_type", "amount").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for listing prices: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prices: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	var prices []*entity.Price
+
+	for rows.Next() {
+		var price entity.Price
+
+		if err := rows.Scan(
+			&price.PriceId,
+			&price.EstablishmentId,
+			&price.ItemType,
+			&price.Name,
+			&price.Price.Amount,
+			&price.Price.Currency,
+			&price.CreatedAt,
+			&price.UpdatedAt,
+		); err != nil {
+			return nil, p.db.Error(err)
+		}
+
+		prices = append(prices, &price)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, p.db.Error(err)
+	}
+
+	return prices, nil
+}
+
+// soft delete a price
+func (p priceRepo) DeletePrice(ctx context.Context, price_id string) error {
+
+	ctx, span := otlp.Start(ctx, priceServiceName, priceSpanRepoPrefix+"Delete")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Update(p.tableName).
+		Set("deleted_at", time.Now().Local()).
+		Where(p.db.Sq.Equal("price_id", price_id)).
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for deleting price: %w", err)
+	}
+
+	commandTag, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute SQL query for deleting price: %w", p.db.Error(err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return entity.NewErrNotFound("price")
+	}
+
+	return nil
+}
+
+type currencyRateRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewCurrencyRateRepo(db *postgres.PostgresDB) *currencyRateRepo {
+	return &currencyRateRepo{
+		tableName: currencyRateTableName,
+		db:        db,
+	}
+}
+
+// list currency rates against the base currency
+func (p currencyRateRepo) ListCurrencyRates(ctx context.Context) ([]*entity.CurrencyRate, error) {
+
+	ctx, span := otlp.Start(ctx, priceServiceName, priceSpanRepoPrefix+"ListRates")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Select("currency", "rate::text", "updated_at").
+		From(p.tableName).
+		OrderBy("currency").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for listing currency rates: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list currency rates: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	var rates []*entity.CurrencyRate
+
+	for rows.Next() {
+		var rate entity.CurrencyRate
+
+		if err := rows.Scan(&rate.Currency, &rate.Rate, &rate.UpdatedAt); err != nil {
+			return nil, p.db.Error(err)
+		}
+
+		rates = append(rates, &rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, p.db.Error(err)
+	}
+
+	return rates, nil
+}
+
+// create or overwrite currency rates
+func (p currencyRateRepo) UpsertCurrencyRates(ctx context.Context, rates []*entity.CurrencyRate) error {
+
+	ctx, span := otlp.Start(ctx, priceServiceName, priceSpanRepoPrefix+"UpsertRates")
+	defer span.End()
+
+	if len(rates) == 0 {
+		return nil
+	}
+
+	insert := p.db.Sq.Builder.Insert(p.tableName).Columns("currency", "rate", "updated_at")
+	for _, rate := range rates {
+		insert = insert.Values(rate.Currency, rate.Rate, time.Now().Local())
+	}
+
+	query, args, err := insert.
+		Suffix("ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for saving currency rates: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for saving currency rates: %w", p.db.Error(err))
+	}
+
+	return nil
+}
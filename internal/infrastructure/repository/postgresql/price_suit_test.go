@@ -0,0 +1,56 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrice(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewPriceRepo(db)
+	rateRepo := NewCurrencyRateRepo(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	defer cancel()
+
+	err = rateRepo.UpsertCurrencyRates(ctx, []*entity.CurrencyRate{{Currency: "UZS", Rate: "12650.5"}})
+	assert.NoError(t, err)
+
+	rates, err := rateRepo.ListCurrencyRates(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rates)
+
+	establishment_id := uuid.New().String()
+
+	price, err := repo.CreatePrice(ctx, &entity.Price{
+		PriceId:         uuid.New().String(),
+		EstablishmentId: establishment_id,
+		ItemType:        entity.PriceItemRoom,
+		Name:            "Standard room",
+		Price:           entity.Money{Amount: 4999, Currency: "USD"},
+	})
+	assert.NoError(t, err)
+
+	prices, err := repo.ListPrices(ctx, []string{establishment_id})
+	assert.NoError(t, err)
+	assert.Len(t, prices, 1)
+	assert.Equal(t, entity.Money{Amount: 4999, Currency: "USD"}, prices[0].Price)
+
+	assert.NoError(t, repo.DeletePrice(ctx, price.PriceId))
+	assert.Error(t, repo.DeletePrice(ctx, price.PriceId))
+}
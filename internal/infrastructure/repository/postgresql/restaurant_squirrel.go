@@ -44,6 +44,7 @@ func (p *restaurantRepo) RestaurantSelectQueryPrefix() squirrel.SelectBuilder {
 		"website_url",
 		"created_at",
 		"updated_at",
+		"version",
 	).From(p.tableName)
 }
 
@@ -63,19 +64,19 @@ func (p restaurantRepo) CreateRestaurant(ctx context.Context, restaurant *entity
 		"country":          restaurant.Location.Country,
 		"city":             restaurant.Location.City,
 		"state_province":   restaurant.Location.StateProvince,
-		"category":         restaurant.Location.Category,
+		"category":         entity.EstablishmentTypeRestaurant,
 		"created_at":       restaurant.Location.CreatedAt,
 		"updated_at":       restaurant.Location.UpdatedAt,
 	}
 
 	query, args, err := p.db.Sq.Builder.Insert(locationTableName).SetMap(dataL).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for creating restaurant's location part: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for creating restaurant's location part: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for creating restaurant's location part: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for creating restaurant's location part: %w", p.db.Error(err))
 	}
 
 	// insert images to image_table
@@ -84,6 +85,7 @@ func (p restaurantRepo) CreateRestaurant(ctx context.Context, restaurant *entity
 			"image_id":         image.ImageId,
 			"establishment_id": image.EstablishmentId,
 			"image_url":        image.ImageUrl,
+			"caption":          image.Caption,
 			"category":         image.Category,
 			"created_at":       image.CreatedAt,
 			"updated_at":       image.UpdatedAt,
@@ -91,12 +93,12 @@ func (p restaurantRepo) CreateRestaurant(ctx context.Context, restaurant *entity
 
 		query, args, err := p.db.Sq.Builder.Insert(imageTableName).SetMap(dataI).ToSql()
 		if err != nil {
-			return nil, fmt.Errorf("failed to build SQL query for creating image: %v", err)
+			return nil, fmt.Errorf("failed to build SQL query for creating image: %w", err)
 		}
 
 		_, err = p.db.Exec(ctx, query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to execute SQL query for creating image: %v", err)
+			return nil, fmt.Errorf("failed to execute SQL query for creating image: %w", p.db.Error(err))
 		}
 	}
 
@@ -116,14 +118,29 @@ func (p restaurantRepo) CreateRestaurant(ctx context.Context, restaurant *entity
 	}
 	query, args, err = p.db.Sq.Builder.Insert(p.tableName).SetMap(data).ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for creating restaurant: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for creating restaurant: %w", err)
 	}
 
 	_, err = p.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for creating restaurant: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for creating restaurant: %w", p.db.Error(err))
 	}
 
+	// link categories of the restaurant
+	if len(restaurant.Categories) != 0 {
+		codes := make([]string, 0, len(restaurant.Categories))
+		for _, category := range restaurant.Categories {
+			codes = append(codes, category.Code)
+		}
+
+		restaurant.Categories, err = replaceEstablishmentCategories(ctx, p.db, entity.EstablishmentTypeRestaurant, restaurant.RestaurantId, codes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	restaurant.Version = initialVersion
+
 	return restaurant, nil
 }
 
@@ -141,7 +158,7 @@ func (p restaurantRepo) GetRestaurant(ctx context.Context, restaurant_id string)
 	// Get the SQL query and arguments from the query builder
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for getting restaurant: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for getting restaurant: %w", err)
 	}
 
 	// Execute the query to fetch restaurant details
@@ -157,8 +174,9 @@ func (p restaurantRepo) GetRestaurant(ctx context.Context, restaurant_id string)
 		&restaurant.WebsiteUrl,
 		&restaurant.CreatedAt,
 		&restaurant.UpdatedAt,
+		&restaurant.Version,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get restaurant: %v", err)
+		return nil, fmt.Errorf("failed to get restaurant: %w", p.db.Error(err))
 	}
 
 	// Fetch location information
@@ -175,14 +193,14 @@ func (p restaurantRepo) GetRestaurant(ctx context.Context, restaurant_id string)
 		&restaurant.Location.CreatedAt,
 		&restaurant.Location.UpdatedAt,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get location for location: %v", err)
+		return nil, fmt.Errorf("failed to get location for location: %w", p.db.Error(err))
 	}
 
 	// Fetch images information
-	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 	rows, err := p.db.Query(ctx, imagesQuery, restaurant_id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get images for restaurant: %v", err)
+		return nil, fmt.Errorf("failed to get images for restaurant: %w", p.db.Error(err))
 	}
 	defer rows.Close()
 
@@ -193,22 +211,29 @@ func (p restaurantRepo) GetRestaurant(ctx context.Context, restaurant_id string)
 			&image.ImageId,
 			&image.EstablishmentId,
 			&image.ImageUrl,
+			&image.Caption,
 			&image.CreatedAt,
 			&image.UpdatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan image row: %v", err)
+			return nil, fmt.Errorf("failed to scan image row: %w", p.db.Error(err))
 		}
 		restaurant.Images = append(restaurant.Images, &image)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error encountered while iterating over image rows: %v", err)
+		return nil, fmt.Errorf("error encountered while iterating over image rows: %w", p.db.Error(err))
+	}
+
+	// Fetch categories information
+	restaurant.Categories, err = selectEstablishmentCategories(ctx, p.db, restaurant.RestaurantId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &restaurant, nil
 }
 
 // get a list of restaurants
-func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64) ([]*entity.Restaurant, uint64, error) {
+func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Restaurant, uint64, error) {
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, restaurantSpanRepoPrefix+"List")
 	defer span.End()
@@ -216,6 +241,8 @@ func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64
 	var restaurants []*entity.Restaurant
 
 	queryBuilder := p.RestaurantSelectQueryPrefix()
+	queryBuilder = applyFilter(queryBuilder, "restaurant_id", filter)
+	queryBuilder = applySort(queryBuilder, "restaurant_id", filter)
 
 	if limit != 0 {
 		queryBuilder = queryBuilder.Limit(uint64(limit)).Offset(uint64(offset)).Where(p.db.Sq.Equal("deleted_at", nil))
@@ -228,7 +255,7 @@ func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64
 
 	rows, err := p.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, p.db.Error(err)
 	}
 	defer rows.Close()
 
@@ -247,8 +274,9 @@ func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64
 			&restaurant.WebsiteUrl,
 			&restaurant.CreatedAt,
 			&restaurant.UpdatedAt,
+			&restaurant.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch location information for the restaurant
@@ -265,14 +293,14 @@ func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64
 			&restaurant.Location.CreatedAt,
 			&restaurant.Location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch images information for the attraction
-		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 		imageRows, err := p.db.Query(ctx, imagesQuery, restaurant.RestaurantId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Iterate over the image rows and populate the Images slice for the restaurant
@@ -283,29 +311,42 @@ func (p restaurantRepo) ListRestaurants(ctx context.Context, offset, limit int64
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 			restaurant.Images = append(restaurant.Images, &image)
 		}
 		if err := imageRows.Err(); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Append the attraction to the restaurants slice
+		// Fetch categories information for the restaurant
+		restaurant.Categories, err = selectEstablishmentCategories(ctx, p.db, restaurant.RestaurantId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		restaurants = append(restaurants, &restaurant)
 	}
 
 	var overall uint64
 
-	queryC := `SELECT COUNT(*) FROM restaurant_table WHERE deleted_at IS NULL`
+	countBuilder := p.db.Sq.Builder.Select("COUNT(*)").From(p.tableName).Where(p.db.Sq.Equal("deleted_at", nil))
+	countBuilder = applyFilter(countBuilder, "restaurant_id", filter)
 
-	if err := p.db.QueryRow(ctx, queryC).Scan(&overall); err != nil {
+	queryC, argsC, err := countBuilder.ToSql()
+	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&overall); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
 	return restaurants, overall, nil
 }
 
@@ -323,24 +364,26 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 		"contact_number":  request.ContactNumber,
 		"licence_url":     request.LicenceUrl,
 		"website_url":     request.WebsiteUrl,
-		"updated_at":      time.Now().Local(),
+		"updated_at":      request.UpdatedAt,
+		"version":         squirrel.Expr("version + 1"),
 	}
 
 	sqlStr, args, err := p.db.Sq.Builder.Update(p.tableName).
 		SetMap(clauses).
 		Where(p.db.Sq.Equal("restaurant_id", request.RestaurantId), p.db.Sq.Equal("deleted_at", nil)).
+		Where(p.db.Sq.Equal("version", request.Version)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for updating restaurant: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for updating restaurant: %w", err)
 	}
 
 	commandTag, err := p.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for updating restaurant: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for updating restaurant: %w", p.db.Error(err))
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return nil, fmt.Errorf("no rows affected while updating restaurant")
+		return nil, versionMismatch(ctx, p.db, p.tableName, "restaurant_id", request.RestaurantId, "restaurant")
 	}
 
 	clausesL := map[string]interface{}{
@@ -350,7 +393,7 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 		"country":        request.Location.Country,
 		"city":           request.Location.City,
 		"state_province": request.Location.StateProvince,
-		"updated_at":     time.Now().Local(),
+		"updated_at":     request.UpdatedAt,
 	}
 
 	sqlStrL, args, err := p.db.Sq.Builder.Update("location_table").
@@ -358,16 +401,28 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 		Where(p.db.Sq.Equal("establishment_id", request.RestaurantId), p.db.Sq.Equal("deleted_at", nil)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for updating location of Restaurant: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for updating location of Restaurant: %w", err)
 	}
 
 	commandTagL, err := p.db.Exec(ctx, sqlStrL, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute SQL query for updating location of Restaurant: %v", err)
+		return nil, fmt.Errorf("failed to execute SQL query for updating location of Restaurant: %w", p.db.Error(err))
 	}
 
 	if commandTagL.RowsAffected() == 0 {
-		return nil, fmt.Errorf("no rows affected while updating restaurant")
+		return nil, entity.NewErrNotFound("restaurant")
+	}
+
+	// replace categories of the restaurant when they are given
+	if request.Categories != nil {
+		codes := make([]string, 0, len(request.Categories))
+		for _, category := range request.Categories {
+			codes = append(codes, category.Code)
+		}
+
+		if _, err := replaceEstablishmentCategories(ctx, p.db, entity.EstablishmentTypeRestaurant, request.RestaurantId, codes); err != nil {
+			return nil, err
+		}
 	}
 
 	var restaurant entity.Restaurant
@@ -378,14 +433,14 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 	// Get the SQL query and arguments from the query builder
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build SQL query for getting restaurant: %v", err)
+		return nil, fmt.Errorf("failed to build SQL query for getting restaurant: %w", err)
 	}
 
 	// Execute the query to fetch restaurant details
 	if err := p.db.QueryRow(ctx, query, args...).Scan(
 		&restaurant.RestaurantId,
-		&restaurant.RestaurantName,
 		&restaurant.OwnerId,
+		&restaurant.RestaurantName,
 		&restaurant.Description,
 		&restaurant.Rating,
 		&restaurant.OpeningHours,
@@ -394,8 +449,9 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 		&restaurant.WebsiteUrl,
 		&restaurant.CreatedAt,
 		&restaurant.UpdatedAt,
+		&restaurant.Version,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get restaurant: %v", err)
+		return nil, fmt.Errorf("failed to get restaurant: %w", p.db.Error(err))
 	}
 
 	// Fetch location information
@@ -412,14 +468,14 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 		&restaurant.Location.CreatedAt,
 		&restaurant.Location.UpdatedAt,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get location for restaurant: %v", err)
+		return nil, fmt.Errorf("failed to get location for restaurant: %w", p.db.Error(err))
 	}
 
 	// Fetch images information
-	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+	imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 	rows, err := p.db.Query(ctx, imagesQuery, request.RestaurantId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get images for restaurant: %v", err)
+		return nil, fmt.Errorf("failed to get images for restaurant: %w", p.db.Error(err))
 	}
 	defer rows.Close()
 
@@ -430,51 +486,59 @@ func (p restaurantRepo) UpdateRestaurant(ctx context.Context, request *entity.Re
 			&image.ImageId,
 			&image.EstablishmentId,
 			&image.ImageUrl,
+			&image.Caption,
 			&image.CreatedAt,
 			&image.UpdatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan image row: %v", err)
+			return nil, fmt.Errorf("failed to scan image row: %w", p.db.Error(err))
 		}
 		restaurant.Images = append(restaurant.Images, &image)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error encountered while iterating over image rows: %v", err)
+		return nil, fmt.Errorf("error encountered while iterating over image rows: %w", p.db.Error(err))
+	}
+
+	// Fetch categories information
+	restaurant.Categories, err = selectEstablishmentCategories(ctx, p.db, restaurant.RestaurantId)
+	if err != nil {
+		return nil, err
 	}
 
 	return &restaurant, nil
 }
 
 // delete a restaurant softly
-func (p restaurantRepo) DeleteRestaurant(ctx context.Context, restaurant_id string) error {
+func (p restaurantRepo) DeleteRestaurant(ctx context.Context, restaurant_id string, version int64, deleted_at time.Time) error {
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, restaurantSpanRepoPrefix+"Delete")
 	defer span.End()
 
 	// Build the SQL query
 	sqlStr, args, err := p.db.Sq.Builder.Update(p.tableName).
-		Set("deleted_at", time.Now().Local()).
+		Set("deleted_at", deleted_at).
 		Where(p.db.Sq.Equal("restaurant_id", restaurant_id)).
+		Where(p.db.Sq.Equal("deleted_at", nil), p.db.Sq.Equal("version", version)).
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build SQL query for deleting restaurant: %v", err)
+		return fmt.Errorf("failed to build SQL query for deleting restaurant: %w", err)
 	}
 
 	// Execute the SQL query
 	commandTag, err := p.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return fmt.Errorf("failed to execute SQL query for deleting restaurant: %v", err)
+		return fmt.Errorf("failed to execute SQL query for deleting restaurant: %w", p.db.Error(err))
 	}
 
 	// Check if any rows were affected
 	if commandTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows affected while deleting restaurant")
+		return versionMismatch(ctx, p.db, p.tableName, "restaurant_id", restaurant_id, "restaurant")
 	}
 
 	return nil
 }
 
 // list restaurants by location
-func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Restaurant, int64, error) {
+func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Restaurant, int64, error) {
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, restaurantSpanRepoPrefix+"ListL")
 	defer span.End()
@@ -483,11 +547,22 @@ func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, l
 	cityStr := "%"+city+"%"
 	stateStr := "%"+state_province+"%"
 
-	queryL := fmt.Sprintf("SELECT establishment_id FROM location_table WHERE country LIKE '%s' and city LIKE '%s' and state_province LIKE '%s' and category = 'restaurant' and deleted_at IS NULL LIMIT $1 OFFSET $2", countryStr, cityStr, stateStr)
-	rows, err := p.db.Query(ctx, queryL, limit, offset)
+	// establishments of this type only, the location category is not trusted
+	locationBuilder := p.db.Sq.Builder.Select("establishment_id").From(locationTableName).
+		Where(squirrel.Like{"country": countryStr, "city": cityStr, "state_province": stateStr}).
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		Where(squirrel.Expr("establishment_id IN (SELECT restaurant_id FROM restaurant_table WHERE deleted_at IS NULL)"))
+	locationBuilder = applyFilter(locationBuilder, "establishment_id", filter)
+
+	queryL, argsL, err := locationBuilder.Limit(limit).Offset(offset).ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
+
+	rows, err := p.db.Query(ctx, queryL, argsL...)
+	if err != nil {
+		return nil, 0, p.db.Error(err)
+	}
 	defer rows.Close()
 
 	var restaurants []*entity.Restaurant
@@ -497,12 +572,12 @@ func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, l
 		var establishment_id string
 
 		if err := rows.Scan(&establishment_id); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		var restaurant entity.Restaurant
 
-		queryA := `SELECT restaurant_id, owner_id, restaurant_name, description, rating, opening_hours, contact_number, licence_url, website_url, created_at, updated_at FROM restaurant_table WHERE restaurant_id = $1`
+		queryA := `SELECT restaurant_id, owner_id, restaurant_name, description, rating, opening_hours, contact_number, licence_url, website_url, created_at, updated_at, version FROM restaurant_table WHERE restaurant_id = $1`
 
 		if err := p.db.QueryRow(ctx, queryA, establishment_id).Scan(
 			&restaurant.RestaurantId,
@@ -516,8 +591,9 @@ func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, l
 			&restaurant.WebsiteUrl,
 			&restaurant.CreatedAt,
 			&restaurant.UpdatedAt,
+			&restaurant.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		var location entity.Location
@@ -536,16 +612,16 @@ func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, l
 			&location.CreatedAt,
 			&location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		restaurant.Location = location
 
-		queryI := `SELECT image_id, establishment_id, image_url, created_at, updated_at FROM image_table WHERE establishment_id = $1`
+		queryI := `SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM image_table WHERE establishment_id = $1`
 
 		rowsI, err := p.db.Query(ctx, queryI, restaurant.RestaurantId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 		defer rowsI.Close()
 
@@ -558,10 +634,11 @@ func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, l
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 
 			images = append(images, &image)
@@ -569,49 +646,51 @@ func (p restaurantRepo) ListRestaurantsByLocation(ctx context.Context, offset, l
 
 		restaurant.Images = images
 
+		// Fetch categories information for the restaurant
+		restaurant.Categories, err = selectEstablishmentCategories(ctx, p.db, restaurant.RestaurantId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		restaurants = append(restaurants, &restaurant)
 	}
 
 	var count int64
 
-	queryC := fmt.Sprintf("SELECT COUNT(*) establishment_id FROM location_table WHERE country LIKE '%s' and city LIKE '%s' and state_province LIKE '%s' and category = 'restaurant' and deleted_at IS NULL", countryStr, cityStr, stateStr)
-
-	if err := p.db.QueryRow(ctx, queryC).Scan(&count); err != nil {
+	queryC, argsC, err := locationBuilder.RemoveColumns().Columns("COUNT(*)").ToSql()
+	if err != nil {
 		return restaurants, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&count); err != nil {
+		return restaurants, 0, p.db.Error(err)
+	}
+
 	return restaurants, count, nil
 }
 
 // find restaurants by name
-func (p restaurantRepo) FindRestaurantsByName(ctx context.Context, name string) ([]*entity.Restaurant, uint64, error) {
+func (p restaurantRepo) FindRestaurantsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Restaurant, uint64, error) {
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, restaurantSpanRepoPrefix+"Find")
 	defer span.End()
 
 	var restaurants []*entity.Restaurant
 
-	query := `SELECT
-  restaurant_id,
-  owner_id,
-  restaurant_name,
-  description,
-  rating,
-	opening_hours,
-  contact_number,
-  licence_url,
-  website_url,
-  created_at,
-  updated_at
-  FROM restaurant_table
-  WHERE deleted_at IS NULL
-  AND restaurant_name ILIKE '%' || $1 || '%'
-  ORDER BY rating DESC`
-
-	rows, err := p.db.Query(ctx, query, name)
+	findBuilder := p.RestaurantSelectQueryPrefix().
+		Where(p.db.Sq.Equal("deleted_at", nil)).
+		Where(p.db.Sq.ILike("restaurant_name", "%"+name+"%"))
+	findBuilder = applyFilter(findBuilder, "restaurant_id", filter)
+
+	query, args, err := applySort(findBuilder, "restaurant_id", filter).ToSql()
 	if err != nil {
 		return nil, 0, err
 	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, p.db.Error(err)
+	}
 	defer rows.Close()
 
 	// Iterate over the rows to fetch each restaurant's details
@@ -629,8 +708,9 @@ func (p restaurantRepo) FindRestaurantsByName(ctx context.Context, name string)
 			&restaurant.WebsiteUrl,
 			&restaurant.CreatedAt,
 			&restaurant.UpdatedAt,
+			&restaurant.Version,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch location information for the restaurant
@@ -647,14 +727,14 @@ func (p restaurantRepo) FindRestaurantsByName(ctx context.Context, name string)
 			&restaurant.Location.CreatedAt,
 			&restaurant.Location.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Fetch images information for the restaurant
-		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
+		imagesQuery := fmt.Sprintf("SELECT image_id, establishment_id, image_url, caption, created_at, updated_at FROM %s WHERE establishment_id = $1", imageTableName)
 		imageRows, err := p.db.Query(ctx, imagesQuery, restaurant.RestaurantId)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Iterate over the image rows and populate the Images slice for the restaurant
@@ -665,28 +745,38 @@ func (p restaurantRepo) FindRestaurantsByName(ctx context.Context, name string)
 				&image.ImageId,
 				&image.EstablishmentId,
 				&image.ImageUrl,
+				&image.Caption,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 			); err != nil {
-				return nil, 0, err
+				return nil, 0, p.db.Error(err)
 			}
 			restaurant.Images = append(restaurant.Images, &image)
 		}
 		if err := imageRows.Err(); err != nil {
-			return nil, 0, err
+			return nil, 0, p.db.Error(err)
 		}
 
 		// Append the restaurant to the restaurants slice
+		// Fetch categories information for the restaurant
+		restaurant.Categories, err = selectEstablishmentCategories(ctx, p.db, restaurant.RestaurantId)
+		if err != nil {
+			return nil, 0, err
+		}
+
 		restaurants = append(restaurants, &restaurant)
 	}
 
 	var overall uint64
 
-	queryC := `SELECT COUNT(*) FROM restaurant_table WHERE restaurant_name ILIKE '%' || $1 || '%' and deleted_at IS NULL`
-
-	if err := p.db.QueryRow(ctx, queryC, name).Scan(&overall); err != nil {
+	queryC, argsC, err := findBuilder.RemoveColumns().Columns("COUNT(*)").ToSql()
+	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := p.db.QueryRow(ctx, queryC, argsC...).Scan(&overall); err != nil {
+		return nil, 0, p.db.Error(err)
+	}
+
 	return restaurants, overall, nil
 }
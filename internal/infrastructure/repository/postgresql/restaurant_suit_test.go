@@ -339,10 +339,14 @@ func TestUpdaterestaurant(t *testing.T) {
 	ctx, cancel = context.WithTimeout(context.Background(), time.Second*7)
 	defer cancel()
 
+	restaurant.Version = 1
+	restaurant.UpdatedAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
 	updatedrestaurant, err := repo.UpdateRestaurant(ctx, restaurant)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, updatedrestaurant)
+	assert.True(t, restaurant.UpdatedAt.Equal(updatedrestaurant.UpdatedAt))
+	assert.Equal(t, int64(2), updatedrestaurant.Version)
 	assert.Equal(t, restaurant.RestaurantId, updatedrestaurant.RestaurantId)
 	assert.Equal(t, restaurant.OwnerId, updatedrestaurant.OwnerId)
 	assert.Equal(t, restaurant.RestaurantName, updatedrestaurant.RestaurantName)
@@ -432,7 +436,7 @@ func TestDeleterestaurant(t *testing.T) {
 	ctx, cancel = context.WithTimeout(context.Background(), time.Second*7)
 	defer cancel()
 
-	err = repo.DeleteRestaurant(ctx, restaurant_id)
+	err = repo.DeleteRestaurant(ctx, restaurant_id, 1, time.Now().UTC())
 
 	assert.NoError(t, err)
 }
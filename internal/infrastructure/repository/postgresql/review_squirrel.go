@@ -5,9 +5,11 @@ import (
 	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"Booking/establishment-service-booking/internal/pkg/postgres"
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
@@ -52,8 +54,8 @@ func (r *reviewRepo) CreateReview(ctx context.Context, review *entity.Review) (*
 		"user_id":          review.UserId,
 		"rating":           review.Rating,
 		"comment":          review.Comment,
-		"created_at":       time.Now().Local(),
-		"updated_at":       time.Now().Local(),
+		"created_at":       review.CreatedAt,
+		"updated_at":       review.UpdatedAt,
 	}
 
 	query, args, err := r.db.Sq.Builder.Insert(r.reviewTableName).SetMap(data).ToSql()
@@ -63,7 +65,7 @@ func (r *reviewRepo) CreateReview(ctx context.Context, review *entity.Review) (*
 
 	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, r.db.Error(err)
 	}
 
 	var respReview entity.Review
@@ -84,12 +86,46 @@ func (r *reviewRepo) CreateReview(ctx context.Context, review *entity.Review) (*
 		&respReview.CreatedAt,
 		&respReview.UpdatedAt,
 	); err != nil {
-		return nil, err
+		return nil, r.db.Error(err)
 	}
 
 	return &respReview, nil
 }
 
+// get a review by review_id
+func (r *reviewRepo) GetReview(ctx context.Context, review_id string) (*entity.Review, error) {
+
+	ctx, span := otlp.Start(ctx, reviewServiceName, reviewSpanRepoPrefix+"Get")
+	defer span.End()
+
+	query, args, err := r.ReviewSelectQueryPrefix().
+		Where(r.db.Sq.Equal("review_id", review_id)).
+		Where(r.db.Sq.Equal("deleted_at", nil)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var review entity.Review
+
+	if err := r.db.QueryRow(ctx, query, args...).Scan(
+		&review.ReviewId,
+		&review.EstablishmentId,
+		&review.UserId,
+		&review.Rating,
+		&review.Comment,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.NewErrNotFound("review")
+		}
+		return nil, r.db.Error(err)
+	}
+
+	return &review, nil
+}
+
 // list reviews by establishment_id
 func (r *reviewRepo) ListReviews(ctx context.Context, establishment_id string) ([]*entity.Review, uint64, error) {
 	
@@ -109,7 +145,7 @@ func (r *reviewRepo) ListReviews(ctx context.Context, establishment_id string) (
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, r.db.Error(err)
 	}
 	defer rows.Close()
 
@@ -125,7 +161,7 @@ func (r *reviewRepo) ListReviews(ctx context.Context, establishment_id string) (
 			&review.CreatedAt,
 			&review.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, 0, r.db.Error(err)
 		}
 
 		reviews = append(reviews, &review)
@@ -136,7 +172,7 @@ func (r *reviewRepo) ListReviews(ctx context.Context, establishment_id string) (
 	queryC := `SELECT COUNT(*) FROM review_table WHERE deleted_at is NULL`
 
 	if err := r.db.QueryRow(ctx, queryC).Scan(&count); err != nil {
-		return nil, 0, err
+		return nil, 0, r.db.Error(err)
 	}
 
 	return reviews, count, nil
@@ -160,13 +196,84 @@ func (r *reviewRepo) DeleteReview(ctx context.Context, review_id string) error {
 	// Execute the SQL query
 	commandTag, err := r.db.Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return err
+		return r.db.Error(err)
 	}
 
 	// Check if any rows were affected
 	if commandTag.RowsAffected() == 0 {
-		return err
+		return entity.NewErrNotFound("review")
 	}
 
 	return nil
 }
+
+// list reviews written by a user
+func (r *reviewRepo) ListReviewsByUserId(ctx context.Context, user_id string) ([]*entity.Review, error) {
+
+	ctx, span := otlp.Start(ctx, reviewServiceName, reviewSpanRepoPrefix+"ListU")
+	defer span.End()
+
+	query, args, err := r.ReviewSelectQueryPrefix().
+		Where(r.db.Sq.Equal("user_id", user_id)).
+		Where(r.db.Sq.Equal("deleted_at", nil)).
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.db.Error(err)
+	}
+	defer rows.Close()
+
+	var reviews []*entity.Review
+
+	for rows.Next() {
+		var review entity.Review
+
+		if err := rows.Scan(
+			&review.ReviewId,
+			&review.EstablishmentId,
+			&review.UserId,
+			&review.Rating,
+			&review.Comment,
+			&review.CreatedAt,
+			&review.UpdatedAt,
+		); err != nil {
+			return nil, r.db.Error(err)
+		}
+
+		reviews = append(reviews, &review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.db.Error(err)
+	}
+
+	return reviews, nil
+}
+
+// replace the author of the reviews of a user with the anonymous user
+func (r *reviewRepo) AnonymiseReviews(ctx context.Context, user_id string) (int64, error) {
+
+	ctx, span := otlp.Start(ctx, reviewServiceName, reviewSpanRepoPrefix+"Anonymise")
+	defer span.End()
+
+	sqlStr, args, err := r.db.Sq.Builder.Update(r.reviewTableName).
+		Set("user_id", entity.AnonymousUserId).
+		Set("updated_at", time.Now().Local()).
+		Where(r.db.Sq.Equal("user_id", user_id)).
+		Where(r.db.Sq.Equal("deleted_at", nil)).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	commandTag, err := r.db.Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return 0, r.db.Error(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	statisticsServiceName    = "statisticsService"
+	statisticsSpanRepoPrefix = "statisticsRepo"
+)
+
+type statisticsRepo struct {
+	db *postgres.PostgresDB
+}
+
+func NewStatisticsRepo(db *postgres.PostgresDB) *statisticsRepo {
+	return &statisticsRepo{
+		db: db,
+	}
+}
+
+// establishmentCountQuery counts the establishments of every type
+const establishmentCountQuery = `SELECT '` + entity.EstablishmentTypeHotel + `', COUNT(*) FROM ` + hotelTableName + ` WHERE deleted_at IS NULL
+UNION ALL
+SELECT '` + entity.EstablishmentTypeRestaurant + `', COUNT(*) FROM ` + restaurantTableName + ` WHERE deleted_at IS NULL
+UNION ALL
+SELECT '` + entity.EstablishmentTypeAttraction + `', COUNT(*) FROM ` + attractionTableName + ` WHERE deleted_at IS NULL`
+
+func (p statisticsRepo) CountEstablishments(ctx context.Context) (map[string]int64, error) {
+
+	ctx, span := otlp.Start(ctx, statisticsServiceName, statisticsSpanRepoPrefix+"CountEstablishments")
+	defer span.End()
+
+	rows, err := p.db.Query(ctx, establishmentCountQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count establishments: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	counts := map[string]int64{}
+	for rows.Next() {
+		var (
+			establishmentType string
+			count             int64
+		)
+		if err := rows.Scan(&establishmentType, &count); err != nil {
+			return nil, p.db.Error(err)
+		}
+		counts[establishmentType] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, p.db.Error(err)
+	}
+
+	return counts, nil
+}
+
+func (p statisticsRepo) CountReviews(ctx context.Context, since time.Time) (int64, error) {
+
+	ctx, span := otlp.Start(ctx, statisticsServiceName, statisticsSpanRepoPrefix+"CountReviews")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Select("COUNT(*)").
+		From(reviewTableName).
+		Where(squirrel.GtOrEq{"created_at": since}).
+		Where("deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build SQL query for counting reviews: %w", err)
+	}
+
+	var count int64
+	if err := p.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reviews: %w", p.db.Error(err))
+	}
+
+	return count, nil
+}
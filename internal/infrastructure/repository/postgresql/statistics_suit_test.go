@@ -0,0 +1,73 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatistics(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewStatisticsRepo(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	defer cancel()
+
+	before, err := repo.CountEstablishments(ctx)
+	assert.NoError(t, err)
+	since := time.Now().UTC().Add(-time.Hour)
+	reviewsBefore, err := repo.CountReviews(ctx, since)
+	assert.NoError(t, err)
+
+	hotel_id := uuid.New().String()
+	hotel := &entity.Hotel{
+		HotelId:   hotel_id,
+		OwnerId:   uuid.New().String(),
+		HotelName: "test hotel name",
+		Location: entity.Location{
+			LocationId:      uuid.New().String(),
+			EstablishmentId: hotel_id,
+		},
+	}
+	if _, err := NewHotelRepo(db).CreateHotel(ctx, hotel); err != nil {
+		t.Fatalf("failed to insert hotel for testing: %v", err)
+	}
+
+	review := &entity.Review{
+		ReviewId:        uuid.New().String(),
+		EstablishmentId: hotel_id,
+		UserId:          uuid.New().String(),
+		Rating:          4,
+		CreatedAt:       time.Now().UTC(),
+		UpdatedAt:       time.Now().UTC(),
+	}
+	if _, err := NewReviewRepo(db).CreateReview(ctx, review); err != nil {
+		t.Fatalf("failed to insert review for testing: %v", err)
+	}
+
+	after, err := repo.CountEstablishments(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, before[entity.EstablishmentTypeHotel]+1, after[entity.EstablishmentTypeHotel])
+	assert.Equal(t, before[entity.EstablishmentTypeRestaurant], after[entity.EstablishmentTypeRestaurant])
+
+	reviews, err := repo.CountReviews(ctx, since)
+	assert.NoError(t, err)
+	assert.Equal(t, reviewsBefore+1, reviews)
+
+	assert.NoError(t, NewReviewRepo(db).DeleteReview(ctx, review.ReviewId))
+	assert.NoError(t, NewHotelRepo(db).DeleteHotel(ctx, hotel_id, initialVersion, time.Now().UTC()))
+}
@@ -0,0 +1,170 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	translationTableName      = "translation_table"
+	translationServiceName    = "translationService"
+	translationSpanRepoPrefix = "translationRepo"
+)
+
+type translationRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewTranslationRepo(db *postgres.PostgresDB) *translationRepo {
+	return &translationRepo{
+		tableName: translationTableName,
+		db:        db,
+	}
+}
+
+func (p *translationRepo) TranslationSelectQueryPrefix() squirrel.SelectBuilder {
+	return p.db.Sq.Builder.Select(
+		"entity_id",
+		"entity_type",
+		"field",
+		"locale",
+		"value",
+		"created_at",
+		"updated_at",
+	).From(p.tableName)
+}
+
+// list translations of the entities, in every locale when no locale is given
+func (p translationRepo) ListTranslations(ctx context.Context, entity_ids []string, locales []string) ([]*entity.Translation, error) {
+
+	ctx, span := otlp.Start(ctx, translationServiceName, translationSpanRepoPrefix+"List")
+	defer span.End()
+
+	if len(entity_ids) == 0 {
+		return nil, nil
+	}
+
+	queryBuilder := p.TranslationSelectQueryPrefix().Where(squirrel.Expr("entity_id = ANY(?)", entity_ids))
+
+	if len(locales) != 0 {
+		queryBuilder = queryBuilder.Where(squirrel.Expr("locale = ANY(?)", locales))
+	}
+
+	query, args, err := queryBuilder.OrderBy("entity_id", "field", "locale").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SQL query for listing translations: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", p.db.Error(err))
+	}
+	defer rows.Close()
+
+	var translations []*entity.Translation
+
+	for rows.Next() {
+		var translation entity.Translation
+
+		if err := rows.Scan(
+			&translation.EntityId,
+			&translation.EntityType,
+			&translation.Field,
+			&translation.Locale,
+			&translation.Value,
+			&translation.CreatedAt,
+			&translation.UpdatedAt,
+		); err != nil {
+			return nil, p.db.Error(err)
+		}
+
+		translations = append(translations, &translation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, p.db.Error(err)
+	}
+
+	return translations, nil
+}
+
+// create or overwrite translations
+func (p translationRepo) UpsertTranslations(ctx context.Context, translations []*entity.Translation) error {
+
+	ctx, span := otlp.Start(ctx, translationServiceName, translationSpanRepoPrefix+"Upsert")
+	defer span.End()
+
+	if len(translations) == 0 {
+		return nil
+	}
+
+	insert := p.db.Sq.Builder.Insert(p.tableName).Columns(
+		"entity_id",
+		"entity_type",
+		"field",
+		"locale",
+		"value",
+		"created_at",
+		"updated_at",
+	)
+
+	for _, translation := range translations {
+		insert = insert.Values(
+			translation.EntityId,
+			translation.EntityType,
+			translation.Field,
+			translation.Locale,
+			translation.Value,
+			time.Now().Local(),
+			time.Now().Local(),
+		)
+	}
+
+	query, args, err := insert.
+		Suffix("ON CONFLICT (entity_id, field, locale) DO UPDATE SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for saving translations: %w", err)
+	}
+
+	if _, err := p.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute SQL query for saving translations: %w", p.db.Error(err))
+	}
+
+	return nil
+}
+
+// delete a translation of an entity's field
+func (p translationRepo) DeleteTranslation(ctx context.Context, entity_id, field, locale string) error {
+
+	ctx, span := otlp.Start(ctx, translationServiceName, translationSpanRepoPrefix+"Delete")
+	defer span.End()
+
+	query, args, err := p.db.Sq.Builder.Delete(p.tableName).
+		Where(p.db.Sq.EqualMany(map[string]interface{}{
+			"entity_id": entity_id,
+			"field":     field,
+			"locale":    locale,
+		})).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build SQL query for deleting translation: %w", err)
+	}
+
+	commandTag, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute SQL query for deleting translation: %w", p.db.Error(err))
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return entity.NewErrNotFound("translation")
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/config"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslation(t *testing.T) {
+	// Connect to database
+	cfg := config.New()
+
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return
+	}
+
+	repo := NewTranslationRepo(db)
+
+	entity_id := uuid.New().String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*7)
+	defer cancel()
+
+	err = repo.UpsertTranslations(ctx, []*entity.Translation{
+		{EntityId: entity_id, EntityType: entity.EstablishmentTypeHotel, Field: entity.TranslationFieldName, Locale: "ru", Value: "Отель"},
+		{EntityId: entity_id, EntityType: entity.EstablishmentTypeHotel, Field: entity.TranslationFieldName, Locale: "uz", Value: "Mehmonxona"},
+	})
+	assert.NoError(t, err)
+
+	// saving again overwrites the value
+	err = repo.UpsertTranslations(ctx, []*entity.Translation{
+		{EntityId: entity_id, EntityType: entity.EstablishmentTypeHotel, Field: entity.TranslationFieldName, Locale: "ru", Value: "Гостиница"},
+	})
+	assert.NoError(t, err)
+
+	translations, err := repo.ListTranslations(ctx, []string{entity_id}, []string{"ru"})
+	assert.NoError(t, err)
+	assert.Len(t, translations, 1)
+	assert.Equal(t, "Гостиница", translations[0].Value)
+
+	assert.NoError(t, repo.DeleteTranslation(ctx, entity_id, entity.TranslationFieldName, "uz"))
+	assert.Error(t, repo.DeleteTranslation(ctx, entity_id, entity.TranslationFieldName, "uz"))
+
+	translations, err = repo.ListTranslations(ctx, []string{entity_id}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, translations, 1)
+
+	assert.NoError(t, repo.DeleteTranslation(ctx, entity_id, entity.TranslationFieldName, "ru"))
+}
@@ -0,0 +1,30 @@
+package postgresql
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/postgres"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// initialVersion is the version a new row gets by the column default
+const initialVersion = 1
+
+// versionMismatch explains why a write guarded by version matched no row,
+// either the object is gone or it was changed since the client read it
+func versionMismatch(ctx context.Context, db *postgres.PostgresDB, table, id_column, id, name string) error {
+	query := fmt.Sprintf("SELECT version FROM %s WHERE %s = $1 AND deleted_at IS NULL", table, id_column)
+
+	var version int64
+	if err := db.QueryRow(ctx, query, id).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.NewErrNotFound(name)
+		}
+		return fmt.Errorf("failed to get version of %s: %w", name, db.Error(err))
+	}
+
+	return entity.NewErrVersionMismatch(name, version)
+}
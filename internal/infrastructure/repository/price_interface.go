@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+)
+
+type Price interface {
+	CreatePrice(ctx context.Context, price *entity.Price) (*entity.Price, error)
+	ListPrices(ctx context.Context, establishment_ids []string) ([]*entity.Price, error)
+	DeletePrice(ctx context.Context, price_id string) error
+}
+
+type CurrencyRate interface {
+	ListCurrencyRates(ctx context.Context) ([]*entity.CurrencyRate, error)
+	UpsertCurrencyRates(ctx context.Context, rates []*entity.CurrencyRate) error
+}
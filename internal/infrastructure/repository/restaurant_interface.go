@@ -3,14 +3,15 @@ package repository
 import (
 	"Booking/establishment-service-booking/internal/entity"
 	"context"
+	"time"
 )
 
 type Restaurant interface {
 	CreateRestaurant(ctx context.Context, restaurant *entity.Restaurant) (*entity.Restaurant, error)
 	GetRestaurant(ctx context.Context, restaurant_id string) (*entity.Restaurant, error)
-	ListRestaurants(ctx context.Context, offset, limit int64) ([]*entity.Restaurant, uint64, error)
+	ListRestaurants(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Restaurant, uint64, error)
 	UpdateRestaurant(ctx context.Context, restaurant *entity.Restaurant) (*entity.Restaurant, error)
-	DeleteRestaurant(ctx context.Context, restaurant_id string) error
-	ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Restaurant, int64, error)
-	FindRestaurantsByName(ctx context.Context, name string) ([]*entity.Restaurant, uint64, error)
+	DeleteRestaurant(ctx context.Context, restaurant_id string, version int64, deleted_at time.Time) error
+	ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Restaurant, int64, error)
+	FindRestaurantsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Restaurant, uint64, error)
 }
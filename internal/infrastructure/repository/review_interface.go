@@ -7,6 +7,11 @@ import (
 
 type Review interface {
 	CreateReview(ctx context.Context, review *entity.Review) (*entity.Review, error)
+	GetReview(ctx context.Context, review_id string) (*entity.Review, error)
 	ListReviews(ctx context.Context, establishment_id string) ([]*entity.Review, uint64, error)
 	DeleteReview(ctx context.Context, review_id string) error
+	ListReviewsByUserId(ctx context.Context, user_id string) ([]*entity.Review, error)
+	// AnonymiseReviews replaces the author with entity.AnonymousUserId and
+	// returns how many reviews changed
+	AnonymiseReviews(ctx context.Context, user_id string) (int64, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Statistics counts objects across establishments for the metrics
+type Statistics interface {
+	// CountEstablishments returns the establishments that are not deleted by type
+	CountEstablishments(ctx context.Context) (map[string]int64, error)
+	// CountReviews returns the reviews that are not deleted written from since
+	CountReviews(ctx context.Context, since time.Time) (int64, error)
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// Transactor runs the repository calls made with the context passed to fn
+// in one transaction
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
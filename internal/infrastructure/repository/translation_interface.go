@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+)
+
+type Translation interface {
+	ListTranslations(ctx context.Context, entity_ids []string, locales []string) ([]*entity.Translation, error)
+	UpsertTranslations(ctx context.Context, translations []*entity.Translation) error
+	DeleteTranslation(ctx context.Context, entity_id, field, locale string) error
+}
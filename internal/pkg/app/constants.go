@@ -1,20 +1,117 @@
 package app
 
 import (
+	"Booking/establishment-service-booking/internal/entity"
 	"context"
 )
 
 type ctxKeyLocalization int
 
+type ctxKeyCategories int
+
+type ctxKeyCurrency int
+
+type ctxKeyCaller int
+
+type ctxKeyIdempotencyKey int
+
+type ctxKeyUpdateMask int
+
+type ctxKeyVersion int
+
+type ctxKeyMethod int
+
+type ctxKeySort int
+
 const (
-	EnvironmentProduction                    = "production"
-	EnvironmentDevelop                       = "develop"
-	CtxKeyLocalization    ctxKeyLocalization = 0
+	EnvironmentProduction                      = "production"
+	EnvironmentDevelop                         = "develop"
+	CtxKeyLocalization    ctxKeyLocalization   = 0
+	CtxKeyCategories      ctxKeyCategories     = 0
+	CtxKeyCurrency        ctxKeyCurrency       = 0
+	CtxKeyCaller          ctxKeyCaller         = 0
+	CtxKeyIdempotencyKey  ctxKeyIdempotencyKey = 0
+	CtxKeyUpdateMask      ctxKeyUpdateMask     = 0
+	CtxKeyVersion         ctxKeyVersion        = 0
+	CtxKeyMethod          ctxKeyMethod         = 0
+	CtxKeySort            ctxKeySort           = 0
 )
 
+// MetadataKeyVersion carries the version of an establishment, servers send
+// it in the response header and clients send it back with updates and deletes
+const MetadataKeyVersion = "version"
+
 func GetLocalizationFromContext(ctx context.Context) string {
 	if lang, ok := ctx.Value(CtxKeyLocalization).(string); ok {
 		return lang
 	}
 	return ""
 }
+
+// GetCategoriesFromContext returns category codes sent by the client,
+// nil means the client did not send any
+func GetCategoriesFromContext(ctx context.Context) []string {
+	if categories, ok := ctx.Value(CtxKeyCategories).([]string); ok {
+		return categories
+	}
+	return nil
+}
+
+// GetCurrencyFromContext returns the currency prices are requested in
+func GetCurrencyFromContext(ctx context.Context) string {
+	if currency, ok := ctx.Value(CtxKeyCurrency).(string); ok {
+		return currency
+	}
+	return ""
+}
+
+// GetCallerFromContext returns the caller of the request, nil when anonymous
+func GetCallerFromContext(ctx context.Context) *entity.Caller {
+	if caller, ok := ctx.Value(CtxKeyCaller).(*entity.Caller); ok {
+		return caller
+	}
+	return nil
+}
+
+// GetIdempotencyKeyFromContext returns the key the client sent to make a
+// create request safe to retry, "" when none
+func GetIdempotencyKeyFromContext(ctx context.Context) string {
+	if key, ok := ctx.Value(CtxKeyIdempotencyKey).(string); ok {
+		return key
+	}
+	return ""
+}
+
+// GetUpdateMaskFromContext returns field paths an update is limited to, nil
+// means the client did not send a mask and every field is replaced
+func GetUpdateMaskFromContext(ctx context.Context) []string {
+	if paths, ok := ctx.Value(CtxKeyUpdateMask).([]string); ok {
+		return paths
+	}
+	return nil
+}
+
+// GetVersionFromContext returns the version of the object the client read
+// before changing it, false when the client did not send one
+func GetVersionFromContext(ctx context.Context) (int64, bool) {
+	version, ok := ctx.Value(CtxKeyVersion).(int64)
+	return version, ok
+}
+
+// GetMethodFromContext returns the name of the RPC being served, e.g.
+// "UpdateHotel", or the type of the event being handled, e.g. "user.deleted"
+func GetMethodFromContext(ctx context.Context) string {
+	if method, ok := ctx.Value(CtxKeyMethod).(string); ok {
+		return method
+	}
+	return ""
+}
+
+// GetSortFromContext returns the order a list is requested in, e.g.
+// "popularity", "" for the default order
+func GetSortFromContext(ctx context.Context) string {
+	if sort, ok := ctx.Value(CtxKeySort).(string); ok {
+		return sort
+	}
+	return ""
+}
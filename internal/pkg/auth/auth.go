@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the claims of access tokens, the user id is the subject
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// Authenticator verifies access tokens signed with keys of a key set
+type Authenticator struct {
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+func NewAuthenticator(keys *KeySet, issuer, audience string, leeway time.Duration) *Authenticator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{AlgorithmHS256, AlgorithmRS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &Authenticator{
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+// Authenticate verifies the token and returns the caller it was issued to
+func (a *Authenticator) Authenticate(token string) (*entity.Caller, error) {
+	var claims Claims
+
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.keyFunc); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("invalid token: no subject")
+	}
+
+	caller := &entity.Caller{UserId: claims.Subject, Role: entity.RoleUser}
+	if entity.IsRole(claims.Role) {
+		caller.Role = claims.Role
+	}
+
+	return caller, nil
+}
+
+func (a *Authenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	keys := a.keys.Lookup(kid, token.Method.Alg())
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no %s key with id %q", token.Method.Alg(), kid)
+	}
+
+	set := jwt.VerificationKeySet{Keys: make([]jwt.VerificationKey, 0, len(keys))}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, key.Key)
+	}
+	return set, nil
+}
+
+// ParseDevCaller parses the fixed caller of local development given as
+// "user_id:role"
+func ParseDevCaller(value string) (*entity.Caller, error) {
+	user_id, role, found := strings.Cut(value, ":")
+	user_id, role = strings.TrimSpace(user_id), strings.TrimSpace(role)
+	if !found || user_id == "" || !entity.IsRole(role) {
+		return nil, fmt.Errorf("invalid dev caller %q: want user_id:role with role %s, %s or %s", value, entity.RoleUser, entity.RoleOwner, entity.RoleAdmin)
+	}
+	return &entity.Caller{UserId: user_id, Role: role}, nil
+}
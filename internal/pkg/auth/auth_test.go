@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"Booking/establishment-service-booking/internal/entity"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func validClaims(subject, role string) Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "booking",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Role: role,
+	}
+}
+
+func writeJWKS(t *testing.T, path string, keys map[string]*rsa.PublicKey) {
+	var entries string
+	for kid, key := range keys {
+		if entries != "" {
+			entries += ","
+		}
+		entries += fmt.Sprintf(`{"kty":"RSA","kid":%q,"use":"sig","alg":"RS256","n":%q,"e":%q}`,
+			kid,
+			base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"keys":[`+entries+`]}`), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+}
+
+func TestAuthenticateHS256(t *testing.T) {
+	keys, err := NewKeySet(time.Minute, StaticKeys{NewHMACKey("", "old-secret"), NewHMACKey("", "new-secret")})
+	assert.NoError(t, err)
+
+	authenticator := NewAuthenticator(keys, "booking", "", 0)
+
+	// both secrets are accepted during rotation
+	for _, secret := range []string{"old-secret", "new-secret"} {
+		caller, err := authenticator.Authenticate(sign(t, jwt.SigningMethodHS256, "", []byte(secret), validClaims("user-1", entity.RoleOwner)))
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.Caller{UserId: "user-1", Role: entity.RoleOwner}, caller)
+	}
+
+	// unknown roles are downgraded to user
+	caller, err := authenticator.Authenticate(sign(t, jwt.SigningMethodHS256, "", []byte("new-secret"), validClaims("user-1", "root")))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RoleUser, caller.Role)
+
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodHS256, "", []byte("other-secret"), validClaims("user-1", entity.RoleUser)))
+	assert.Error(t, err)
+
+	expired := validClaims("user-1", entity.RoleUser)
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodHS256, "", []byte("new-secret"), expired))
+	assert.Error(t, err)
+
+	wrongIssuer := validClaims("user-1", entity.RoleUser)
+	wrongIssuer.Issuer = "someone"
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodHS256, "", []byte("new-secret"), wrongIssuer))
+	assert.Error(t, err)
+}
+
+func TestAuthenticateRS256WithJWKSRotation(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	second, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]*rsa.PublicKey{"first": &first.PublicKey})
+
+	// keys are reloaded on every lookup
+	keys, err := NewKeySet(0, JWKSFile(path))
+	assert.NoError(t, err)
+
+	authenticator := NewAuthenticator(keys, "", "", 0)
+
+	caller, err := authenticator.Authenticate(sign(t, jwt.SigningMethodRS256, "first", first, validClaims("admin-1", entity.RoleAdmin)))
+	assert.NoError(t, err)
+	assert.True(t, caller.IsAdmin())
+
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodRS256, "second", second, validClaims("admin-1", entity.RoleAdmin)))
+	assert.Error(t, err)
+
+	// the rotated key is picked up from the file
+	writeJWKS(t, path, map[string]*rsa.PublicKey{"first": &first.PublicKey, "second": &second.PublicKey})
+
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodRS256, "second", second, validClaims("admin-1", entity.RoleAdmin)))
+	assert.NoError(t, err)
+
+	// a revoked key stops verifying although its tokens still find a key
+	writeJWKS(t, path, map[string]*rsa.PublicKey{"second": &second.PublicKey})
+
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodRS256, "first", first, validClaims("admin-1", entity.RoleAdmin)))
+	assert.Error(t, err)
+}
+
+func TestKeySetRefreshesOncePerInterval(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, map[string]*rsa.PublicKey{"first": &key.PublicKey})
+
+	keys, err := NewKeySet(time.Hour, JWKSFile(path))
+	assert.NoError(t, err)
+
+	// the keys are not stale yet
+	writeJWKS(t, path, map[string]*rsa.PublicKey{})
+	assert.Len(t, keys.Lookup("first", AlgorithmRS256), 1)
+}
+
+func TestAuthenticateRejectsAlgorithmConfusion(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	keys, err := NewKeySet(time.Minute, StaticKeys{{Algorithm: AlgorithmRS256, Key: &key.PublicKey}})
+	assert.NoError(t, err)
+
+	authenticator := NewAuthenticator(keys, "", "", 0)
+
+	// an HS256 token must not be verified with the RSA key
+	_, err = authenticator.Authenticate(sign(t, jwt.SigningMethodHS256, "", key.PublicKey.N.Bytes(), validClaims("user-1", entity.RoleAdmin)))
+	assert.Error(t, err)
+
+	// unsigned tokens are rejected
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims("user-1", entity.RoleAdmin)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+	_, err = authenticator.Authenticate(none)
+	assert.Error(t, err)
+}
+
+func TestParseDevCaller(t *testing.T) {
+	caller, err := ParseDevCaller("user-1:admin")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Caller{UserId: "user-1", Role: entity.RoleAdmin}, caller)
+
+	for _, value := range []string{"", "user-1", ":owner", "user-1:root"} {
+		_, err := ParseDevCaller(value)
+		assert.Error(t, err, value)
+	}
+}
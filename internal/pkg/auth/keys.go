@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// Key is a verification key, Id matches the "kid" header of tokens signed
+// with it and may be empty
+type Key struct {
+	Id        string
+	Algorithm string
+	Key       interface{}
+}
+
+// KeySource loads verification keys, it is asked again on rotation
+type KeySource interface {
+	Keys() ([]Key, error)
+}
+
+// StaticKeys are keys taken from configuration
+type StaticKeys []Key
+
+func (s StaticKeys) Keys() ([]Key, error) {
+	return s, nil
+}
+
+// NewHMACKey returns an HS256 key of the shared secret
+func NewHMACKey(id, secret string) Key {
+	return Key{Id: id, Algorithm: AlgorithmHS256, Key: []byte(secret)}
+}
+
+// NewRSAKeyFromFile reads an RS256 public key in PEM format
+func NewRSAKeyFromFile(id, path string) (Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to read RSA public key: %w", err)
+	}
+
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to parse RSA public key: %w", err)
+	}
+
+	return Key{Id: id, Algorithm: AlgorithmRS256, Key: key}, nil
+}
+
+// JWKSFile reads keys from a local JSON Web Key Set, the file is read on
+// every rotation so replacing it rotates the keys
+type JWKSFile string
+
+func (f JWKSFile) Keys() ([]Key, error) {
+	data, err := os.ReadFile(string(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// symmetric
+	K string `json:"k"`
+}
+
+// ParseJWKS parses RSA and symmetric keys of a JSON Web Key Set
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make([]Key, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		switch k.Kty {
+		case "RSA":
+			if k.Alg != "" && k.Alg != AlgorithmRS256 {
+				continue
+			}
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("invalid modulus of key %s: %w", k.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exponent of key %s: %w", k.Kid, err)
+			}
+			keys = append(keys, Key{
+				Id:        k.Kid,
+				Algorithm: AlgorithmRS256,
+				Key: &rsa.PublicKey{
+					N: new(big.Int).SetBytes(n),
+					E: int(new(big.Int).SetBytes(e).Int64()),
+				},
+			})
+		case "oct":
+			if k.Alg != "" && k.Alg != AlgorithmHS256 {
+				continue
+			}
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return nil, fmt.Errorf("invalid secret of key %s: %w", k.Kid, err)
+			}
+			keys = append(keys, Key{Id: k.Kid, Algorithm: AlgorithmHS256, Key: secret})
+		}
+	}
+
+	return keys, nil
+}
+
+// KeySet holds the current keys of its sources and reloads them on the first
+// lookup after every refresh interval, so rotated keys are picked up and
+// revoked ones stop verifying without a restart
+type KeySet struct {
+	sources  []KeySource
+	interval time.Duration
+
+	mu        sync.RWMutex
+	keys      []Key
+	refreshed time.Time
+}
+
+func NewKeySet(interval time.Duration, sources ...KeySource) (*KeySet, error) {
+	set := &KeySet{sources: sources, interval: interval}
+	if err := set.Refresh(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Refresh reloads keys of every source, the old keys are kept on failure
+func (s *KeySet) Refresh() error {
+	s.mu.Lock()
+	s.refreshed = time.Now()
+	s.mu.Unlock()
+
+	return s.load()
+}
+
+func (s *KeySet) load() error {
+	var keys []Key
+	for _, source := range s.sources {
+		loaded, err := source.Keys()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, loaded...)
+	}
+	if len(keys) == 0 {
+		return errors.New("no verification keys configured")
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Lookup returns keys of the algorithm, only the one with the id if given.
+// The keys are reloaded first when they are older than the interval, the old
+// ones are used when the sources fail.
+func (s *KeySet) Lookup(id, algorithm string) []Key {
+	if s.claimRefresh() {
+		s.load()
+	}
+	return s.lookup(id, algorithm)
+}
+
+func (s *KeySet) lookup(id, algorithm string) []Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var keys []Key
+	for _, key := range s.keys {
+		if key.Algorithm != algorithm {
+			continue
+		}
+		if id != "" && key.Id != "" && key.Id != id {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// claimRefresh reports whether the keys are stale and lets only one lookup
+// reload them, failed reloads count too so bad tokens can not hammer the
+// sources
+func (s *KeySet) claimRefresh() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Since(s.refreshed) < s.interval {
+		return false
+	}
+	s.refreshed = time.Now()
+	return true
+}
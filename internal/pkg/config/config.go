@@ -25,14 +25,103 @@ type Config struct {
 	}
 
 	OTLPCollector struct {
-		Host string
-		Port string
+		Enabled      string
+		Host         string
+		Port         string
+		Sampler      string
+		SamplerRatio string
+	}
+
+	Auth struct {
+		HMACSecrets      []string
+		RSAPublicKeyFile string
+		JWKSFile         string
+		KeysRefresh      string
+		Issuer           string
+		Audience         string
+		Leeway           string
+		PublicMethods    []string
+		DevCaller        string
+	}
+
+	CurrencyRates struct {
+		File string
+	}
+
+	Idempotency struct {
+		TTL     string
+		Cleanup string
+		Methods []string
+	}
+
+	Outbox struct {
+		Interval       string
+		PublishTimeout string
+		MinBackoff     string
+		MaxBackoff     string
+		Retention      string
+	}
+
+	Shutdown struct {
+		ReadinessDelay string
+		DrainTimeout   string
+		Timeout        string
+	}
+
+	Health struct {
+		Interval string
+		Timeout  string
+		Checks   []string
+	}
+
+	Metrics struct {
+		Port               string
+		StatisticsInterval string
+	}
+
+	ServiceClients struct {
+		MinBackoff          string
+		MaxBackoff          string
+		ReconnectMaxBackoff string
+		HealthCheck         string
+	}
+
+	UserService struct {
+		Address         string
+		TLS             string
+		CAFile          string
+		ServerName      string
+		Timeout         string
+		MethodTimeouts  []string
+		Retries         string
+		BreakerFailures string
+		BreakerCooldown string
+	}
+
+	BookingService struct {
+		Address         string
+		TLS             string
+		CAFile          string
+		ServerName      string
+		Timeout         string
+		MethodTimeouts  []string
+		Retries         string
+		BreakerFailures string
+		BreakerCooldown string
 	}
 
 	Kafka struct {
 		Address []string
 		Topic   struct {
-			UserService string
+			UserService         string
+			BookingService      string
+			EstablishmentEvents string
+		}
+		Consumer struct {
+			Group       string
+			MaxAttempts string
+			MinBackoff  string
+			MaxBackoff  string
 		}
 	}
 }
@@ -58,10 +147,121 @@ func New() *Config {
 	// otlp collector configuration
 	config.OTLPCollector.Host = getEnv("OTLP_COLLECTOR_HOST", "otel-collector")
 	config.OTLPCollector.Port = getEnv("OTLP_COLLECTOR_PORT", ":4317")
+	// sampler names follow OTEL_TRACES_SAMPLER, the ratio applies to the ratio samplers
+	config.OTLPCollector.Enabled = getEnv("OTLP_ENABLED", "true")
+	config.OTLPCollector.Sampler = getEnv("OTLP_SAMPLER", "parentbased_traceidratio")
+	config.OTLPCollector.SamplerRatio = getEnv("OTLP_SAMPLER_RATIO", "1")
+
+	// auth configuration, secrets are comma separated to allow rotation
+	config.Auth.HMACSecrets = splitNonEmpty(getEnv("JWT_HMAC_SECRETS", ""))
+	config.Auth.RSAPublicKeyFile = getEnv("JWT_RSA_PUBLIC_KEY_FILE", "")
+	config.Auth.JWKSFile = getEnv("JWT_JWKS_FILE", "")
+	config.Auth.KeysRefresh = getEnv("JWT_KEYS_REFRESH", "5m")
+	config.Auth.Issuer = getEnv("JWT_ISSUER", "")
+	config.Auth.Audience = getEnv("JWT_AUDIENCE", "")
+	config.Auth.Leeway = getEnv("JWT_LEEWAY", "30s")
+	config.Auth.PublicMethods = splitNonEmpty(getEnv("JWT_PUBLIC_METHODS", strings.Join([]string{
+		"GetAttraction", "ListAttractions", "FindAttractionsByName", "ListAttractionsByLocation",
+		"GetRestaurant", "ListRestaurants", "FindRestaurantsByName", "ListRestaurantsByLocation",
+		"GetHotel", "ListHotels", "FindHotelsByName", "ListHotelsByLocation",
+		"ListReviews",
+	}, ",")))
+
+	// the caller of every request when no keys are configured, as
+	// "user_id:role", e.g. "8f14e45f-ceea-467f-a0e6-7b5b1b1f3a2c:admin". It is
+	// opt-in for local development and refused in production, without it and
+	// without keys the service does not start.
+	config.Auth.DevCaller = getEnv("JWT_DEV_CALLER", "")
+
+	// currency rates configuration
+	config.CurrencyRates.File = getEnv("CURRENCY_RATES_FILE", "")
+
+	// idempotency configuration, responses are replayed for TTL
+	config.Idempotency.TTL = getEnv("IDEMPOTENCY_TTL", "24h")
+	config.Idempotency.Cleanup = getEnv("IDEMPOTENCY_CLEANUP", "1h")
+	config.Idempotency.Methods = splitNonEmpty(getEnv("IDEMPOTENT_METHODS", strings.Join([]string{
+		"CreateAttraction", "CreateRestaurant", "CreateHotel", "CreateReview", "CreateMedia",
+		"AddToFavourites",
+	}, ",")))
+
+	// outbox relay configuration, failed messages are retried with a backoff
+	// doubling from the min to the max, sent messages are kept for retention.
+	// Every message gets the publish timeout to reach the broker.
+	config.Outbox.Interval = getEnv("OUTBOX_INTERVAL", "1s")
+	config.Outbox.PublishTimeout = getEnv("OUTBOX_PUBLISH_TIMEOUT", "5s")
+	config.Outbox.MinBackoff = getEnv("OUTBOX_MIN_BACKOFF", "1s")
+	config.Outbox.MaxBackoff = getEnv("OUTBOX_MAX_BACKOFF", "5m")
+	config.Outbox.Retention = getEnv("OUTBOX_RETENTION", "168h")
+
+	// shutdown configuration, after readiness flips the instance keeps
+	// serving for the readiness delay so load balancers stop routing to it,
+	// running RPCs then get the drain timeout to complete and the rest of
+	// the teardown the timeout
+	config.Shutdown.ReadinessDelay = getEnv("SHUTDOWN_READINESS_DELAY", "0s")
+	config.Shutdown.DrainTimeout = getEnv("SHUTDOWN_DRAIN_TIMEOUT", "20s")
+	config.Shutdown.Timeout = getEnv("SHUTDOWN_TIMEOUT", "10s")
+
+	// health configuration, the service is ready in grpc.health.v1 while
+	// the listed checks pass: postgres, kafka and services, the configured
+	// user and booking services
+	config.Health.Interval = getEnv("HEALTH_INTERVAL", "5s")
+	config.Health.Timeout = getEnv("HEALTH_TIMEOUT", "2s")
+	config.Health.Checks = splitNonEmpty(getEnv("HEALTH_CHECKS", "postgres,kafka,services"))
+
+	// metrics configuration, prometheus scrapes /metrics on the port, an
+	// empty port turns the endpoint off. Business gauges are counted in the
+	// database at the statistics interval.
+	config.Metrics.Port = getEnv("METRICS_PORT", ":9100")
+	config.Metrics.StatisticsInterval = getEnv("METRICS_STATISTICS_INTERVAL", "1m")
+
+	// outbound gRPC clients configuration, retries wait a jittered backoff
+	// doubling from the min to the max; connections are reconnected with a
+	// backoff up to the reconnect max and, with the health check, only to
+	// servers reporting SERVING
+	config.ServiceClients.MinBackoff = getEnv("SERVICE_CLIENTS_MIN_BACKOFF", "100ms")
+	config.ServiceClients.MaxBackoff = getEnv("SERVICE_CLIENTS_MAX_BACKOFF", "2s")
+	config.ServiceClients.ReconnectMaxBackoff = getEnv("SERVICE_CLIENTS_RECONNECT_MAX_BACKOFF", "30s")
+	config.ServiceClients.HealthCheck = getEnv("SERVICE_CLIENTS_HEALTH_CHECK", "true")
+
+	// user service configuration, owners and reviewers are not checked
+	// when no address is set; method timeouts are comma separated
+	// Method=duration pairs overriding the timeout; after the given
+	// consecutive failures calls fail fast until the cooldown passes, zero
+	// failures disable it
+	config.UserService.Address = getEnv("USER_SERVICE_ADDRESS", "")
+	config.UserService.TLS = getEnv("USER_SERVICE_TLS", "false")
+	config.UserService.CAFile = getEnv("USER_SERVICE_CA_FILE", "")
+	config.UserService.ServerName = getEnv("USER_SERVICE_SERVER_NAME", "")
+	config.UserService.Timeout = getEnv("USER_SERVICE_TIMEOUT", "3s")
+	config.UserService.MethodTimeouts = splitNonEmpty(getEnv("USER_SERVICE_METHOD_TIMEOUTS", ""))
+	config.UserService.Retries = getEnv("USER_SERVICE_RETRIES", "2")
+	config.UserService.BreakerFailures = getEnv("USER_SERVICE_BREAKER_FAILURES", "5")
+	config.UserService.BreakerCooldown = getEnv("USER_SERVICE_BREAKER_COOLDOWN", "30s")
+
+	// booking service configuration, booking summaries are unavailable when
+	// no address is set; the rest is configured like the user service
+	config.BookingService.Address = getEnv("BOOKING_SERVICE_ADDRESS", "")
+	config.BookingService.TLS = getEnv("BOOKING_SERVICE_TLS", "false")
+	config.BookingService.CAFile = getEnv("BOOKING_SERVICE_CA_FILE", "")
+	config.BookingService.ServerName = getEnv("BOOKING_SERVICE_SERVER_NAME", "")
+	config.BookingService.Timeout = getEnv("BOOKING_SERVICE_TIMEOUT", "3s")
+	config.BookingService.MethodTimeouts = splitNonEmpty(getEnv("BOOKING_SERVICE_METHOD_TIMEOUTS", ""))
+	config.BookingService.Retries = getEnv("BOOKING_SERVICE_RETRIES", "2")
+	config.BookingService.BreakerFailures = getEnv("BOOKING_SERVICE_BREAKER_FAILURES", "5")
+	config.BookingService.BreakerCooldown = getEnv("BOOKING_SERVICE_BREAKER_COOLDOWN", "30s")
 
 	// kafka configuration
 	config.Kafka.Address = strings.Split(getEnv("KAFKA_ADDRESS", "localhost:29092"), ",")
 	config.Kafka.Topic.UserService = getEnv("KAFKA_TOPIC_USER_SERVICE", "user.service")
+	config.Kafka.Topic.BookingService = getEnv("KAFKA_TOPIC_BOOKING_SERVICE", "booking.service")
+	config.Kafka.Topic.EstablishmentEvents = getEnv("KAFKA_TOPIC_ESTABLISHMENT_EVENTS", "establishment.events")
+
+	// kafka consumer configuration, failed messages are retried with a backoff
+	// doubling from the min to the max before going to the dead letter topic
+	config.Kafka.Consumer.Group = getEnv("KAFKA_CONSUMER_GROUP", "establishment-service")
+	config.Kafka.Consumer.MaxAttempts = getEnv("KAFKA_CONSUMER_MAX_ATTEMPTS", "5")
+	config.Kafka.Consumer.MinBackoff = getEnv("KAFKA_CONSUMER_MIN_BACKOFF", "1s")
+	config.Kafka.Consumer.MaxBackoff = getEnv("KAFKA_CONSUMER_MAX_BACKOFF", "1m")
 
 	return &config
 }
@@ -73,3 +273,13 @@ func getEnv(key string, defaultVaule string) string {
 	}
 	return defaultVaule
 }
+
+func splitNonEmpty(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"strconv"
+
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace prefixes the metrics of the service, RPC metrics keep the grpc_
+// names of go-grpc-prometheus so the usual dashboards work
+const namespace = "establishment"
+
+// Metrics holds the collectors of the service in a registry of its own. The
+// methods of a nil Metrics count nothing, which is what tests use.
+type Metrics struct {
+	Registry *prometheus.Registry
+	// RPC counts requests, status codes and latencies of the gRPC server
+	RPC *grpc_prometheus.ServerMetrics
+
+	published      *prometheus.CounterVec
+	publishErrors  *prometheus.CounterVec
+	consumed       *prometheus.CounterVec
+	consumeErrors  *prometheus.CounterVec
+	consumerLag    *prometheus.GaugeVec
+	establishments *prometheus.GaugeVec
+	reviews        prometheus.Gauge
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		RPC:      grpc_prometheus.NewServerMetrics(),
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "published_messages_total",
+			Help:      "Messages written to the broker.",
+		}, []string{"topic"}),
+		publishErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "publish_errors_total",
+			Help:      "Messages the broker did not take.",
+		}, []string{"topic"}),
+		consumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "consumed_messages_total",
+			Help:      "Messages handled and committed.",
+		}, []string{"topic"}),
+		consumeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "consume_errors_total",
+			Help:      "Failures of the consumers by stage: fetch, handle, commit or dead_letter.",
+		}, []string{"topic", "stage"}),
+		consumerLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "consumer_lag_messages",
+			Help:      "Messages of the partition behind the last one fetched.",
+		}, []string{"topic", "partition"}),
+		establishments: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "establishments",
+			Help:      "Establishments that are not deleted by type.",
+		}, []string{"type"}),
+		reviews: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "reviews_last_day",
+			Help:      "Reviews written in the last 24 hours.",
+		}),
+	}
+	m.RPC.EnableHandlingTimeHistogram()
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.RPC,
+		m.published,
+		m.publishErrors,
+		m.consumed,
+		m.consumeErrors,
+		m.consumerLag,
+		m.establishments,
+		m.reviews,
+	)
+	return m
+}
+
+// Published counts a message written to the topic, or not when err is set
+func (m *Metrics) Published(topic string, err error) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.publishErrors.WithLabelValues(topic).Inc()
+		return
+	}
+	m.published.WithLabelValues(topic).Inc()
+}
+
+// Consumed counts a message handled and records the lag of its partition
+// given the high water mark the broker sent with it
+func (m *Metrics) Consumed(topic string, partition int, offset, highWaterMark int64) {
+	if m == nil {
+		return
+	}
+	m.consumed.WithLabelValues(topic).Inc()
+
+	lag := highWaterMark - offset - 1
+	if lag < 0 {
+		lag = 0
+	}
+	m.consumerLag.WithLabelValues(topic, strconv.Itoa(partition)).Set(float64(lag))
+}
+
+// stages of the consumer an error is counted for
+const (
+	StageFetch      = "fetch"
+	StageHandle     = "handle"
+	StageCommit     = "commit"
+	StageDeadLetter = "dead_letter"
+)
+
+// ConsumeFailed counts a failure of a consumer of the topic at the stage
+func (m *Metrics) ConsumeFailed(topic, stage string) {
+	if m == nil {
+		return
+	}
+	m.consumeErrors.WithLabelValues(topic, stage).Inc()
+}
+
+// SetStatistics sets the business gauges
+func (m *Metrics) SetStatistics(statistics *entity.Statistics) {
+	if m == nil {
+		return
+	}
+	for _, establishmentType := range []string{entity.EstablishmentTypeHotel, entity.EstablishmentTypeRestaurant, entity.EstablishmentTypeAttraction} {
+		m.establishments.WithLabelValues(establishmentType).Set(float64(statistics.Establishments[establishmentType]))
+	}
+	m.reviews.Set(float64(statistics.ReviewsLastDay))
+}
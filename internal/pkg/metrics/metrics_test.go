@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// value returns the value of the metric with the labels, -1 when it is missing
+func value(t *testing.T, m *Metrics, name string, labels map[string]string) float64 {
+	families, err := m.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			switch {
+			case metric.Counter != nil:
+				return metric.Counter.GetValue()
+			case metric.Gauge != nil:
+				return metric.Gauge.GetValue()
+			}
+		}
+	}
+	return -1
+}
+
+func TestKafkaMetrics(t *testing.T) {
+	m := New()
+	topic := map[string]string{"topic": "establishment.events"}
+
+	m.Published("establishment.events", nil)
+	m.Published("establishment.events", nil)
+	m.Published("establishment.events", errors.New("leader not available"))
+	assert.Equal(t, 2.0, value(t, m, "establishment_kafka_published_messages_total", topic))
+	assert.Equal(t, 1.0, value(t, m, "establishment_kafka_publish_errors_total", topic))
+
+	m.Consumed("booking.events", 3, 40, 51)
+	assert.Equal(t, 1.0, value(t, m, "establishment_kafka_consumed_messages_total", map[string]string{"topic": "booking.events"}))
+	assert.Equal(t, 10.0, value(t, m, "establishment_kafka_consumer_lag_messages", map[string]string{"topic": "booking.events", "partition": "3"}))
+
+	// the last message of the partition leaves no lag
+	m.Consumed("booking.events", 3, 50, 51)
+	assert.Equal(t, 0.0, value(t, m, "establishment_kafka_consumer_lag_messages", map[string]string{"topic": "booking.events", "partition": "3"}))
+
+	m.ConsumeFailed("booking.events", StageHandle)
+	assert.Equal(t, 1.0, value(t, m, "establishment_kafka_consume_errors_total", map[string]string{"topic": "booking.events", "stage": StageHandle}))
+}
+
+func TestSetStatistics(t *testing.T) {
+	m := New()
+	m.SetStatistics(&entity.Statistics{
+		Establishments: map[string]int64{entity.EstablishmentTypeHotel: 12, entity.EstablishmentTypeAttraction: 3},
+		ReviewsLastDay: 40,
+	})
+
+	assert.Equal(t, 12.0, value(t, m, "establishment_establishments", map[string]string{"type": entity.EstablishmentTypeHotel}))
+	// types without establishments are reported as none
+	assert.Equal(t, 0.0, value(t, m, "establishment_establishments", map[string]string{"type": entity.EstablishmentTypeRestaurant}))
+	assert.Equal(t, 40.0, value(t, m, "establishment_reviews_last_day", nil))
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	m.Published("establishment.events", nil)
+	m.Consumed("booking.events", 0, 1, 2)
+	m.ConsumeFailed("booking.events", StageFetch)
+	m.SetStatistics(&entity.Statistics{})
+	m.RegisterPool(nil)
+}
+
+func TestServer(t *testing.T) {
+	m := New()
+	m.Published("establishment.events", nil)
+
+	recorder := httptest.NewRecorder()
+	NewServer(":0", m).Handler.ServeHTTP(recorder, httptest.NewRequest("GET", Path, nil))
+	assert.Equal(t, 200, recorder.Code)
+
+	body, _ := io.ReadAll(recorder.Body)
+	assert.True(t, strings.Contains(string(body), `establishment_kafka_published_messages_total{topic="establishment.events"} 1`))
+	assert.True(t, strings.Contains(string(body), "go_goroutines"))
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where the metrics are served
+const Path = "/metrics"
+
+// NewServer serves the metrics of the registry on the address, e.g. ":9100"
+func NewServer(address string, m *Metrics) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry}))
+	return &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
@@ -8,19 +8,24 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 )
 
-// Initializes an OTLP exporter, and configures the corresponding trace
-func InitOTLPProvider(config *config.Config) (func() error, error) {
+// Initializes an OTLP exporter, and configures the corresponding trace,
+// the returned func flushes the spans and shuts the provider down
+func InitOTLPProvider(config *config.Config) (func(ctx context.Context) error, error) {
 	var (
 		ctx           = context.Background()
 		otelAgentAddr = fmt.Sprintf("%s%s", config.OTLPCollector.Host, config.OTLPCollector.Port)
 	)
 
+	sampler, err := NewSampler(config.OTLPCollector.Sampler, config.OTLPCollector.SamplerRatio)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := resource.New(ctx,
 		resource.WithFromEnv(),
 		resource.WithProcess(),
@@ -51,16 +56,16 @@ func InitOTLPProvider(config *config.Config) (func() error, error) {
 	// span processor to aggregate spans before export.
 	bsp := sdktrace.NewBatchSpanProcessor(traceExporter)
 	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sampler),
 		sdktrace.WithResource(res),
 		sdktrace.WithSpanProcessor(bsp),
 	)
 
 	// set global propagator to tracecontext (the default is no-op).
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	SetPropagator()
 	otel.SetTracerProvider(tracerProvider)
 
-	return func() error {
+	return func(ctx context.Context) error {
 		// Shutdown will flush any remaining spans and shut down the exporter.
 		if err := tracerProvider.Shutdown(ctx); err != nil {
 			return fmt.Errorf("otlp collector failed to shutdown TracerProvider: %w", err)
@@ -0,0 +1,27 @@
+package otlp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// keys of the trace ids in logs
+const (
+	LogKeyTraceId = "trace_id"
+	LogKeySpanId  = "span_id"
+)
+
+// LogFields returns the ids of the span of ctx as log fields, so a log line
+// can be found from its trace and back. There are none when ctx is not traced.
+func LogFields(ctx context.Context) []zap.Field {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String(LogKeyTraceId, spanContext.TraceID().String()),
+		zap.String(LogKeySpanId, spanContext.SpanID().String()),
+	}
+}
@@ -0,0 +1,29 @@
+package otlp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// SetPropagator configures the W3C trace context and baggage propagation of
+// the process, the default propagator of otel propagates nothing
+func SetPropagator() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Inject writes the trace context of ctx into the carrier, e.g. the headers
+// of a message, with the configured propagator
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract returns ctx with the remote trace context read from the carrier,
+// spans started with it belong to the trace of the sender
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,51 @@
+package otlp
+
+import (
+	"fmt"
+	"strconv"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// names of the samplers, the values of OTEL_TRACES_SAMPLER
+const (
+	SamplerAlwaysOn                = "always_on"
+	SamplerAlwaysOff               = "always_off"
+	SamplerTraceIdRatio            = "traceidratio"
+	SamplerParentBasedAlwaysOn     = "parentbased_always_on"
+	SamplerParentBasedAlwaysOff    = "parentbased_always_off"
+	SamplerParentBasedTraceIdRatio = "parentbased_traceidratio"
+)
+
+// NewSampler returns the sampler of the name, ratio is the fraction of the
+// traces the ratio samplers keep. Parent based samplers follow the decision
+// of the caller and apply the sampler to the traces started here.
+func NewSampler(name, ratio string) (sdktrace.Sampler, error) {
+	switch name {
+	case SamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case SamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case SamplerTraceIdRatio:
+		return ratioSampler(ratio)
+	case SamplerParentBasedAlwaysOn:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case SamplerParentBasedAlwaysOff:
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case SamplerParentBasedTraceIdRatio:
+		sampler, err := ratioSampler(ratio)
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ParentBased(sampler), nil
+	}
+	return nil, fmt.Errorf("error during parse otlp sampler %q: unknown sampler", name)
+}
+
+func ratioSampler(ratio string) (sdktrace.Sampler, error) {
+	fraction, err := strconv.ParseFloat(ratio, 64)
+	if err != nil || fraction < 0 || fraction > 1 {
+		return nil, fmt.Errorf("error during parse otlp sampler ratio %q: must be between 0 and 1", ratio)
+	}
+	return sdktrace.TraceIDRatioBased(fraction), nil
+}
@@ -0,0 +1,33 @@
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSampler(t *testing.T) {
+	for _, name := range []string{
+		SamplerAlwaysOn, SamplerAlwaysOff, SamplerTraceIdRatio,
+		SamplerParentBasedAlwaysOn, SamplerParentBasedAlwaysOff, SamplerParentBasedTraceIdRatio,
+	} {
+		sampler, err := NewSampler(name, "0.25")
+		assert.NoError(t, err, name)
+		assert.NotNil(t, sampler, name)
+	}
+
+	sampler, err := NewSampler(SamplerParentBasedTraceIdRatio, "0.5")
+	assert.NoError(t, err)
+	assert.Contains(t, sampler.Description(), "TraceIDRatioBased{0.5}")
+
+	_, err = NewSampler("sometimes", "1")
+	assert.Error(t, err)
+	_, err = NewSampler(SamplerTraceIdRatio, "2")
+	assert.Error(t, err)
+	_, err = NewSampler(SamplerParentBasedTraceIdRatio, "half")
+	assert.Error(t, err)
+
+	// the ratio does not matter to the other samplers
+	_, err = NewSampler(SamplerAlwaysOn, "half")
+	assert.NoError(t, err)
+}
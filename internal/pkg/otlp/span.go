@@ -75,28 +75,3 @@ func (s *span) Error(err error) {
 		s.span.SetStatus(codes.Error, err.Error())
 	}
 }
-
-// RestoreTraceContext function forms context and span from trace_id and span_id
-//
-// span_id and trace_id should both be strings in hex format.
-//
-// Although this function returns both context and span it is required to call Start method to start tracing
-// WARNING: if error IS NOT NIL, then context and span are BOTH NIL.
-func RestoreTraceContext(traceIdStr, spanIdStr string) (context.Context, trace.Span, error) {
-	spanId, err := trace.SpanIDFromHex(spanIdStr)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	traceId, err := trace.TraceIDFromHex(traceIdStr)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	ctx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
-		TraceID: traceId,
-		SpanID:  spanId,
-	}))
-
-	return ctx, trace.SpanFromContext(ctx), nil
-}
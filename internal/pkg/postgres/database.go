@@ -79,16 +79,26 @@ func (p *PostgresDB) Close() {
 	p.Pool.Close()
 }
 
+// Error translates pgx errors into domain errors, other errors are returned as is
 func (p *PostgresDB) Error(err error) error {
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		switch pgErr.Code {
-		case "23505":
+		case "23505": // unique_violation
 			return entity.ErrorConflict
+		case "23503": // foreign_key_violation
+			return entity.NewErrNotFound("referenced object")
+		case "22P02", "23514": // invalid_text_representation, check_violation
+			errValidation := entity.NewErrValidation()
+			errValidation.Err = errors.New("invalid value")
+			if pgErr.ColumnName != "" {
+				errValidation.Errors[pgErr.ColumnName] = "invalid value"
+			}
+			return errValidation
 		}
 	}
 
-	if err == pgx.ErrNoRows {
+	if errors.Is(err, pgx.ErrNoRows) {
 		return entity.ErrorNotFound
 	}
 	return err
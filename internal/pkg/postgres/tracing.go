@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "postgres"
+
+// startStatement starts the span of a statement as a child of the span of
+// ctx, it is named after the statement, e.g. "SELECT hotel"
+func startStatement(ctx context.Context, sql string) (context.Context, trace.Span) {
+	operation, table := statementName(sql)
+
+	attributes := []attribute.KeyValue{
+		semconv.DBSystemPostgreSQL,
+		semconv.DBStatementKey.String(sql),
+	}
+	name := operation
+	if operation != "" {
+		attributes = append(attributes, semconv.DBOperationKey.String(operation))
+	}
+	if table != "" {
+		attributes = append(attributes, semconv.DBSQLTableKey.String(table))
+		name += " " + table
+	}
+	if name == "" {
+		name = "QUERY"
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// endStatement ends the span, a missing row is an answer and not an error
+func endStatement(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// statementName returns the SQL keyword of the statement and the table it
+// reads or writes, the table is empty when it is not found
+func statementName(sql string) (operation, table string) {
+	words := strings.Fields(sql)
+	if len(words) == 0 {
+		return "", ""
+	}
+
+	operation = strings.ToUpper(words[0])
+	switch operation {
+	case "SELECT", "DELETE":
+		table = wordAfter(words, "FROM")
+	case "INSERT":
+		table = wordAfter(words, "INTO")
+	case "UPDATE":
+		if len(words) > 1 {
+			table = words[1]
+		}
+	}
+
+	// subqueries have no table of their own
+	if strings.HasPrefix(table, "(") {
+		return operation, ""
+	}
+	return operation, strings.Trim(table, `"(),;`)
+}
+
+func wordAfter(words []string, keyword string) string {
+	for i := 0; i < len(words)-1; i++ {
+		if strings.EqualFold(words[i], keyword) {
+			return words[i+1]
+		}
+	}
+	return ""
+}
+
+// tracedRows ends the span of the query when the rows are closed
+type tracedRows struct {
+	pgx.Rows
+	span trace.Span
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if r.span.IsRecording() {
+		r.span.SetAttributes(attribute.Int64("db.rows", r.Rows.CommandTag().RowsAffected()))
+	}
+	endStatement(r.span, r.Rows.Err())
+}
+
+// tracedRow ends the span of the query when the row is scanned
+type tracedRow struct {
+	pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	endStatement(r.span, err)
+	return err
+}
+
+// tracedTx traces the statements of a transaction started with Begin
+type tracedTx struct {
+	pgx.Tx
+}
+
+func (t *tracedTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := t.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx}, nil
+}
+
+func (t *tracedTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return exec(ctx, t.Tx, sql, args...)
+}
+
+func (t *tracedTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return query(ctx, t.Tx, sql, args...)
+}
+
+func (t *tracedTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return queryRow(ctx, t.Tx, sql, args...)
+}
+
+// querier is what the pool and transactions have in common
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+func exec(ctx context.Context, q querier, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := startStatement(ctx, sql)
+	tag, err := q.Exec(ctx, sql, args...)
+	if err == nil && span.IsRecording() {
+		span.SetAttributes(attribute.Int64("db.rows", tag.RowsAffected()))
+	}
+	endStatement(span, err)
+	return tag, err
+}
+
+func query(ctx context.Context, q querier, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := startStatement(ctx, sql)
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		endStatement(span, err)
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func queryRow(ctx context.Context, q querier, sql string, args ...interface{}) pgx.Row {
+	ctx, span := startStatement(ctx, sql)
+	return &tracedRow{Row: q.QueryRow(ctx, sql, args...), span: span}
+}
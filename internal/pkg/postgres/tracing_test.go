@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStatementName(t *testing.T) {
+	tests := []struct {
+		sql       string
+		operation string
+		table     string
+	}{
+		{"SELECT hotel_id, hotel_name FROM hotel WHERE hotel_id = $1", "SELECT", "hotel"},
+		{"select count(*) from \"review\" where deleted_at is null", "SELECT", "review"},
+		{"INSERT INTO outbox (id,topic) VALUES ($1,$2)", "INSERT", "outbox"},
+		{"UPDATE restaurant SET restaurant_name = $1 WHERE restaurant_id = $2", "UPDATE", "restaurant"},
+		{"DELETE FROM idempotency WHERE expires_at < $1", "DELETE", "idempotency"},
+		{"SELECT id FROM (SELECT id FROM hotel) AS h", "SELECT", ""},
+		{"WITH counted AS (SELECT 1) SELECT * FROM counted", "WITH", ""},
+		{"  ", "", ""},
+	}
+	for _, tt := range tests {
+		operation, table := statementName(tt.sql)
+		assert.Equal(t, tt.operation, operation, tt.sql)
+		assert.Equal(t, tt.table, table, tt.sql)
+	}
+}
+
+// fakeQuerier answers without a database
+type fakeQuerier struct {
+	err error
+}
+
+func (f fakeQuerier) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag("UPDATE 2"), f.err
+}
+
+func (f fakeQuerier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, f.err
+}
+
+func (f fakeQuerier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return fakeRow{err: f.err}
+}
+
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	return r.err
+}
+
+func TestStatementSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "GetHotel")
+
+	_, err := exec(ctx, fakeQuerier{}, "UPDATE hotel SET rating = $1")
+	assert.NoError(t, err)
+
+	// a missing row is not an error of the statement
+	assert.ErrorIs(t, queryRow(ctx, fakeQuerier{err: pgx.ErrNoRows}, "SELECT * FROM hotel WHERE hotel_id = $1").Scan(), pgx.ErrNoRows)
+
+	_, err = query(ctx, fakeQuerier{err: errors.New("connection reset")}, "SELECT * FROM review")
+	assert.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 4) {
+		return
+	}
+	assert.Equal(t, "UPDATE hotel", spans[0].Name())
+	assert.Equal(t, "SELECT hotel", spans[1].Name())
+	assert.Equal(t, "SELECT review", spans[2].Name())
+	for _, span := range spans[:3] {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+	}
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKeyTx struct{}
+
+// WithinTransaction runs fn in a transaction, queries made through the
+// context passed to fn join it. The transaction is committed when fn returns
+// nil and rolled back otherwise. Nested calls join the outer transaction.
+func (p *PostgresDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	// the statements of the transaction are children of its span
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "TRANSACTION", trace.WithSpanKind(trace.SpanKindClient))
+
+	err := p.withinTransaction(ctx, fn)
+	endStatement(span, err)
+	return err
+}
+
+func (p *PostgresDB) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", p.Error(err))
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, ctxKeyTx{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", p.Error(err))
+	}
+	return nil
+}
+
+func txFromContext(ctx context.Context) pgx.Tx {
+	if tx, ok := ctx.Value(ctxKeyTx{}).(pgx.Tx); ok {
+		return tx
+	}
+	return nil
+}
+
+// Exec runs in the transaction of the context if there is one
+func (p *PostgresDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return exec(ctx, tx, sql, args...)
+	}
+	return exec(ctx, p.Pool, sql, args...)
+}
+
+// Query runs in the transaction of the context if there is one, the rows
+// must be closed before the next query of the transaction
+func (p *PostgresDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return query(ctx, tx, sql, args...)
+	}
+	return query(ctx, p.Pool, sql, args...)
+}
+
+func (p *PostgresDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if tx := txFromContext(ctx); tx != nil {
+		return queryRow(ctx, tx, sql, args...)
+	}
+	return queryRow(ctx, p.Pool, sql, args...)
+}
+
+// Begin starts a savepoint within the transaction of the context if there is
+// one and a transaction otherwise
+func (p *PostgresDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	var (
+		tx  pgx.Tx
+		err error
+	)
+	if outer := txFromContext(ctx); outer != nil {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = p.Pool.Begin(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx}, nil
+}
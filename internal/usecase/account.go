@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	accountServiceName = "accountService"
+	spanNameAccount    = "accountUsecase"
+)
+
+type Account interface {
+	// HandleUserEvent applies an event of the user service once, events
+	// handled before are skipped
+	HandleUserEvent(ctx context.Context, event *entity.UserEvent) error
+}
+
+// AccountService keeps the data of this service consistent with the
+// accounts of the user service
+type AccountService struct {
+	BaseUseCase
+	inboxRepo      repository.Inbox
+	ownershipRepo  repository.Ownership
+	hotelRepo      repository.Hotel
+	restaurantRepo repository.Restaurant
+	attractionRepo repository.Attraction
+	reviewRepo     repository.Review
+	favouriteRepo  repository.Favourite
+	auditor        auditor
+	publisher      publisher
+	transactor     repository.Transactor
+	ctxTimeout     time.Duration
+}
+
+func NewAccountService(ctxTimeout time.Duration, inboxRepo repository.Inbox, ownershipRepo repository.Ownership, hotelRepo repository.Hotel, restaurantRepo repository.Restaurant, attractionRepo repository.Attraction, reviewRepo repository.Review, favouriteRepo repository.Favourite, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor) AccountService {
+	return AccountService{
+		ctxTimeout:     ctxTimeout,
+		inboxRepo:      inboxRepo,
+		ownershipRepo:  ownershipRepo,
+		hotelRepo:      hotelRepo,
+		restaurantRepo: restaurantRepo,
+		attractionRepo: attractionRepo,
+		reviewRepo:     reviewRepo,
+		favouriteRepo:  favouriteRepo,
+		auditor:        auditor{repo: auditRepo},
+		publisher:      publisher{repo: outboxRepo},
+		transactor:     transactor,
+	}
+}
+
+// HandleUserEvent deletes the establishments of a removed account, anonymises
+// its reviews and drops its favourites. The event is marked processed in the
+// same transaction, a redelivered event changes nothing.
+func (a AccountService) HandleUserEvent(ctx context.Context, event *entity.UserEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, accountServiceName, spanNameAccount+"HandleUserEvent")
+	defer span.End()
+
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	// other events of the user service do not concern establishments
+	if !event.RemovesAccount() {
+		return nil
+	}
+
+	// the audit log shows the event as the cause of the changes
+	ctx = context.WithValue(ctx, app.CtxKeyMethod, event.Type)
+
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		first, err := a.inboxRepo.MarkEventProcessed(ctx, event.EventId, event.Type, time.Now().UTC())
+		if err != nil || !first {
+			return err
+		}
+
+		if err := a.deleteEstablishments(ctx, event.UserId); err != nil {
+			return err
+		}
+
+		if err := a.anonymiseReviews(ctx, event.UserId); err != nil {
+			return err
+		}
+
+		return a.dropFavourites(ctx, event.UserId)
+	})
+}
+
+func (a AccountService) deleteEstablishments(ctx context.Context, owner_id string) error {
+	establishments, err := a.ownershipRepo.ListEstablishmentsByOwner(ctx, owner_id)
+	if err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	a.beforeRequest(nil, nil, nil, &deletedAt)
+
+	for _, establishment := range establishments {
+		var (
+			id      = establishment.EstablishmentId
+			fields  map[string]interface{}
+			version int64
+		)
+
+		switch establishment.EstablishmentType {
+		case entity.EstablishmentTypeHotel:
+			hotel, err := a.hotelRepo.GetHotel(ctx, id)
+			if err != nil {
+				return err
+			}
+			fields, version = hotel.AuditFields(), hotel.Version
+			if err := a.hotelRepo.DeleteHotel(ctx, id, version, deletedAt); err != nil {
+				return err
+			}
+		case entity.EstablishmentTypeRestaurant:
+			restaurant, err := a.restaurantRepo.GetRestaurant(ctx, id)
+			if err != nil {
+				return err
+			}
+			fields, version = restaurant.AuditFields(), restaurant.Version
+			if err := a.restaurantRepo.DeleteRestaurant(ctx, id, version, deletedAt); err != nil {
+				return err
+			}
+		case entity.EstablishmentTypeAttraction:
+			attraction, err := a.attractionRepo.GetAttraction(ctx, id)
+			if err != nil {
+				return err
+			}
+			fields, version = attraction.AuditFields(), attraction.Version
+			if err := a.attractionRepo.DeleteAttraction(ctx, id, version, deletedAt); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown establishment type %s of %s", establishment.EstablishmentType, id)
+		}
+
+		if err := a.auditor.record(ctx, entity.AuditActionDelete, establishment.EstablishmentType, id, id, fields, nil); err != nil {
+			return err
+		}
+
+		if err := a.publisher.publish(ctx, entity.EventDeleted, establishment.EstablishmentType, id, id, version, fields, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// anonymiseReviews keeps the reviews of the user, their author is replaced
+func (a AccountService) anonymiseReviews(ctx context.Context, user_id string) error {
+	reviews, err := a.reviewRepo.ListReviewsByUserId(ctx, user_id)
+	if err != nil || len(reviews) == 0 {
+		return err
+	}
+
+	if _, err := a.reviewRepo.AnonymiseReviews(ctx, user_id); err != nil {
+		return err
+	}
+
+	for _, review := range reviews {
+		before := review.AuditFields()
+		review.UserId = entity.AnonymousUserId
+		after := review.AuditFields()
+
+		if err := a.auditor.record(ctx, entity.AuditActionUpdate, entity.EntityTypeReview, review.ReviewId, review.EstablishmentId, before, after); err != nil {
+			return err
+		}
+
+		if err := a.publisher.publish(ctx, entity.EventUpdated, entity.EntityTypeReview, review.ReviewId, review.EstablishmentId, 0, before, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a AccountService) dropFavourites(ctx context.Context, user_id string) error {
+	favourites, err := a.favouriteRepo.ListFavouritesByUserId(ctx, user_id)
+	if err != nil {
+		return err
+	}
+
+	for _, favourite := range favourites {
+		if err := a.favouriteRepo.RemoveFromFavourites(ctx, favourite.FavouriteId); err != nil {
+			return err
+		}
+
+		if err := a.auditor.record(ctx, entity.AuditActionDelete, entity.EntityTypeFavourite, favourite.FavouriteId, favourite.EstablishmentId, favourite.AuditFields(), nil); err != nil {
+			return err
+		}
+
+		if err := a.publisher.publish(ctx, entity.EventDeleted, entity.EntityTypeFavourite, favourite.FavouriteId, favourite.EstablishmentId, 0, favourite.AuditFields(), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
 	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
 	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"context"
 	"time"
@@ -16,23 +18,36 @@ const (
 type Attraction interface {
 	CreateAttraction(ctx context.Context, attracation *entity.Attraction) (*entity.Attraction, error)
 	GetAttraction(ctx context.Context, attraction_id string) (*entity.Attraction, error)
-	ListAttractions(ctx context.Context, page, limit int64) ([]*entity.Attraction, uint64, error)
+	ListAttractions(ctx context.Context, page, limit int64, filter *entity.Filter) ([]*entity.Attraction, uint64, error)
 	UpdateAttraction(ctx context.Context, attracation *entity.Attraction) (*entity.Attraction, error)
 	DeleteAttraction(ctx context.Context, attraction_id string) error
-	ListAttractionsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Attraction, int64, error)
-	FindAttractionsByName(ctx context.Context, name string) ([]*entity.Attraction, uint64, error)
+	ListAttractionsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Attraction, int64, error)
+	FindAttractionsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Attraction, uint64, error)
 }
 
 type AttractionService struct {
 	BaseUseCase
 	repo       repository.Attraction
+	localizer  localizer
+	pricer     pricer
+	guard      guard
+	directory  directory
+	auditor    auditor
+	publisher  publisher
+	transactor repository.Transactor
 	ctxTimeout time.Duration
 }
 
-func NewAttractionService(ctxTimeout time.Duration, repo repository.Attraction) AttractionService {
+func NewAttractionService(ctxTimeout time.Duration, repo repository.Attraction, translationRepo repository.Translation, priceRepo repository.Price, rateRepo repository.CurrencyRate, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor, users grpc_service_clients.UserService) AttractionService {
 	return AttractionService{
 		ctxTimeout: ctxTimeout,
 		repo:       repo,
+		localizer:  localizer{repo: translationRepo},
+		pricer:     pricer{repo: priceRepo, rateRepo: rateRepo},
+		auditor:    auditor{repo: auditRepo},
+		publisher:  publisher{repo: outboxRepo},
+		directory:  directory{users: users, publisher: publisher{repo: outboxRepo}},
+		transactor: transactor,
 	}
 }
 
@@ -43,7 +58,42 @@ func (a AttractionService) CreateAttraction(ctx context.Context, attracation *en
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"Create")
 	defer span.End()
 
-	return a.repo.CreateAttraction(ctx, attracation)
+	a.beforeCreateEstablishment(ctx, entity.EstablishmentTypeAttraction, &attracation.AttractionId, &attracation.CreatedAt, &attracation.UpdatedAt, &attracation.Location, attracation.Images)
+
+	if err := attracation.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := a.guard.create(ctx, attracation.OwnerId); err != nil {
+		return nil, err
+	}
+
+	if err := a.directory.owner(ctx, attracation.OwnerId); err != nil {
+		return nil, err
+	}
+
+	var created *entity.Attraction
+	if err := a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = a.repo.CreateAttraction(ctx, attracation); err != nil {
+			return err
+		}
+
+		if err := a.auditor.record(ctx, entity.AuditActionCreate, entity.EstablishmentTypeAttraction, created.AttractionId, created.AttractionId, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		if err := a.publisher.publish(ctx, entity.EventCreated, entity.EstablishmentTypeAttraction, created.AttractionId, created.AttractionId, created.Version, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		// the outbox links the owner once the establishment is committed
+		return a.directory.link(ctx, created.OwnerId, created.AttractionId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (a AttractionService) GetAttraction(ctx context.Context, attraction_id string) (*entity.Attraction, error) {
@@ -53,17 +103,55 @@ func (a AttractionService) GetAttraction(ctx context.Context, attraction_id stri
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"Get")
 	defer span.End()
 
-	return a.repo.GetAttraction(ctx, attraction_id)
+	if err := entity.ValidateId("attraction_id", attraction_id); err != nil {
+		return nil, err
+	}
+
+	attraction, err := a.repo.GetAttraction(ctx, attraction_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.localizer.attractions(ctx, attraction); err != nil {
+		return nil, err
+	}
+
+	if err := a.pricer.attractions(ctx, attraction); err != nil {
+		return nil, err
+	}
+
+	return attraction, nil
 }
 
-func (a AttractionService) ListAttractions(ctx context.Context, offset, limit int64) ([]*entity.Attraction, uint64, error) {
+func (a AttractionService) ListAttractions(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Attraction, uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"List")
 	defer span.End()
 
-	return a.repo.ListAttractions(ctx, offset, limit)
+	if err := entity.ValidatePage(offset, limit); err != nil {
+		return nil, 0, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	attractions, count, err := a.repo.ListAttractions(ctx, offset, limit, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := a.localizer.attractions(ctx, attractions...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := a.pricer.attractions(ctx, attractions...); err != nil {
+		return nil, 0, err
+	}
+
+	return attractions, count, nil
 }
 
 func (a AttractionService) UpdateAttraction(ctx context.Context, attracation *entity.Attraction) (*entity.Attraction, error) {
@@ -73,7 +161,60 @@ func (a AttractionService) UpdateAttraction(ctx context.Context, attracation *en
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"Update")
 	defer span.End()
 
-	return a.repo.UpdateAttraction(ctx, attracation)
+	if err := entity.ValidateId("attraction_id", attracation.AttractionId); err != nil {
+		return nil, err
+	}
+
+	version, ok := app.GetVersionFromContext(ctx)
+	if !ok {
+		return nil, entity.NewErrNoRequiredParameter("version")
+	}
+
+	existing, err := a.repo.GetAttraction(ctx, attracation.AttractionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.guard.owner(ctx, existing.OwnerId); err != nil {
+		return nil, err
+	}
+
+	before := existing.AuditFields()
+
+	// only the fields named by the mask change
+	if paths := app.GetUpdateMaskFromContext(ctx); paths != nil {
+		if err := existing.ApplyFieldMask(attracation, paths); err != nil {
+			return nil, err
+		}
+		attracation = existing
+	}
+	attracation.OwnerId = existing.OwnerId
+	attracation.Version = version
+
+	a.beforeRequest(nil, nil, &attracation.UpdatedAt, nil)
+	linkEstablishment(attracation.AttractionId, &attracation.Location, attracation.Images)
+
+	if err := attracation.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.Attraction
+	if err := a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = a.repo.UpdateAttraction(ctx, attracation); err != nil {
+			return err
+		}
+
+		if err := a.auditor.record(ctx, entity.AuditActionUpdate, entity.EstablishmentTypeAttraction, updated.AttractionId, updated.AttractionId, before, updated.AuditFields()); err != nil {
+			return err
+		}
+
+		return a.publisher.publish(ctx, entity.EventUpdated, entity.EstablishmentTypeAttraction, updated.AttractionId, updated.AttractionId, updated.Version, before, updated.AuditFields())
+	}); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (a AttractionService) DeleteAttraction(ctx context.Context, attraction_id string) error {
@@ -83,25 +224,94 @@ func (a AttractionService) DeleteAttraction(ctx context.Context, attraction_id s
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"Delete")
 	defer span.End()
 
-	return a.repo.DeleteAttraction(ctx, attraction_id)
+	if err := entity.ValidateId("attraction_id", attraction_id); err != nil {
+		return err
+	}
+
+	version, ok := app.GetVersionFromContext(ctx)
+	if !ok {
+		return entity.NewErrNoRequiredParameter("version")
+	}
+
+	existing, err := a.repo.GetAttraction(ctx, attraction_id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.guard.owner(ctx, existing.OwnerId); err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	a.beforeRequest(nil, nil, nil, &deletedAt)
+
+	return a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := a.repo.DeleteAttraction(ctx, attraction_id, version, deletedAt); err != nil {
+			return err
+		}
+
+		if err := a.auditor.record(ctx, entity.AuditActionDelete, entity.EstablishmentTypeAttraction, attraction_id, attraction_id, existing.AuditFields(), nil); err != nil {
+			return err
+		}
+
+		return a.publisher.publish(ctx, entity.EventDeleted, entity.EstablishmentTypeAttraction, attraction_id, attraction_id, existing.Version, existing.AuditFields(), nil)
+	})
 }
 
-func (a AttractionService) ListAttractionsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Attraction, int64, error) {
+func (a AttractionService) ListAttractionsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Attraction, int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"ListL")
 	defer span.End()
 
-	return a.repo.ListAttractionsByLocation(ctx, offset, limit, country, city, state_province)
+	if err := entity.ValidateLocationPage(offset, limit, country, city, state_province); err != nil {
+		return nil, 0, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	attractions, count, err := a.repo.ListAttractionsByLocation(ctx, offset, limit, country, city, state_province, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := a.localizer.attractions(ctx, attractions...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := a.pricer.attractions(ctx, attractions...); err != nil {
+		return nil, 0, err
+	}
+
+	return attractions, count, nil
 }
 
-func (a AttractionService) FindAttractionsByName(ctx context.Context, name string) ([]*entity.Attraction, uint64, error) {
+func (a AttractionService) FindAttractionsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Attraction, uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, attractionServiceName, spanNameAttraction+"ListL")
 	defer span.End()
 
-	return a.repo.FindAttractionsByName(ctx, name)
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	attractions, count, err := a.repo.FindAttractionsByName(ctx, name, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := a.localizer.attractions(ctx, attractions...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := a.pricer.attractions(ctx, attractions...); err != nil {
+		return nil, 0, err
+	}
+
+	return attractions, count, nil
 }
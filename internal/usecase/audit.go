@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	auditServiceName = "auditService"
+	spanNameAudit    = "auditUsecase"
+)
+
+type Audit interface {
+	ListAuditEvents(ctx context.Context, filter *entity.AuditFilter, offset, limit int64) ([]*entity.AuditEvent, uint64, error)
+}
+
+type AuditService struct {
+	BaseUseCase
+	repo       repository.Audit
+	guard      guard
+	ctxTimeout time.Duration
+}
+
+func NewAuditService(ctxTimeout time.Duration, repo repository.Audit) AuditService {
+	return AuditService{
+		ctxTimeout: ctxTimeout,
+		repo:       repo,
+		guard:      guard{},
+	}
+}
+
+// ListAuditEvents lets admins see who changed what and when
+func (a AuditService) ListAuditEvents(ctx context.Context, filter *entity.AuditFilter, offset, limit int64) ([]*entity.AuditEvent, uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, auditServiceName, spanNameAudit+"List")
+	defer span.End()
+
+	if err := a.guard.admin(ctx, "list audit events"); err != nil {
+		return nil, 0, err
+	}
+
+	if err := entity.ValidatePage(offset, limit); err != nil {
+		return nil, 0, err
+	}
+
+	if filter == nil {
+		filter = &entity.AuditFilter{}
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	return a.repo.ListAuditEvents(ctx, filter, uint64(offset), uint64(limit))
+}
+
+// auditor appends the changes made by a request to the audit log
+type auditor struct {
+	repo repository.Audit
+}
+
+// record stores the fields changed between the audit fields of an object
+// before and after the request, before is nil for a create and after for a delete
+func (a auditor) record(ctx context.Context, action, entity_type, entity_id, establishment_id string, before, after map[string]interface{}) error {
+	event := &entity.AuditEvent{
+		EventId:         uuid.New().String(),
+		EstablishmentId: establishment_id,
+		EntityType:      entity_type,
+		EntityId:        entity_id,
+		Action:          action,
+		Method:          app.GetMethodFromContext(ctx),
+		Changes:         entity.Diff(before, after),
+		CreatedAt:       time.Now().UTC(),
+	}
+	if caller := app.GetCallerFromContext(ctx); caller != nil {
+		event.ActorId = caller.UserId
+	}
+
+	if err := a.repo.CreateAuditEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"time"
+)
+
+const (
+	bookingSummaryServiceName = "bookingSummaryService"
+	spanNameBookingSummary    = "bookingSummaryUsecase"
+)
+
+type BookingSummary interface {
+	// GetEstablishmentBookingSummary counts the upcoming, current and
+	// canceled bookings of an establishment for the dashboard of its owner.
+	// The booking service lists bookings by user, so only the bookings of
+	// the first grpc_service_clients.MaxBookingUsers users who booked are
+	// counted, and all of them are listed within the context timeout.
+	GetEstablishmentBookingSummary(ctx context.Context, establishment_id string) (*entity.BookingSummary, error)
+}
+
+type BookingSummaryService struct {
+	BaseUseCase
+	bookings   grpc_service_clients.BookingService
+	guard      guard
+	ctxTimeout time.Duration
+}
+
+func NewBookingSummaryService(ctxTimeout time.Duration, ownershipRepo repository.Ownership, bookings grpc_service_clients.BookingService) BookingSummaryService {
+	return BookingSummaryService{
+		ctxTimeout: ctxTimeout,
+		bookings:   bookings,
+		guard:      guard{repo: ownershipRepo},
+	}
+}
+
+func (b BookingSummaryService) GetEstablishmentBookingSummary(ctx context.Context, establishment_id string) (*entity.BookingSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, b.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, bookingSummaryServiceName, spanNameBookingSummary+"Get")
+	defer span.End()
+
+	if err := entity.ValidateId("establishment_id", establishment_id); err != nil {
+		return nil, err
+	}
+
+	// only the owner or an admin sees the bookings
+	establishment, err := b.guard.establishment(ctx, establishment_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.bookings == nil {
+		return nil, entity.NewErrUnavailable("booking service")
+	}
+
+	bookings, err := b.bookings.ListBookings(ctx, establishment)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.SummariseBookings(establishment_id, bookings, time.Now().UTC()), nil
+}
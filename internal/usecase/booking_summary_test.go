@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOwnership counts the lookups of the establishments it knows
+type memoryOwnership struct {
+	establishments map[string]*entity.EstablishmentRef
+	lookups        int
+}
+
+func (m *memoryOwnership) GetOwnerId(ctx context.Context, entity_id string) (string, error) {
+	establishment, err := m.GetEstablishmentRef(ctx, entity_id)
+	if err != nil {
+		return "", err
+	}
+	return establishment.OwnerId, nil
+}
+
+func (m *memoryOwnership) GetEstablishmentRef(ctx context.Context, establishment_id string) (*entity.EstablishmentRef, error) {
+	m.lookups++
+	establishment, ok := m.establishments[establishment_id]
+	if !ok {
+		return nil, entity.NewErrNotFound("establishment")
+	}
+	return establishment, nil
+}
+
+func (m *memoryOwnership) ListEstablishmentsByOwner(ctx context.Context, owner_id string) ([]*entity.EstablishmentRef, error) {
+	return nil, nil
+}
+
+type bookingServiceStub []*entity.Booking
+
+func (m bookingServiceStub) ListBookings(ctx context.Context, establishment *entity.EstablishmentRef) ([]*entity.Booking, error) {
+	return m, nil
+}
+
+func TestBookingSummaryGuard(t *testing.T) {
+	hotel_id, owner_id := uuid.New().String(), uuid.New().String()
+	repo := &memoryOwnership{establishments: map[string]*entity.EstablishmentRef{
+		hotel_id: {EstablishmentType: entity.EstablishmentTypeHotel, EstablishmentId: hotel_id, OwnerId: owner_id},
+	}}
+	now := time.Now().UTC()
+	summaries := NewBookingSummaryService(time.Second, repo, bookingServiceStub{
+		{BookingId: "b1", EstablishmentId: hotel_id, ArriveAt: now.Add(time.Hour), LeaveAt: now.Add(2 * time.Hour), Guests: 2},
+	})
+
+	as := func(user_id, role string) context.Context {
+		return context.WithValue(context.Background(), app.CtxKeyCaller, &entity.Caller{UserId: user_id, Role: role})
+	}
+
+	// an anonymous caller learns nothing about the establishment
+	_, err := summaries.GetEstablishmentBookingSummary(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, entity.ErrorUnauthenticated)
+	assert.Zero(t, repo.lookups)
+
+	_, err = summaries.GetEstablishmentBookingSummary(as(uuid.New().String(), entity.RoleOwner), hotel_id)
+	var denied *entity.ErrPermissionDenied
+	assert.ErrorAs(t, err, &denied)
+
+	// the owner is found with a single lookup
+	repo.lookups = 0
+	summary, err := summaries.GetEstablishmentBookingSummary(as(owner_id, entity.RoleOwner), hotel_id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), summary.Upcoming)
+		assert.Equal(t, int64(2), summary.UpcomingGuests)
+	}
+	assert.Equal(t, 1, repo.lookups)
+
+	_, err = summaries.GetEstablishmentBookingSummary(as(uuid.New().String(), entity.RoleAdmin), hotel_id)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"time"
+)
+
+const (
+	categoryServiceName = "categoryService"
+	spanNameCategory    = "categoryUsecase"
+)
+
+type Category interface {
+	ListCategories(ctx context.Context, establishment_type string) ([]*entity.Category, error)
+	ListEstablishmentCategories(ctx context.Context, establishment_id string) ([]*entity.Category, error)
+}
+
+type CategoryService struct {
+	BaseUseCase
+	repo       repository.Category
+	ctxTimeout time.Duration
+}
+
+func NewCategoryService(ctxTimeout time.Duration, repo repository.Category) CategoryService {
+	return CategoryService{
+		ctxTimeout: ctxTimeout,
+		repo:       repo,
+	}
+}
+
+func (c CategoryService) ListCategories(ctx context.Context, establishment_type string) ([]*entity.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, categoryServiceName, spanNameCategory+"List")
+	defer span.End()
+
+	return c.repo.ListCategories(ctx, establishment_type)
+}
+
+func (c CategoryService) ListEstablishmentCategories(ctx context.Context, establishment_id string) ([]*entity.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, categoryServiceName, spanNameCategory+"ListE")
+	defer span.End()
+
+	return c.repo.ListEstablishmentCategories(ctx, establishment_id)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"errors"
+	"sync"
+)
+
+// directory checks owners and describes reviewers with the user service,
+// nothing is checked when the user service is not configured
+type directory struct {
+	users     grpc_service_clients.UserService
+	publisher publisher
+}
+
+// owner makes sure the owner of a new establishment is a user with the owner role
+func (d directory) owner(ctx context.Context, owner_id string) error {
+	if d.users == nil {
+		return nil
+	}
+
+	user, err := d.users.GetUser(ctx, owner_id)
+	var errNotFound *entity.ErrNotFound
+	if errors.As(err, &errNotFound) {
+		return entity.Validate("invalid owner", func(violations map[string]string) {
+			violations["owner_id"] = "must be an existing user"
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Role != entity.RoleOwner {
+		return entity.Validate("invalid owner", func(violations map[string]string) {
+			violations["owner_id"] = "must be a user with the " + entity.RoleOwner + " role"
+		})
+	}
+	return nil
+}
+
+// link registers the establishment with its owner in the user service. The
+// link goes to the outbox in the transaction of the establishment, so it is
+// made only after the commit and retried until the user service takes it.
+func (d directory) link(ctx context.Context, owner_id, establishment_id string) error {
+	if d.users == nil {
+		return nil
+	}
+	return d.publisher.link(ctx, owner_id, establishment_id)
+}
+
+// reviewers adds the name and the avatar of the authors to the reviews, the
+// reviews are returned without them when the user service fails. Every
+// author is asked once, by at most grpc_service_clients.MaxConcurrentCalls
+// calls at a time.
+func (d directory) reviewers(ctx context.Context, reviews ...*entity.Review) {
+	if d.users == nil {
+		return
+	}
+
+	ctx, span := otlp.Start(ctx, reviewServiceName, spanNameReview+"Reviewers")
+	defer span.End()
+
+	users := make(map[string]*entity.User)
+	var user_ids []string
+	for _, review := range reviews {
+		if _, seen := users[review.UserId]; !seen && review.UserId != entity.AnonymousUserId {
+			users[review.UserId] = nil
+			user_ids = append(user_ids, review.UserId)
+		}
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		next = make(chan string)
+	)
+	for i := 0; i < grpc_service_clients.MaxConcurrentCalls && i < len(user_ids); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for user_id := range next {
+				user, err := d.users.GetUser(ctx, user_id)
+
+				mu.Lock()
+				if err != nil {
+					span.Error(err)
+				}
+				users[user_id] = user
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, user_id := range user_ids {
+		next <- user_id
+	}
+	close(next)
+	wg.Wait()
+
+	for _, review := range reviews {
+		if user := users[review.UserId]; user != nil {
+			review.UserName = user.FullName
+			review.UserAvatar = user.ProfileImg
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryUsers serves users from memory and remembers the links, it counts
+// the calls and the most calls running at once
+type memoryUsers struct {
+	mu          sync.Mutex
+	users       map[string]*entity.User
+	links       map[string]string
+	calls       int
+	running     int
+	maxRunning  int
+	callLatency time.Duration
+}
+
+func (m *memoryUsers) GetUser(ctx context.Context, user_id string) (*entity.User, error) {
+	m.mu.Lock()
+	m.calls++
+	m.running++
+	if m.running > m.maxRunning {
+		m.maxRunning = m.running
+	}
+	m.mu.Unlock()
+
+	time.Sleep(m.callLatency)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.running--
+	user, ok := m.users[user_id]
+	if !ok {
+		return nil, entity.NewErrNotFound("user")
+	}
+	return user, nil
+}
+
+func (m *memoryUsers) LinkEstablishment(ctx context.Context, user_id, establishment_id string) error {
+	m.links[establishment_id] = user_id
+	return nil
+}
+
+func TestDirectory(t *testing.T) {
+	ctx := context.Background()
+	users := &memoryUsers{
+		users: map[string]*entity.User{
+			"owner":  {UserId: "owner", FullName: "Owner", Role: entity.RoleOwner},
+			"author": {UserId: "author", FullName: "Author", ProfileImg: "https://cdn/author.png", Role: "user"},
+		},
+		links: map[string]string{},
+	}
+	outbox := &memoryOutbox{}
+	d := directory{users: users, publisher: publisher{repo: outbox}}
+
+	var errValidation *entity.ErrValidation
+	assert.NoError(t, d.owner(ctx, "owner"))
+	assert.True(t, errors.As(d.owner(ctx, "author"), &errValidation))
+	assert.True(t, errors.As(d.owner(ctx, "missing"), &errValidation))
+
+	// the link waits in the outbox for the commit
+	assert.NoError(t, d.link(ctx, "owner", "hotel"))
+	assert.Empty(t, users.links)
+	if assert.Len(t, outbox.messages, 1) {
+		assert.Equal(t, entity.EventTypeOwnerLink, outbox.messages[0].Headers[headerEventType])
+	}
+
+	// authors are asked once, anonymous and unknown authors stay undescribed
+	users.calls = 0
+	reviews := []*entity.Review{{UserId: "author"}, {UserId: "author"}, {UserId: entity.AnonymousUserId}, {UserId: "missing"}}
+	d.reviewers(ctx, reviews...)
+	assert.Equal(t, 2, users.calls)
+	assert.Equal(t, "Author", reviews[1].UserName)
+	assert.Equal(t, "https://cdn/author.png", reviews[1].UserAvatar)
+	assert.Empty(t, reviews[2].UserName)
+	assert.Empty(t, reviews[3].UserName)
+
+	// many authors are asked a few at a time
+	users.calls, users.callLatency = 0, 5*time.Millisecond
+	reviews = nil
+	for i := 0; i < 3*grpc_service_clients.MaxConcurrentCalls; i++ {
+		user_id := fmt.Sprintf("author-%d", i)
+		users.users[user_id] = &entity.User{UserId: user_id, FullName: user_id}
+		reviews = append(reviews, &entity.Review{UserId: user_id})
+	}
+	d.reviewers(ctx, reviews...)
+	assert.Equal(t, len(reviews), users.calls)
+	assert.LessOrEqual(t, users.maxRunning, grpc_service_clients.MaxConcurrentCalls)
+	assert.Greater(t, users.maxRunning, 1)
+	for _, review := range reviews {
+		assert.Equal(t, review.UserId, review.UserName)
+	}
+
+	// without the user service nothing is checked
+	assert.NoError(t, directory{}.owner(ctx, "missing"))
+	assert.NoError(t, directory{}.link(ctx, "owner", "hotel"))
+}
@@ -0,0 +1,31 @@
+package event
+
+import "errors"
+
+// permanentError is a failure retrying cannot fix, like a message that
+// cannot be decoded
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error of a handler so the message goes to the dead
+// letter topic without retries
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
@@ -1,8 +1,8 @@
 package event
 
 import (
-	"Booking/establishment-service-booking/internal/entity"
 	"context"
+	"time"
 )
 
 type ConsumerConfig interface {
@@ -10,15 +10,52 @@ type ConsumerConfig interface {
 	GetTopic() string
 	GetGroupID() string
 	GetHandler() func(ctx context.Context, key, value []byte) error
+	// GetRetryPolicy returns nil to use the policy of the consumer
+	GetRetryPolicy() *RetryPolicy
+	// GetDeadLetterTopic returns the topic of messages that failed every
+	// attempt, they are dropped when it is empty
+	GetDeadLetterTopic() string
+}
+
+// RetryPolicy retries a failing handler with a delay doubling from
+// MinBackoff up to MaxBackoff, MaxAttempts counts the first attempt
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Backoff returns the delay after the failed attempt
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
 }
 
 type BrokerConsumer interface {
 	Run()
 	RegisterConsumer(config ConsumerConfig)
-	Close()
+	Close() error
+}
+
+// Message is a record sent to the broker, messages of the same key keep
+// their order
+type Message struct {
+	Key     string
+	Value   []byte
+	Headers map[string]string
 }
 
 type BrokerProducer interface {
-	ProduceContent(ctx context.Context, key string, value *entity.Attraction) error
-	Close()
+	// Publish returns once the broker stored the message
+	Publish(ctx context.Context, message *Message) error
+	// Ping reports if a broker can be reached
+	Ping(ctx context.Context) error
+	// Close returns once the messages being written are flushed
+	Close() error
 }
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker is a BrokerProducer keeping messages in memory, a stand-in
+// for the broker in tests
+type MemoryBroker struct {
+	mu       sync.Mutex
+	messages []*Message
+	errs     []error
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+// Fail makes the next publishes return the errors, one per publish
+func (b *MemoryBroker) Fail(errs ...error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.errs = append(b.errs, errs...)
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, message *Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.errs) != 0 {
+		err := b.errs[0]
+		b.errs = b.errs[1:]
+		return err
+	}
+
+	b.messages = append(b.messages, message)
+	return nil
+}
+
+// Messages returns the published messages in order, all of them without keys
+// or the messages of the keys
+func (b *MemoryBroker) Messages(keys ...string) []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(keys) == 0 {
+		return append([]*Message(nil), b.messages...)
+	}
+
+	var messages []*Message
+	for _, message := range b.messages {
+		for _, key := range keys {
+			if message.Key == key {
+				messages = append(messages, message)
+				break
+			}
+		}
+	}
+	return messages
+}
+
+// Ping always succeeds, the broker is in memory
+func (b *MemoryBroker) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
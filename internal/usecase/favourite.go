@@ -22,13 +22,21 @@ type Favourite interface {
 type FavouriteService struct {
 	BaseUseCase
 	repo       repository.Favourite
+	guard      guard
+	auditor    auditor
+	publisher  publisher
+	transactor repository.Transactor
 	ctxTimeout time.Duration
 }
 
-func NewFavouriteService(ctxTimeout time.Duration, repo repository.Favourite) FavouriteService {
+func NewFavouriteService(ctxTimeout time.Duration, repo repository.Favourite, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor) FavouriteService {
 	return FavouriteService{
 		ctxTimeout: ctxTimeout,
 		repo:       repo,
+		guard:      guard{},
+		auditor:    auditor{repo: auditRepo},
+		publisher:  publisher{repo: outboxRepo},
+		transactor: transactor,
 	}
 }
 
@@ -39,7 +47,34 @@ func (f FavouriteService) AddToFavourites(ctx context.Context, favourite *entity
 	ctx, span := otlp.Start(ctx, favouriteServiceName, spanNameFavourite+"Create")
 	defer span.End()
 
-	return f.repo.AddToFavourites(ctx, favourite)
+	favourite.FavouriteId = f.newId(ctx, "favourite")
+	f.beforeRequest(nil, &favourite.CreatedAt, &favourite.UpdatedAt, nil)
+
+	if err := favourite.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.guard.user(ctx, favourite.UserId); err != nil {
+		return nil, err
+	}
+
+	var created *entity.Favourite
+	if err := f.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = f.repo.AddToFavourites(ctx, favourite); err != nil {
+			return err
+		}
+
+		if err := f.auditor.record(ctx, entity.AuditActionCreate, entity.EntityTypeFavourite, created.FavouriteId, created.EstablishmentId, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		return f.publisher.publish(ctx, entity.EventCreated, entity.EntityTypeFavourite, created.FavouriteId, created.EstablishmentId, 0, nil, created.AuditFields())
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (f FavouriteService) RemoveFromFavourites(ctx context.Context, favourite_id string) error {
@@ -49,7 +84,31 @@ func (f FavouriteService) RemoveFromFavourites(ctx context.Context, favourite_id
 	ctx, span := otlp.Start(ctx, favouriteServiceName, spanNameFavourite+"Delete")
 	defer span.End()
 
-	return f.repo.RemoveFromFavourites(ctx, favourite_id)
+	if err := entity.ValidateId("favourite_id", favourite_id); err != nil {
+		return err
+	}
+
+	favourite, err := f.repo.GetFavourite(ctx, favourite_id)
+	if err != nil {
+		return err
+	}
+
+	// only the user or an admin removes a favourite
+	if err := f.guard.user(ctx, favourite.UserId); err != nil {
+		return err
+	}
+
+	return f.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := f.repo.RemoveFromFavourites(ctx, favourite_id); err != nil {
+			return err
+		}
+
+		if err := f.auditor.record(ctx, entity.AuditActionDelete, entity.EntityTypeFavourite, favourite.FavouriteId, favourite.EstablishmentId, favourite.AuditFields(), nil); err != nil {
+			return err
+		}
+
+		return f.publisher.publish(ctx, entity.EventDeleted, entity.EntityTypeFavourite, favourite.FavouriteId, favourite.EstablishmentId, 0, favourite.AuditFields(), nil)
+	})
 }
 
 func (f FavouriteService) ListFavouritesByUserId(ctx context.Context, user_id string) ([]*entity.Favourite, error) {
@@ -59,5 +118,13 @@ func (f FavouriteService) ListFavouritesByUserId(ctx context.Context, user_id st
 	ctx, span := otlp.Start(ctx, favouriteServiceName, spanNameFavourite+"List")
 	defer span.End()
 
+	if err := entity.ValidateId("user_id", user_id); err != nil {
+		return nil, err
+	}
+
+	if err := f.guard.user(ctx, user_id); err != nil {
+		return nil, err
+	}
+
 	return f.repo.ListFavouritesByUserId(ctx, user_id)
 }
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"context"
+)
+
+// guard allows changes only to the owner of an establishment and to admins
+type guard struct {
+	repo repository.Ownership
+}
+
+// caller returns the caller of the request or an unauthenticated error
+func (g guard) caller(ctx context.Context) (*entity.Caller, error) {
+	caller := app.GetCallerFromContext(ctx)
+	if caller == nil {
+		return nil, entity.ErrorUnauthenticated
+	}
+	return caller, nil
+}
+
+// create allows owners to create establishments of their own
+func (g guard) create(ctx context.Context, owner_id string) error {
+	caller, err := g.caller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.IsAdmin() || (caller.Role == entity.RoleOwner && caller.UserId == owner_id) {
+		return nil
+	}
+	return entity.NewErrPermissionDenied("create an establishment")
+}
+
+// owner allows the caller being the given owner
+func (g guard) owner(ctx context.Context, owner_id string) error {
+	caller, err := g.caller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.IsAdmin() || caller.UserId == owner_id {
+		return nil
+	}
+	return entity.NewErrPermissionDenied("change the establishment")
+}
+
+// attached allows the owner of the establishment the entity belongs to
+func (g guard) attached(ctx context.Context, entity_id string) error {
+	caller, err := g.caller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.IsAdmin() {
+		return nil
+	}
+
+	owner_id, err := g.repo.GetOwnerId(ctx, entity_id)
+	if err != nil {
+		return err
+	}
+	if caller.UserId != owner_id {
+		return entity.NewErrPermissionDenied("change the establishment")
+	}
+	return nil
+}
+
+// establishment allows the owner of the establishment and returns it, the
+// caller is checked before the establishment is looked up
+func (g guard) establishment(ctx context.Context, establishment_id string) (*entity.EstablishmentRef, error) {
+	caller, err := g.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	establishment, err := g.repo.GetEstablishmentRef(ctx, establishment_id)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.IsAdmin() && caller.UserId != establishment.OwnerId {
+		return nil, entity.NewErrPermissionDenied("view the establishment")
+	}
+	return establishment, nil
+}
+
+// user allows the caller acting on their own behalf
+func (g guard) user(ctx context.Context, user_id string) error {
+	caller, err := g.caller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.IsAdmin() || caller.UserId == user_id {
+		return nil
+	}
+	return entity.NewErrPermissionDenied("act on behalf of another user")
+}
+
+// admin allows admins only
+func (g guard) admin(ctx context.Context, action string) error {
+	caller, err := g.caller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.IsAdmin() {
+		return nil
+	}
+	return entity.NewErrPermissionDenied(action)
+}
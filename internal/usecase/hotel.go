@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
 	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
 	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"context"
 	"time"
@@ -16,23 +18,36 @@ const (
 type Hotel interface {
 	CreateHotel(ctx context.Context, hotel *entity.Hotel) (*entity.Hotel, error)
 	GetHotel(ctx context.Context, hotel_id string) (*entity.Hotel, error)
-	ListHotels(ctx context.Context, page, limit int64) ([]*entity.Hotel, uint64, error)
+	ListHotels(ctx context.Context, page, limit int64, filter *entity.Filter) ([]*entity.Hotel, uint64, error)
 	UpdateHotel(ctx context.Context, hotel *entity.Hotel) (*entity.Hotel, error)
 	DeleteHotel(ctx context.Context, hotel_id string) error
-	ListHotelsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Hotel, int64, error)
-	FindHotelsByName(ctx context.Context, name string) ([]*entity.Hotel, uint64, error)
+	ListHotelsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Hotel, int64, error)
+	FindHotelsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Hotel, uint64, error)
 }
 
 type HotelService struct {
 	BaseUseCase
 	repo       repository.Hotel
+	localizer  localizer
+	pricer     pricer
+	guard      guard
+	directory  directory
+	auditor    auditor
+	publisher  publisher
+	transactor repository.Transactor
 	ctxTimeout time.Duration
 }
 
-func NewHotelService(ctxTimeout time.Duration, repo repository.Hotel) HotelService {
+func NewHotelService(ctxTimeout time.Duration, repo repository.Hotel, translationRepo repository.Translation, priceRepo repository.Price, rateRepo repository.CurrencyRate, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor, users grpc_service_clients.UserService) HotelService {
 	return HotelService{
 		ctxTimeout: ctxTimeout,
 		repo:       repo,
+		localizer:  localizer{repo: translationRepo},
+		pricer:     pricer{repo: priceRepo, rateRepo: rateRepo},
+		auditor:    auditor{repo: auditRepo},
+		publisher:  publisher{repo: outboxRepo},
+		directory:  directory{users: users, publisher: publisher{repo: outboxRepo}},
+		transactor: transactor,
 	}
 }
 
@@ -43,7 +58,42 @@ func (h HotelService) CreateHotel(ctx context.Context, hotel *entity.Hotel) (*en
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"Create")
 	defer span.End()
 
-	return h.repo.CreateHotel(ctx, hotel)
+	h.beforeCreateEstablishment(ctx, entity.EstablishmentTypeHotel, &hotel.HotelId, &hotel.CreatedAt, &hotel.UpdatedAt, &hotel.Location, hotel.Images)
+
+	if err := hotel.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := h.guard.create(ctx, hotel.OwnerId); err != nil {
+		return nil, err
+	}
+
+	if err := h.directory.owner(ctx, hotel.OwnerId); err != nil {
+		return nil, err
+	}
+
+	var created *entity.Hotel
+	if err := h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = h.repo.CreateHotel(ctx, hotel); err != nil {
+			return err
+		}
+
+		if err := h.auditor.record(ctx, entity.AuditActionCreate, entity.EstablishmentTypeHotel, created.HotelId, created.HotelId, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		if err := h.publisher.publish(ctx, entity.EventCreated, entity.EstablishmentTypeHotel, created.HotelId, created.HotelId, created.Version, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		// the outbox links the owner once the establishment is committed
+		return h.directory.link(ctx, created.OwnerId, created.HotelId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (h HotelService) GetHotel(ctx context.Context, hotel_id string) (*entity.Hotel, error) {
@@ -53,17 +103,55 @@ func (h HotelService) GetHotel(ctx context.Context, hotel_id string) (*entity.Ho
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"Get")
 	defer span.End()
 
-	return h.repo.GetHotel(ctx, hotel_id)
+	if err := entity.ValidateId("hotel_id", hotel_id); err != nil {
+		return nil, err
+	}
+
+	hotel, err := h.repo.GetHotel(ctx, hotel_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.localizer.hotels(ctx, hotel); err != nil {
+		return nil, err
+	}
+
+	if err := h.pricer.hotels(ctx, hotel); err != nil {
+		return nil, err
+	}
+
+	return hotel, nil
 }
 
-func (h HotelService) ListHotels(ctx context.Context, offset, limit int64) ([]*entity.Hotel, uint64, error) {
+func (h HotelService) ListHotels(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Hotel, uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, h.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"List")
 	defer span.End()
 
-	return h.repo.ListHotels(ctx, offset, limit)
+	if err := entity.ValidatePage(offset, limit); err != nil {
+		return nil, 0, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	hotels, count, err := h.repo.ListHotels(ctx, offset, limit, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := h.localizer.hotels(ctx, hotels...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := h.pricer.hotels(ctx, hotels...); err != nil {
+		return nil, 0, err
+	}
+
+	return hotels, count, nil
 }
 
 func (h HotelService) UpdateHotel(ctx context.Context, hotel *entity.Hotel) (*entity.Hotel, error) {
@@ -73,7 +161,60 @@ func (h HotelService) UpdateHotel(ctx context.Context, hotel *entity.Hotel) (*en
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"Update")
 	defer span.End()
 
-	return h.repo.UpdateHotel(ctx, hotel)
+	if err := entity.ValidateId("hotel_id", hotel.HotelId); err != nil {
+		return nil, err
+	}
+
+	version, ok := app.GetVersionFromContext(ctx)
+	if !ok {
+		return nil, entity.NewErrNoRequiredParameter("version")
+	}
+
+	existing, err := h.repo.GetHotel(ctx, hotel.HotelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.guard.owner(ctx, existing.OwnerId); err != nil {
+		return nil, err
+	}
+
+	before := existing.AuditFields()
+
+	// only the fields named by the mask change
+	if paths := app.GetUpdateMaskFromContext(ctx); paths != nil {
+		if err := existing.ApplyFieldMask(hotel, paths); err != nil {
+			return nil, err
+		}
+		hotel = existing
+	}
+	hotel.OwnerId = existing.OwnerId
+	hotel.Version = version
+
+	h.beforeRequest(nil, nil, &hotel.UpdatedAt, nil)
+	linkEstablishment(hotel.HotelId, &hotel.Location, hotel.Images)
+
+	if err := hotel.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.Hotel
+	if err := h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = h.repo.UpdateHotel(ctx, hotel); err != nil {
+			return err
+		}
+
+		if err := h.auditor.record(ctx, entity.AuditActionUpdate, entity.EstablishmentTypeHotel, updated.HotelId, updated.HotelId, before, updated.AuditFields()); err != nil {
+			return err
+		}
+
+		return h.publisher.publish(ctx, entity.EventUpdated, entity.EstablishmentTypeHotel, updated.HotelId, updated.HotelId, updated.Version, before, updated.AuditFields())
+	}); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (h HotelService) DeleteHotel(ctx context.Context, hotel_id string) error {
@@ -83,25 +224,94 @@ func (h HotelService) DeleteHotel(ctx context.Context, hotel_id string) error {
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"Delete")
 	defer span.End()
 
-	return h.repo.DeleteHotel(ctx, hotel_id)
+	if err := entity.ValidateId("hotel_id", hotel_id); err != nil {
+		return err
+	}
+
+	version, ok := app.GetVersionFromContext(ctx)
+	if !ok {
+		return entity.NewErrNoRequiredParameter("version")
+	}
+
+	existing, err := h.repo.GetHotel(ctx, hotel_id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.guard.owner(ctx, existing.OwnerId); err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	h.beforeRequest(nil, nil, nil, &deletedAt)
+
+	return h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repo.DeleteHotel(ctx, hotel_id, version, deletedAt); err != nil {
+			return err
+		}
+
+		if err := h.auditor.record(ctx, entity.AuditActionDelete, entity.EstablishmentTypeHotel, hotel_id, hotel_id, existing.AuditFields(), nil); err != nil {
+			return err
+		}
+
+		return h.publisher.publish(ctx, entity.EventDeleted, entity.EstablishmentTypeHotel, hotel_id, hotel_id, existing.Version, existing.AuditFields(), nil)
+	})
 }
 
-func (h HotelService) ListHotelsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Hotel, int64, error) {
+func (h HotelService) ListHotelsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Hotel, int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, h.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"ListL")
 	defer span.End()
 
-	return h.repo.ListHotelsByLocation(ctx, offset, limit, country, city, state_province)
+	if err := entity.ValidateLocationPage(offset, limit, country, city, state_province); err != nil {
+		return nil, 0, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	hotels, count, err := h.repo.ListHotelsByLocation(ctx, offset, limit, country, city, state_province, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := h.localizer.hotels(ctx, hotels...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := h.pricer.hotels(ctx, hotels...); err != nil {
+		return nil, 0, err
+	}
+
+	return hotels, count, nil
 }
 
-func (h HotelService) FindHotelsByName(ctx context.Context, name string) ([]*entity.Hotel, uint64, error) {
+func (h HotelService) FindHotelsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Hotel, uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, h.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, hotelServiceName, spanNameHotel+"List")
 	defer span.End()
 
-	return h.repo.FindHotelsByName(ctx, name)
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	hotels, count, err := h.repo.FindHotelsByName(ctx, name, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := h.localizer.hotels(ctx, hotels...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := h.pricer.hotels(ctx, hotels...); err != nil {
+		return nil, 0, err
+	}
+
+	return hotels, count, nil
 }
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	idempotencyServiceName = "idempotencyService"
+	spanNameIdempotency    = "idempotencyUsecase"
+)
+
+type Idempotency interface {
+	// Begin reserves the idempotency key of the request for the caller. A
+	// completed record is returned when the request was already handled.
+	Begin(ctx context.Context, method, request_hash string) (*entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, method, request_hash, response_type string, response []byte) error
+	Abort(ctx context.Context, method, request_hash string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type IdempotencyService struct {
+	BaseUseCase
+	repo       repository.Idempotency
+	ttl        time.Duration
+	ctxTimeout time.Duration
+}
+
+func NewIdempotencyService(ctxTimeout, ttl time.Duration, repo repository.Idempotency) IdempotencyService {
+	return IdempotencyService{
+		ctxTimeout: ctxTimeout,
+		ttl:        ttl,
+		repo:       repo,
+	}
+}
+
+// record identifies the request by its idempotency key, the caller and the method
+func (i IdempotencyService) record(ctx context.Context, method, request_hash string) *entity.IdempotencyRecord {
+	record := &entity.IdempotencyRecord{
+		Key:         app.GetIdempotencyKeyFromContext(ctx),
+		Method:      method,
+		RequestHash: request_hash,
+	}
+	if caller := app.GetCallerFromContext(ctx); caller != nil {
+		record.UserId = caller.UserId
+	}
+	return record
+}
+
+func (i IdempotencyService) Begin(ctx context.Context, method, request_hash string) (*entity.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, spanNameIdempotency+"Begin")
+	defer span.End()
+
+	record := i.record(ctx, method, request_hash)
+	if err := entity.Validate("invalid idempotency key",
+		entity.Field("idempotency-key", record.Key, entity.Required, entity.MaxLength(entity.MaxNameLength)),
+	); err != nil {
+		return nil, err
+	}
+
+	i.beforeRequest(nil, &record.CreatedAt, nil, nil)
+	record.ExpiresAt = record.CreatedAt.Add(i.ttl)
+	// the request runs within the context timeout, a reservation outliving
+	// it belongs to a request that died before completing or releasing it
+	record.LockedUntil = record.CreatedAt.Add(i.ctxTimeout)
+
+	existing, err := i.repo.ReserveIdempotencyKey(ctx, record)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	if existing.RequestHash != request_hash {
+		errValidation := entity.NewErrValidation()
+		errValidation.Errors["idempotency-key"] = "was already used with a different request"
+		errValidation.Err = errors.New("idempotency key reused with a different request")
+		return nil, errValidation
+	}
+
+	if !existing.Completed() {
+		return nil, entity.NewErrConflict("request with the idempotency key in progress")
+	}
+
+	return existing, nil
+}
+
+func (i IdempotencyService) Complete(ctx context.Context, method, request_hash, response_type string, response []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, spanNameIdempotency+"Complete")
+	defer span.End()
+
+	record := i.record(ctx, method, request_hash)
+	record.ResponseType = response_type
+	record.Response = response
+	if record.Response == nil {
+		record.Response = []byte{}
+	}
+
+	return i.repo.CompleteIdempotencyKey(ctx, record)
+}
+
+// Abort releases the key of a failed request, so a retry runs it again
+func (i IdempotencyService) Abort(ctx context.Context, method, request_hash string) error {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, spanNameIdempotency+"Abort")
+	defer span.End()
+
+	return i.repo.ReleaseIdempotencyKey(ctx, i.record(ctx, method, request_hash))
+}
+
+func (i IdempotencyService) DeleteExpired(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, idempotencyServiceName, spanNameIdempotency+"DeleteExpired")
+	defer span.End()
+
+	return i.repo.DeleteExpiredIdempotencyKeys(ctx)
+}
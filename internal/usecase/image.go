@@ -20,14 +20,22 @@ type Image interface {
 type ImageService struct {
 	BaseUseCase
 	repo       repository.Image
+	guard      guard
+	auditor    auditor
+	publisher  publisher
+	transactor repository.Transactor
 	ctxTimeout time.Duration
 }
 
 
-func NewImageService(ctxTimeout time.Duration, repo repository.Image) ImageService {
+func NewImageService(ctxTimeout time.Duration, repo repository.Image, ownershipRepo repository.Ownership, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor) ImageService {
 	return ImageService{
 		ctxTimeout: ctxTimeout,
 		repo:       repo,
+		guard:      guard{repo: ownershipRepo},
+		auditor:    auditor{repo: auditRepo},
+		publisher:  publisher{repo: outboxRepo},
+		transactor: transactor,
 	}
 }
 
@@ -38,5 +46,26 @@ func (h ImageService) CreateImage(ctx context.Context, image *entity.Image) erro
 	ctx, span := otlp.Start(ctx, imageServiceName, spanNameImage+"Create")
 	defer span.End()
 
-	return h.repo.CreateImage(ctx, image)
+	image.ImageId = h.newId(ctx, "image")
+	h.beforeRequest(nil, &image.CreatedAt, &image.UpdatedAt, nil)
+
+	if err := image.Validate(); err != nil {
+		return err
+	}
+
+	if err := h.guard.attached(ctx, image.EstablishmentId); err != nil {
+		return err
+	}
+
+	return h.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := h.repo.CreateImage(ctx, image); err != nil {
+			return err
+		}
+
+		if err := h.auditor.record(ctx, entity.AuditActionCreate, entity.EntityTypeImage, image.ImageId, image.EstablishmentId, nil, image.AuditFields()); err != nil {
+			return err
+		}
+
+		return h.publisher.publish(ctx, entity.EventCreated, entity.EntityTypeImage, image.ImageId, image.EstablishmentId, 0, nil, image.AuditFields())
+	})
 }
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	outboxServiceName = "outboxService"
+	spanNameOutbox    = "outboxUsecase"
+
+	// outboxBatchSize limits the messages claimed by one run of the relay
+	outboxBatchSize = 100
+)
+
+type Outbox interface {
+	// Relay sends a batch of pending messages to the broker, or the owner
+	// links to the user service, and returns how many were sent. Every
+	// message is marked right after its publish, so a later failure does not
+	// send it again.
+	Relay(ctx context.Context) (int, error)
+	DeleteSent(ctx context.Context) (int64, error)
+}
+
+type OutboxService struct {
+	BaseUseCase
+	repo       repository.Outbox
+	transactor repository.Transactor
+	producer   event.BrokerProducer
+	users      grpc_service_clients.UserService
+	// publishTimeout bounds the publish of one message
+	publishTimeout time.Duration
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	retention      time.Duration
+	ctxTimeout     time.Duration
+}
+
+func NewOutboxService(ctxTimeout, publishTimeout, minBackoff, maxBackoff, retention time.Duration, repo repository.Outbox, transactor repository.Transactor, producer event.BrokerProducer, users grpc_service_clients.UserService) OutboxService {
+	return OutboxService{
+		ctxTimeout:     ctxTimeout,
+		publishTimeout: publishTimeout,
+		minBackoff:     minBackoff,
+		maxBackoff:     maxBackoff,
+		retention:      retention,
+		repo:           repo,
+		transactor:     transactor,
+		producer:       producer,
+		users:          users,
+	}
+}
+
+// backoff doubles the delay of every failed attempt up to the maximum
+func (o OutboxService) backoff(attempts int) time.Duration {
+	delay := o.minBackoff
+	for i := 1; i < attempts && delay < o.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > o.maxBackoff {
+		delay = o.maxBackoff
+	}
+	return delay
+}
+
+func (o OutboxService) Relay(ctx context.Context) (int, error) {
+	ctx, span := otlp.Start(ctx, outboxServiceName, spanNameOutbox+"Relay")
+	defer span.End()
+
+	messages, err := o.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			// the claimed messages are due again when the claim ends
+			break
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, o.publishTimeout)
+		err := o.deliver(publishCtx, message)
+		cancel()
+
+		if err := o.done(ctx, message, err); err != nil {
+			return sent, err
+		}
+		if err == nil {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// deliver sends an event to the broker or makes an owner link
+func (o OutboxService) deliver(ctx context.Context, message *entity.OutboxMessage) error {
+	if message.Headers[headerEventType] != entity.EventTypeOwnerLink {
+		return o.producer.Publish(ctx, &event.Message{Key: message.Key, Value: message.Payload, Headers: message.Headers})
+	}
+
+	var link entity.OwnerLink
+	if err := json.Unmarshal(message.Payload, &link); err != nil {
+		return fmt.Errorf("failed to decode owner link: %w", err)
+	}
+	if o.users == nil {
+		return entity.NewErrUnavailable("user service")
+	}
+	return o.users.LinkEstablishment(otlp.Extract(ctx, propagation.MapCarrier(message.Headers)), link.OwnerId, link.EstablishmentId)
+}
+
+// claim lists the due messages and keeps them from other relays until every
+// publish of the batch could have timed out, holding the lock and a
+// connection only for that
+func (o OutboxService) claim(ctx context.Context) ([]*entity.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.ctxTimeout)
+	defer cancel()
+
+	var messages []*entity.OutboxMessage
+	err := o.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// another instance is relaying
+		locked, err := o.repo.LockOutbox(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		messages, err = o.repo.ListPendingOutboxMessages(ctx, now, outboxBatchSize)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(messages))
+		for _, message := range messages {
+			ids = append(ids, message.MessageId)
+		}
+		lease := time.Duration(len(messages))*o.publishTimeout + o.ctxTimeout
+		return o.repo.ClaimOutboxMessages(ctx, ids, now.Add(lease))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// done marks the message sent, or due again after the backoff when the
+// publish failed, the later messages of its key wait for it
+func (o OutboxService) done(ctx context.Context, message *entity.OutboxMessage, publishErr error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), o.ctxTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	if publishErr == nil {
+		return o.repo.MarkOutboxMessageSent(ctx, message.MessageId, now)
+	}
+
+	message.Attempts++
+	message.LastError = publishErr.Error()
+	message.NextAttemptAt = now.Add(o.backoff(message.Attempts))
+	return o.repo.RetryOutboxMessage(ctx, message)
+}
+
+// DeleteSent removes the messages sent longer than the retention ago
+func (o OutboxService) DeleteSent(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, outboxServiceName, spanNameOutbox+"DeleteSent")
+	defer span.End()
+
+	return o.repo.DeleteSentOutboxMessages(ctx, time.Now().UTC().Add(-o.retention))
+}
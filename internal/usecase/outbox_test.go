@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/usecase/event"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOutbox keeps the outbox in memory with the semantics of the table
+type memoryOutbox struct {
+	messages []*entity.OutboxMessage
+	locked   bool
+	markErr  error
+}
+
+func (m *memoryOutbox) AddOutboxMessage(ctx context.Context, message *entity.OutboxMessage) error {
+	message.MessageId = int64(len(m.messages) + 1)
+	message.NextAttemptAt = message.CreatedAt
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func (m *memoryOutbox) LockOutbox(ctx context.Context) (bool, error) {
+	return !m.locked, nil
+}
+
+func (m *memoryOutbox) ListPendingOutboxMessages(ctx context.Context, now time.Time, limit uint64) ([]*entity.OutboxMessage, error) {
+	heads := make(map[string]*entity.OutboxMessage)
+	for _, message := range m.messages {
+		if _, ok := heads[message.Key]; !ok && !message.Sent() {
+			heads[message.Key] = message
+		}
+	}
+
+	var pending []*entity.OutboxMessage
+	for _, message := range heads {
+		if !message.NextAttemptAt.After(now) {
+			pending = append(pending, message)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].MessageId < pending[j].MessageId })
+	if uint64(len(pending)) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+func (m *memoryOutbox) ClaimOutboxMessages(ctx context.Context, message_ids []int64, until time.Time) error {
+	for _, message_id := range message_ids {
+		m.messages[message_id-1].NextAttemptAt = until
+	}
+	return nil
+}
+
+func (m *memoryOutbox) MarkOutboxMessageSent(ctx context.Context, message_id int64, sent_at time.Time) error {
+	if m.markErr != nil {
+		return m.markErr
+	}
+	m.messages[message_id-1].SentAt = sent_at
+	return nil
+}
+
+func (m *memoryOutbox) RetryOutboxMessage(ctx context.Context, message *entity.OutboxMessage) error {
+	*m.messages[message.MessageId-1] = *message
+	return nil
+}
+
+func (m *memoryOutbox) DeleteSentOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+type noTransaction struct{}
+
+func (noTransaction) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestOutboxRelay(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryOutbox{}
+	broker := event.NewMemoryBroker()
+	relay := NewOutboxService(time.Second, time.Second, time.Minute, time.Hour, time.Hour, repo, noTransaction{}, broker, nil)
+
+	publish := publisher{repo: repo}
+	assert.NoError(t, publish.publish(ctx, entity.EventCreated, entity.EstablishmentTypeHotel, "h1", "h1", 1, nil, map[string]interface{}{"hotel_name": "Hilton"}))
+	assert.NoError(t, publish.publish(ctx, entity.EventUpdated, entity.EstablishmentTypeHotel, "h1", "h1", 2, map[string]interface{}{"hotel_name": "Hilton"}, map[string]interface{}{"hotel_name": "Hyatt"}))
+	assert.NoError(t, publish.publish(ctx, entity.EventCreated, entity.EntityTypeReview, "r1", "h2", 0, nil, map[string]interface{}{"rating": 5.0}))
+
+	// the first message of h1 fails, the one of h2 is sent
+	broker.Fail(errors.New("broker is down"))
+	sent, err := relay.Relay(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, broker.Messages("h2"), 1)
+	assert.Equal(t, "review.created", broker.Messages("h2")[0].Headers[headerEventType])
+
+	failed := repo.messages[0]
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "broker is down", failed.LastError)
+	assert.True(t, failed.NextAttemptAt.After(time.Now().Add(50*time.Second)))
+
+	// h1 waits for the backoff of its first message
+	sent, err = relay.Relay(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	failed.NextAttemptAt = time.Now().UTC()
+	for i := 0; i < 2; i++ {
+		sent, err = relay.Relay(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	}
+
+	// messages of a key keep their order
+	messages := broker.Messages("h1")
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "hotel.created", messages[0].Headers[headerEventType])
+		assert.Equal(t, "hotel.updated", messages[1].Headers[headerEventType])
+	}
+
+	// another relay holds the lock
+	assert.NoError(t, publish.publish(ctx, entity.EventDeleted, entity.EstablishmentTypeHotel, "h1", "h1", 2, nil, nil))
+	repo.locked = true
+	sent, err = relay.Relay(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
+
+func TestOutboxRelayMarkFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryOutbox{}
+	broker := event.NewMemoryBroker()
+	relay := NewOutboxService(time.Second, time.Second, time.Minute, time.Hour, time.Hour, repo, noTransaction{}, broker, nil)
+
+	publish := publisher{repo: repo}
+	assert.NoError(t, publish.publish(ctx, entity.EventCreated, entity.EstablishmentTypeHotel, "h1", "h1", 1, nil, map[string]interface{}{"hotel_name": "Hilton"}))
+	assert.NoError(t, publish.publish(ctx, entity.EventCreated, entity.EstablishmentTypeHotel, "h2", "h2", 1, nil, map[string]interface{}{"hotel_name": "Hyatt"}))
+
+	// the first message is delivered but can not be marked
+	repo.markErr = errors.New("connection reset")
+	sent, err := relay.Relay(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Len(t, broker.Messages("h1"), 1)
+	assert.Empty(t, broker.Messages("h2"))
+
+	// the claim keeps both messages off the next relay
+	repo.markErr = nil
+	sent, err = relay.Relay(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Len(t, broker.Messages("h1"), 1)
+	for _, message := range repo.messages {
+		assert.True(t, message.NextAttemptAt.After(time.Now()))
+	}
+}
+
+func TestOutboxRelayOwnerLink(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryOutbox{}
+	broker := event.NewMemoryBroker()
+	users := &memoryUsers{links: map[string]string{}}
+	d := directory{users: users, publisher: publisher{repo: repo}}
+
+	publish := publisher{repo: repo}
+	assert.NoError(t, publish.publish(ctx, entity.EventCreated, entity.EstablishmentTypeHotel, "h1", "h1", 1, nil, map[string]interface{}{"hotel_name": "Hilton"}))
+	assert.NoError(t, d.link(ctx, "owner", "h1"))
+
+	// a user service that is down holds back the link but not the events
+	down := NewOutboxService(time.Second, time.Second, time.Minute, time.Hour, time.Hour, repo, noTransaction{}, broker, nil)
+	sent, err := down.Relay(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, broker.Messages("h1"), 1)
+	assert.Equal(t, 1, repo.messages[1].Attempts)
+
+	repo.messages[1].NextAttemptAt = time.Now().UTC()
+	relay := NewOutboxService(time.Second, time.Second, time.Minute, time.Hour, time.Hour, repo, noTransaction{}, broker, users)
+	sent, err = relay.Relay(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, "owner", users.links["h1"])
+	assert.Len(t, broker.Messages("h1"), 1)
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	relay := NewOutboxService(time.Second, time.Second, time.Second, time.Minute, time.Hour, nil, nil, nil, nil)
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 32*time.Second, relay.backoff(6))
+	assert.Equal(t, time.Minute, relay.backoff(7))
+	assert.Equal(t, time.Minute, relay.backoff(100))
+}
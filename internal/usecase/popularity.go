@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	popularityServiceName = "popularityService"
+	spanNamePopularity    = "popularityUsecase"
+)
+
+type Popularity interface {
+	// RecordBooking counts a booking made or canceled in the booking service,
+	// a booking is counted once however often its events are delivered
+	RecordBooking(ctx context.Context, booking *entity.Booking) error
+	// CountBookings returns the bookings of the establishment in the window
+	// ending now, e.g. to show "booked 120 times this month"
+	CountBookings(ctx context.Context, establishment_id string, window time.Duration) (int64, error)
+}
+
+type PopularityService struct {
+	BaseUseCase
+	repo       repository.Booking
+	transactor repository.Transactor
+	ctxTimeout time.Duration
+}
+
+func NewPopularityService(ctxTimeout time.Duration, repo repository.Booking, transactor repository.Transactor) PopularityService {
+	return PopularityService{
+		ctxTimeout: ctxTimeout,
+		repo:       repo,
+		transactor: transactor,
+	}
+}
+
+func (p PopularityService) RecordBooking(ctx context.Context, booking *entity.Booking) error {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, popularityServiceName, spanNamePopularity+"Record")
+	defer span.End()
+
+	if err := booking.Validate(); err != nil {
+		return err
+	}
+
+	return p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := p.repo.GetBooking(ctx, booking.BookingId)
+
+		var errNotFound *entity.ErrNotFound
+		switch {
+		case errors.As(err, &errNotFound):
+			if err := p.repo.SaveBooking(ctx, booking); err != nil {
+				return err
+			}
+			// a booking first seen canceled never counted
+			if booking.Canceled {
+				return nil
+			}
+			return p.repo.AddBookingCount(ctx, booking.EstablishmentId, booking.BookedAt, 1)
+		case err != nil:
+			return err
+		}
+
+		// redelivered events change nothing, a canceled booking stays canceled
+		if existing.Canceled || !booking.Canceled {
+			return nil
+		}
+
+		existing.Canceled = true
+		if err := p.repo.SaveBooking(ctx, existing); err != nil {
+			return err
+		}
+		// the count of the day the booking was made goes down
+		return p.repo.AddBookingCount(ctx, existing.EstablishmentId, existing.BookedAt, -1)
+	})
+}
+
+func (p PopularityService) CountBookings(ctx context.Context, establishment_id string, window time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, popularityServiceName, spanNamePopularity+"Count")
+	defer span.End()
+
+	if err := entity.ValidateId("establishment_id", establishment_id); err != nil {
+		return 0, err
+	}
+
+	return p.repo.CountBookings(ctx, establishment_id, time.Now().UTC().Add(-window))
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryBookings keeps bookings and their daily counts in memory
+type memoryBookings struct {
+	bookings map[string]entity.Booking
+	counts   map[string]int64
+}
+
+func newMemoryBookings() *memoryBookings {
+	return &memoryBookings{bookings: map[string]entity.Booking{}, counts: map[string]int64{}}
+}
+
+func (m *memoryBookings) GetBooking(ctx context.Context, booking_id string) (*entity.Booking, error) {
+	booking, ok := m.bookings[booking_id]
+	if !ok {
+		return nil, entity.NewErrNotFound("booking")
+	}
+	return &booking, nil
+}
+
+func (m *memoryBookings) SaveBooking(ctx context.Context, booking *entity.Booking) error {
+	m.bookings[booking.BookingId] = *booking
+	return nil
+}
+
+func (m *memoryBookings) AddBookingCount(ctx context.Context, establishment_id string, at time.Time, delta int64) error {
+	m.counts[establishment_id+at.Format("2006-01-02")] += delta
+	return nil
+}
+
+func (m *memoryBookings) CountBookings(ctx context.Context, establishment_id string, since time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestRecordBooking(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryBookings()
+	popularity := NewPopularityService(time.Second, repo, noTransaction{})
+
+	hotel_id := "7d4f5a1e-2c7b-4f1e-9a55-3c2b1d0e9f10"
+	day := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	key := hotel_id + "2024-05-01"
+
+	booking := &entity.Booking{BookingId: "b1", EstablishmentId: hotel_id, BookedAt: day}
+	assert.NoError(t, popularity.RecordBooking(ctx, booking))
+	assert.NoError(t, popularity.RecordBooking(ctx, booking))
+	assert.Equal(t, int64(1), repo.counts[key])
+
+	// a cancellation counts on the day of the booking, once
+	canceled := &entity.Booking{BookingId: "b1", EstablishmentId: hotel_id, BookedAt: day.Add(48 * time.Hour), Canceled: true}
+	assert.NoError(t, popularity.RecordBooking(ctx, canceled))
+	assert.NoError(t, popularity.RecordBooking(ctx, canceled))
+	assert.Equal(t, int64(0), repo.counts[key])
+
+	// a late created event does not count a canceled booking again
+	assert.NoError(t, popularity.RecordBooking(ctx, booking))
+	assert.Equal(t, int64(0), repo.counts[key])
+
+	// a booking first seen canceled never counts
+	assert.NoError(t, popularity.RecordBooking(ctx, &entity.Booking{BookingId: "b2", EstablishmentId: hotel_id, BookedAt: day, Canceled: true}))
+	assert.Equal(t, int64(0), repo.counts[key])
+	assert.True(t, repo.bookings["b2"].Canceled)
+
+	assert.Error(t, popularity.RecordBooking(ctx, &entity.Booking{BookingId: "b3", EstablishmentId: "hotel"}))
+}
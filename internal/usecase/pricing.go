@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	pricingServiceName = "pricingService"
+	spanNamePricing    = "pricingUsecase"
+)
+
+type Pricing interface {
+	CreatePrice(ctx context.Context, price *entity.Price) (*entity.Price, error)
+	ListPrices(ctx context.Context, establishment_id string) ([]*entity.Price, error)
+	DeletePrice(ctx context.Context, price_id string) error
+	ListCurrencyRates(ctx context.Context) ([]*entity.CurrencyRate, error)
+	SetCurrencyRates(ctx context.Context, rates []*entity.CurrencyRate) error
+	LoadCurrencyRates(ctx context.Context, path string) error
+}
+
+type PricingService struct {
+	BaseUseCase
+	repo       repository.Price
+	rateRepo   repository.CurrencyRate
+	pricer     pricer
+	guard      guard
+	ctxTimeout time.Duration
+}
+
+func NewPricingService(ctxTimeout time.Duration, repo repository.Price, rateRepo repository.CurrencyRate, ownershipRepo repository.Ownership) PricingService {
+	return PricingService{
+		ctxTimeout: ctxTimeout,
+		repo:       repo,
+		rateRepo:   rateRepo,
+		pricer:     pricer{repo: repo, rateRepo: rateRepo},
+		guard:      guard{repo: ownershipRepo},
+	}
+}
+
+func (p PricingService) CreatePrice(ctx context.Context, price *entity.Price) (*entity.Price, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, pricingServiceName, spanNamePricing+"Create")
+	defer span.End()
+
+	price.PriceId = p.newId(ctx, "price")
+
+	errValidation := entity.NewErrValidation()
+	switch price.ItemType {
+	case entity.PriceItemRoom, entity.PriceItemTicket, entity.PriceItemMenu:
+	default:
+		errValidation.Errors["item_type"] = "unknown item type " + price.ItemType
+	}
+	if !entity.IsCurrencyCode(price.Price.Currency) {
+		errValidation.Errors["currency"] = "invalid currency code " + price.Price.Currency
+	}
+	if price.Price.Amount < 0 {
+		errValidation.Errors["amount"] = "amount can not be negative"
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid price")
+		return nil, errValidation
+	}
+
+	if err := p.guard.attached(ctx, price.EstablishmentId); err != nil {
+		return nil, err
+	}
+
+	return p.repo.CreatePrice(ctx, price)
+}
+
+// ListPrices lists prices of the establishment converted into the requested currency
+func (p PricingService) ListPrices(ctx context.Context, establishment_id string) ([]*entity.Price, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, pricingServiceName, spanNamePricing+"List")
+	defer span.End()
+
+	prices, err := p.pricer.prices(ctx, []string{establishment_id})
+	if err != nil {
+		return nil, err
+	}
+
+	return prices[establishment_id], nil
+}
+
+func (p PricingService) DeletePrice(ctx context.Context, price_id string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, pricingServiceName, spanNamePricing+"Delete")
+	defer span.End()
+
+	if err := p.guard.attached(ctx, price_id); err != nil {
+		return err
+	}
+
+	return p.repo.DeletePrice(ctx, price_id)
+}
+
+func (p PricingService) ListCurrencyRates(ctx context.Context) ([]*entity.CurrencyRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, pricingServiceName, spanNamePricing+"ListRates")
+	defer span.End()
+
+	return p.rateRepo.ListCurrencyRates(ctx)
+}
+
+// SetCurrencyRates saves rates quoted against entity.BaseCurrency
+func (p PricingService) SetCurrencyRates(ctx context.Context, rates []*entity.CurrencyRate) error {
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, pricingServiceName, spanNamePricing+"SetRates")
+	defer span.End()
+
+	if err := p.guard.admin(ctx, "set currency rates"); err != nil {
+		return err
+	}
+
+	return p.saveCurrencyRates(ctx, rates)
+}
+
+func (p PricingService) saveCurrencyRates(ctx context.Context, rates []*entity.CurrencyRate) error {
+	errValidation := entity.NewErrValidation()
+	for _, rate := range rates {
+		if !entity.IsCurrencyCode(rate.Currency) {
+			errValidation.Errors["currency"] = "invalid currency code " + rate.Currency
+		}
+		if rate.Currency == entity.BaseCurrency {
+			errValidation.Errors["currency"] = "rate of the base currency is always 1"
+		}
+	}
+	if _, err := entity.NewRates(rates); err != nil {
+		errValidation.Errors["rate"] = err.Error()
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid currency rates")
+		return errValidation
+	}
+
+	return p.rateRepo.UpsertCurrencyRates(ctx, rates)
+}
+
+// LoadCurrencyRates saves rates read from a JSON file of the form
+// {"base": "USD", "rates": {"UZS": "12650.5", "RUB": 92.1}}, it is meant for
+// startup and is not guarded
+func (p PricingService) LoadCurrencyRates(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read currency rates file: %w", err)
+	}
+
+	var file struct {
+		Base  string                 `json:"base"`
+		Rates map[string]json.Number `json:"rates"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to decode currency rates file: %w", err)
+	}
+	if file.Base != "" && file.Base != entity.BaseCurrency {
+		return fmt.Errorf("currency rates must be quoted against %s, not %s", entity.BaseCurrency, file.Base)
+	}
+
+	rates := make([]*entity.CurrencyRate, 0, len(file.Rates))
+	for currency, rate := range file.Rates {
+		rates = append(rates, &entity.CurrencyRate{Currency: currency, Rate: rate.String()})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.ctxTimeout)
+	defer cancel()
+
+	return p.saveCurrencyRates(ctx, rates)
+}
+
+// pricer attaches prices converted into the currency of the request
+type pricer struct {
+	repo     repository.Price
+	rateRepo repository.CurrencyRate
+}
+
+// prices fetches prices of the establishments grouped by establishment id
+func (p pricer) prices(ctx context.Context, establishment_ids []string) (map[string][]*entity.Price, error) {
+	if p.repo == nil {
+		return nil, nil
+	}
+
+	prices, err := p.repo.ListPrices(ctx, establishment_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency := app.GetCurrencyFromContext(ctx); currency != "" && len(prices) != 0 {
+		if err := p.convert(ctx, currency, prices); err != nil {
+			return nil, err
+		}
+	}
+
+	grouped := make(map[string][]*entity.Price, len(establishment_ids))
+	for _, price := range prices {
+		grouped[price.EstablishmentId] = append(grouped[price.EstablishmentId], price)
+	}
+
+	return grouped, nil
+}
+
+func (p pricer) convert(ctx context.Context, currency string, prices []*entity.Price) error {
+	list, err := p.rateRepo.ListCurrencyRates(ctx)
+	if err != nil {
+		return err
+	}
+
+	rates, err := entity.NewRates(list)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := rates[currency]; !ok {
+		errValidation := entity.NewErrValidation()
+		errValidation.Errors["currency"] = "unsupported currency " + currency
+		errValidation.Err = errors.New("unsupported currency " + currency)
+		return errValidation
+	}
+
+	for _, price := range prices {
+		converted, err := rates.Convert(price.Price, currency)
+		if err != nil {
+			return err
+		}
+		price.Converted = &converted
+	}
+
+	return nil
+}
+
+func (p pricer) hotels(ctx context.Context, hotels ...*entity.Hotel) error {
+	ids := make([]string, 0, len(hotels))
+	for _, hotel := range hotels {
+		ids = append(ids, hotel.HotelId)
+	}
+
+	prices, err := p.prices(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, hotel := range hotels {
+		hotel.Prices = prices[hotel.HotelId]
+	}
+	return nil
+}
+
+func (p pricer) restaurants(ctx context.Context, restaurants ...*entity.Restaurant) error {
+	ids := make([]string, 0, len(restaurants))
+	for _, restaurant := range restaurants {
+		ids = append(ids, restaurant.RestaurantId)
+	}
+
+	prices, err := p.prices(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, restaurant := range restaurants {
+		restaurant.Prices = prices[restaurant.RestaurantId]
+	}
+	return nil
+}
+
+func (p pricer) attractions(ctx context.Context, attractions ...*entity.Attraction) error {
+	ids := make([]string, 0, len(attractions))
+	for _, attraction := range attractions {
+		ids = append(ids, attraction.AttractionId)
+	}
+
+	prices, err := p.prices(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, attraction := range attractions {
+		attraction.Prices = prices[attraction.AttractionId]
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	headerEventType     = "event_type"
+	headerSchemaVersion = "schema_version"
+)
+
+// publisher tells other services about the changes made by a request. The
+// events go to the outbox in the transaction of the change, the outbox relay
+// sends them to the broker.
+type publisher struct {
+	repo repository.Outbox
+}
+
+// publish stores the event of a change keyed by the establishment id, before
+// is nil for a create and after for a delete
+func (p publisher) publish(ctx context.Context, action, entity_type, entity_id, establishment_id string, version int64, before, after map[string]interface{}) error {
+	domainEvent := &entity.DomainEvent{
+		EventId:         uuid.New().String(),
+		Type:            entity.EventType(entity_type, action),
+		SchemaVersion:   entity.EventSchemaVersion,
+		EntityType:      entity_type,
+		EntityId:        entity_id,
+		EstablishmentId: establishment_id,
+		EntityVersion:   version,
+		Data:            after,
+		OccurredAt:      time.Now().UTC(),
+	}
+	if action == entity.EventUpdated {
+		domainEvent.ChangedFields = entity.ChangedFields(entity.Diff(before, after))
+	}
+	if caller := app.GetCallerFromContext(ctx); caller != nil {
+		domainEvent.ActorId = caller.UserId
+	}
+
+	payload, err := json.Marshal(domainEvent)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", domainEvent.Type, err)
+	}
+
+	// the type and the schema version let consumers skip events without decoding them
+	headers := map[string]string{
+		headerEventType:     domainEvent.Type,
+		headerSchemaVersion: strconv.Itoa(domainEvent.SchemaVersion),
+	}
+	// the relay sends the event later, consumers continue the trace of the request
+	otlp.Inject(ctx, propagation.MapCarrier(headers))
+
+	if err := p.repo.AddOutboxMessage(ctx, &entity.OutboxMessage{
+		Key:       establishment_id,
+		Payload:   payload,
+		Headers:   headers,
+		CreatedAt: domainEvent.OccurredAt,
+	}); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", domainEvent.Type, err)
+	}
+	return nil
+}
+
+// link stores the link of a new establishment with its owner, the relay
+// makes it in the user service once the establishment is committed. Links
+// have keys of their own so a user service that is down does not hold back
+// the events of the establishment.
+func (p publisher) link(ctx context.Context, owner_id, establishment_id string) error {
+	payload, err := json.Marshal(entity.OwnerLink{OwnerId: owner_id, EstablishmentId: establishment_id})
+	if err != nil {
+		return fmt.Errorf("failed to encode owner link: %w", err)
+	}
+
+	headers := map[string]string{headerEventType: entity.EventTypeOwnerLink}
+	otlp.Inject(ctx, propagation.MapCarrier(headers))
+
+	if err := p.repo.AddOutboxMessage(ctx, &entity.OutboxMessage{
+		Key:       entity.EventTypeOwnerLink + "/" + establishment_id,
+		Payload:   payload,
+		Headers:   headers,
+		CreatedAt: time.Now().UTC(),
+	}); err != nil {
+		return fmt.Errorf("failed to publish owner link of %s: %w", establishment_id, err)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
 	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
 	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"context"
 	"time"
@@ -16,23 +18,36 @@ const (
 type Restaurant interface {
 	CreateRestaurant(ctx context.Context, restaurant *entity.Restaurant) (*entity.Restaurant, error)
 	GetRestaurant(ctx context.Context, restaurant_id string) (*entity.Restaurant, error)
-	ListRestaurants(ctx context.Context, page, limit int64) ([]*entity.Restaurant, uint64, error)
+	ListRestaurants(ctx context.Context, page, limit int64, filter *entity.Filter) ([]*entity.Restaurant, uint64, error)
 	UpdateRestaurant(ctx context.Context, restaurant *entity.Restaurant) (*entity.Restaurant, error)
 	DeleteRestaurant(ctx context.Context, restaurant_id string) error
-	ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Restaurant, int64, error)
-	FindRestaurantsByName(ctx context.Context, name string) ([]*entity.Restaurant, uint64, error)
+	ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Restaurant, int64, error)
+	FindRestaurantsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Restaurant, uint64, error)
 }
 
 type RestaurantService struct {
 	BaseUseCase
 	repo       repository.Restaurant
+	localizer  localizer
+	pricer     pricer
+	guard      guard
+	directory  directory
+	auditor    auditor
+	publisher  publisher
+	transactor repository.Transactor
 	ctxTimeout time.Duration
 }
 
-func NewRestaurantService(ctxTimeout time.Duration, repo repository.Restaurant) RestaurantService {
+func NewRestaurantService(ctxTimeout time.Duration, repo repository.Restaurant, translationRepo repository.Translation, priceRepo repository.Price, rateRepo repository.CurrencyRate, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor, users grpc_service_clients.UserService) RestaurantService {
 	return RestaurantService{
 		ctxTimeout: ctxTimeout,
 		repo:       repo,
+		localizer:  localizer{repo: translationRepo},
+		pricer:     pricer{repo: priceRepo, rateRepo: rateRepo},
+		auditor:    auditor{repo: auditRepo},
+		publisher:  publisher{repo: outboxRepo},
+		directory:  directory{users: users, publisher: publisher{repo: outboxRepo}},
+		transactor: transactor,
 	}
 }
 
@@ -43,9 +58,42 @@ func (r RestaurantService) CreateRestaurant(ctx context.Context, restaurant *ent
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"Create")
 	defer span.End()
 
-	r.beforeRequest(nil, &restaurant.CreatedAt, &restaurant.UpdatedAt, nil)
+	r.beforeCreateEstablishment(ctx, entity.EstablishmentTypeRestaurant, &restaurant.RestaurantId, &restaurant.CreatedAt, &restaurant.UpdatedAt, &restaurant.Location, restaurant.Images)
 
-	return r.repo.CreateRestaurant(ctx, restaurant)
+	if err := restaurant.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.guard.create(ctx, restaurant.OwnerId); err != nil {
+		return nil, err
+	}
+
+	if err := r.directory.owner(ctx, restaurant.OwnerId); err != nil {
+		return nil, err
+	}
+
+	var created *entity.Restaurant
+	if err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = r.repo.CreateRestaurant(ctx, restaurant); err != nil {
+			return err
+		}
+
+		if err := r.auditor.record(ctx, entity.AuditActionCreate, entity.EstablishmentTypeRestaurant, created.RestaurantId, created.RestaurantId, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		if err := r.publisher.publish(ctx, entity.EventCreated, entity.EstablishmentTypeRestaurant, created.RestaurantId, created.RestaurantId, created.Version, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		// the outbox links the owner once the establishment is committed
+		return r.directory.link(ctx, created.OwnerId, created.RestaurantId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (r RestaurantService) GetRestaurant(ctx context.Context, restaurant_id string) (*entity.Restaurant, error) {
@@ -55,17 +103,55 @@ func (r RestaurantService) GetRestaurant(ctx context.Context, restaurant_id stri
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"Get")
 	defer span.End()
 
-	return r.repo.GetRestaurant(ctx, restaurant_id)
+	if err := entity.ValidateId("restaurant_id", restaurant_id); err != nil {
+		return nil, err
+	}
+
+	restaurant, err := r.repo.GetRestaurant(ctx, restaurant_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.localizer.restaurants(ctx, restaurant); err != nil {
+		return nil, err
+	}
+
+	if err := r.pricer.restaurants(ctx, restaurant); err != nil {
+		return nil, err
+	}
+
+	return restaurant, nil
 }
 
-func (r RestaurantService) ListRestaurants(ctx context.Context, offset, limit int64) ([]*entity.Restaurant, uint64, error) {
+func (r RestaurantService) ListRestaurants(ctx context.Context, offset, limit int64, filter *entity.Filter) ([]*entity.Restaurant, uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"List")
 	defer span.End()
 
-	return r.repo.ListRestaurants(ctx, offset, limit)
+	if err := entity.ValidatePage(offset, limit); err != nil {
+		return nil, 0, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	restaurants, count, err := r.repo.ListRestaurants(ctx, offset, limit, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.localizer.restaurants(ctx, restaurants...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.pricer.restaurants(ctx, restaurants...); err != nil {
+		return nil, 0, err
+	}
+
+	return restaurants, count, nil
 }
 
 func (r RestaurantService) UpdateRestaurant(ctx context.Context, restaurant *entity.Restaurant) (*entity.Restaurant, error) {
@@ -75,7 +161,60 @@ func (r RestaurantService) UpdateRestaurant(ctx context.Context, restaurant *ent
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"Update")
 	defer span.End()
 
-	return r.repo.UpdateRestaurant(ctx, restaurant)
+	if err := entity.ValidateId("restaurant_id", restaurant.RestaurantId); err != nil {
+		return nil, err
+	}
+
+	version, ok := app.GetVersionFromContext(ctx)
+	if !ok {
+		return nil, entity.NewErrNoRequiredParameter("version")
+	}
+
+	existing, err := r.repo.GetRestaurant(ctx, restaurant.RestaurantId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.guard.owner(ctx, existing.OwnerId); err != nil {
+		return nil, err
+	}
+
+	before := existing.AuditFields()
+
+	// only the fields named by the mask change
+	if paths := app.GetUpdateMaskFromContext(ctx); paths != nil {
+		if err := existing.ApplyFieldMask(restaurant, paths); err != nil {
+			return nil, err
+		}
+		restaurant = existing
+	}
+	restaurant.OwnerId = existing.OwnerId
+	restaurant.Version = version
+
+	r.beforeRequest(nil, nil, &restaurant.UpdatedAt, nil)
+	linkEstablishment(restaurant.RestaurantId, &restaurant.Location, restaurant.Images)
+
+	if err := restaurant.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.Restaurant
+	if err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = r.repo.UpdateRestaurant(ctx, restaurant); err != nil {
+			return err
+		}
+
+		if err := r.auditor.record(ctx, entity.AuditActionUpdate, entity.EstablishmentTypeRestaurant, updated.RestaurantId, updated.RestaurantId, before, updated.AuditFields()); err != nil {
+			return err
+		}
+
+		return r.publisher.publish(ctx, entity.EventUpdated, entity.EstablishmentTypeRestaurant, updated.RestaurantId, updated.RestaurantId, updated.Version, before, updated.AuditFields())
+	}); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (r RestaurantService) DeleteRestaurant(ctx context.Context, restaurant_id string) error {
@@ -85,25 +224,94 @@ func (r RestaurantService) DeleteRestaurant(ctx context.Context, restaurant_id s
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"Delete")
 	defer span.End()
 
-	return r.repo.DeleteRestaurant(ctx, restaurant_id)
+	if err := entity.ValidateId("restaurant_id", restaurant_id); err != nil {
+		return err
+	}
+
+	version, ok := app.GetVersionFromContext(ctx)
+	if !ok {
+		return entity.NewErrNoRequiredParameter("version")
+	}
+
+	existing, err := r.repo.GetRestaurant(ctx, restaurant_id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.guard.owner(ctx, existing.OwnerId); err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	r.beforeRequest(nil, nil, nil, &deletedAt)
+
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.repo.DeleteRestaurant(ctx, restaurant_id, version, deletedAt); err != nil {
+			return err
+		}
+
+		if err := r.auditor.record(ctx, entity.AuditActionDelete, entity.EstablishmentTypeRestaurant, restaurant_id, restaurant_id, existing.AuditFields(), nil); err != nil {
+			return err
+		}
+
+		return r.publisher.publish(ctx, entity.EventDeleted, entity.EstablishmentTypeRestaurant, restaurant_id, restaurant_id, existing.Version, existing.AuditFields(), nil)
+	})
 }
 
-func (r RestaurantService) ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string) ([]*entity.Restaurant, int64, error) {
+func (r RestaurantService) ListRestaurantsByLocation(ctx context.Context, offset, limit uint64, country, city, state_province string, filter *entity.Filter) ([]*entity.Restaurant, int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"ListL")
 	defer span.End()
 
-	return r.repo.ListRestaurantsByLocation(ctx, offset, limit, country, city, state_province)
+	if err := entity.ValidateLocationPage(offset, limit, country, city, state_province); err != nil {
+		return nil, 0, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	restaurants, count, err := r.repo.ListRestaurantsByLocation(ctx, offset, limit, country, city, state_province, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.localizer.restaurants(ctx, restaurants...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.pricer.restaurants(ctx, restaurants...); err != nil {
+		return nil, 0, err
+	}
+
+	return restaurants, count, nil
 }
 
-func (r RestaurantService) FindRestaurantsByName(ctx context.Context, name string) ([]*entity.Restaurant, uint64, error) {
+func (r RestaurantService) FindRestaurantsByName(ctx context.Context, name string, filter *entity.Filter) ([]*entity.Restaurant, uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, restaurantServiceName, spanNameRestaurant+"List")
 	defer span.End()
 
-	return r.repo.FindRestaurantsByName(ctx, name)
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	restaurants, count, err := r.repo.FindRestaurantsByName(ctx, name, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.localizer.restaurants(ctx, restaurants...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.pricer.restaurants(ctx, restaurants...); err != nil {
+		return nil, 0, err
+	}
+
+	return restaurants, count, nil
 }
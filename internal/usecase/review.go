@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/grpc_service_clients"
 	"Booking/establishment-service-booking/internal/infrastructure/repository"
 	"Booking/establishment-service-booking/internal/pkg/otlp"
 	"context"
@@ -22,14 +23,23 @@ type Review interface {
 type ReviewService struct {
 	BaseUseCase
 	repo       repository.Review
+	guard      guard
+	directory  directory
+	auditor    auditor
+	publisher  publisher
+	transactor repository.Transactor
 	ctxTimeout time.Duration
 }
 
-
-func NewReviewService(ctxTimeout time.Duration, repo repository.Review) ReviewService {
+func NewReviewService(ctxTimeout time.Duration, repo repository.Review, auditRepo repository.Audit, outboxRepo repository.Outbox, transactor repository.Transactor, users grpc_service_clients.UserService) ReviewService {
 	return ReviewService{
 		ctxTimeout: ctxTimeout,
 		repo:       repo,
+		guard:      guard{},
+		auditor:    auditor{repo: auditRepo},
+		publisher:  publisher{repo: outboxRepo},
+		directory:  directory{users: users},
+		transactor: transactor,
 	}
 }
 
@@ -40,7 +50,34 @@ func (r ReviewService) CreateReview(ctx context.Context, review *entity.Review)
 	ctx, span := otlp.Start(ctx, reviewServiceName, spanNameReview+"Create")
 	defer span.End()
 
-	return r.repo.CreateReview(ctx, review)
+	review.ReviewId = r.newId(ctx, "review")
+	r.beforeRequest(nil, &review.CreatedAt, &review.UpdatedAt, nil)
+
+	if err := review.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.guard.user(ctx, review.UserId); err != nil {
+		return nil, err
+	}
+
+	var created *entity.Review
+	if err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = r.repo.CreateReview(ctx, review); err != nil {
+			return err
+		}
+
+		if err := r.auditor.record(ctx, entity.AuditActionCreate, entity.EntityTypeReview, created.ReviewId, created.EstablishmentId, nil, created.AuditFields()); err != nil {
+			return err
+		}
+
+		return r.publisher.publish(ctx, entity.EventCreated, entity.EntityTypeReview, created.ReviewId, created.EstablishmentId, 0, nil, created.AuditFields())
+	}); err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 func (r ReviewService) ListReviews(ctx context.Context, establishment_id string) ([]*entity.Review, uint64, error) {
@@ -50,15 +87,50 @@ func (r ReviewService) ListReviews(ctx context.Context, establishment_id string)
 	ctx, span := otlp.Start(ctx, reviewServiceName, spanNameReview+"List")
 	defer span.End()
 
-	return r.repo.ListReviews(ctx, establishment_id)
+	if err := entity.ValidateId("establishment_id", establishment_id); err != nil {
+		return nil, 0, err
+	}
+
+	reviews, count, err := r.repo.ListReviews(ctx, establishment_id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.directory.reviewers(ctx, reviews...)
+
+	return reviews, count, nil
 }
 
-func (r ReviewService) DeleteReview(ctx context.Context, establishment_id string) error {
+func (r ReviewService) DeleteReview(ctx context.Context, review_id string) error {
 	ctx, cancel := context.WithTimeout(ctx, r.ctxTimeout)
 	defer cancel()
 
 	ctx, span := otlp.Start(ctx, reviewServiceName, spanNameReview+"Delete")
 	defer span.End()
 
-	return r.repo.DeleteReview(ctx, establishment_id)
+	if err := entity.ValidateId("review_id", review_id); err != nil {
+		return err
+	}
+
+	review, err := r.repo.GetReview(ctx, review_id)
+	if err != nil {
+		return err
+	}
+
+	// only the author or an admin removes a review
+	if err := r.guard.user(ctx, review.UserId); err != nil {
+		return err
+	}
+
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.repo.DeleteReview(ctx, review_id); err != nil {
+			return err
+		}
+
+		if err := r.auditor.record(ctx, entity.AuditActionDelete, entity.EntityTypeReview, review.ReviewId, review.EstablishmentId, review.AuditFields(), nil); err != nil {
+			return err
+		}
+
+		return r.publisher.publish(ctx, entity.EventDeleted, entity.EntityTypeReview, review.ReviewId, review.EstablishmentId, 0, review.AuditFields(), nil)
+	})
 }
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"time"
+)
+
+const (
+	statisticsServiceName = "statisticsService"
+	spanNameStatistics    = "statisticsUsecase"
+
+	// reviewsWindow is the period the reviews gauge counts
+	reviewsWindow = 24 * time.Hour
+)
+
+type Statistics interface {
+	// GetStatistics counts the establishments and the recent reviews for the
+	// business metrics
+	GetStatistics(ctx context.Context) (*entity.Statistics, error)
+}
+
+type StatisticsService struct {
+	BaseUseCase
+	repo       repository.Statistics
+	ctxTimeout time.Duration
+}
+
+func NewStatisticsService(ctxTimeout time.Duration, repo repository.Statistics) StatisticsService {
+	return StatisticsService{
+		ctxTimeout: ctxTimeout,
+		repo:       repo,
+	}
+}
+
+func (s StatisticsService) GetStatistics(ctx context.Context) (*entity.Statistics, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, statisticsServiceName, spanNameStatistics+"Get")
+	defer span.End()
+
+	establishments, err := s.repo.CountEstablishments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.repo.CountReviews(ctx, time.Now().UTC().Add(-reviewsWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Statistics{Establishments: establishments, ReviewsLastDay: reviews}, nil
+}
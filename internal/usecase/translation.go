@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/infrastructure/repository"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"Booking/establishment-service-booking/internal/pkg/otlp"
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	translationServiceName = "translationService"
+	spanNameTranslation    = "translationUsecase"
+)
+
+type Translation interface {
+	SetTranslations(ctx context.Context, translations []*entity.Translation) error
+	DeleteTranslation(ctx context.Context, entity_id, field, locale string) error
+	ListTranslations(ctx context.Context, entity_id string) ([]*entity.Translation, error)
+	ListMissingTranslations(ctx context.Context, entity_type, entity_id string) ([]*entity.Translation, error)
+}
+
+type TranslationService struct {
+	BaseUseCase
+	repo       repository.Translation
+	guard      guard
+	ctxTimeout time.Duration
+}
+
+func NewTranslationService(ctxTimeout time.Duration, repo repository.Translation, ownershipRepo repository.Ownership) TranslationService {
+	return TranslationService{
+		ctxTimeout: ctxTimeout,
+		repo:       repo,
+		guard:      guard{repo: ownershipRepo},
+	}
+}
+
+func (t TranslationService) SetTranslations(ctx context.Context, translations []*entity.Translation) error {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, translationServiceName, spanNameTranslation+"Set")
+	defer span.End()
+
+	errValidation := entity.NewErrValidation()
+	for _, translation := range translations {
+		if !entity.IsSupportedLocale(translation.Locale) {
+			errValidation.Errors["locale"] = "unsupported locale " + translation.Locale
+		}
+		if !isTranslationField(translation.EntityType, translation.Field) {
+			errValidation.Errors["field"] = "field " + translation.Field + " of " + translation.EntityType + " can not be translated"
+		}
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid translations")
+		return errValidation
+	}
+
+	checked := make(map[string]bool)
+	for _, translation := range translations {
+		if checked[translation.EntityId] {
+			continue
+		}
+		if err := t.guard.attached(ctx, translation.EntityId); err != nil {
+			return err
+		}
+		checked[translation.EntityId] = true
+	}
+
+	return t.repo.UpsertTranslations(ctx, translations)
+}
+
+func (t TranslationService) DeleteTranslation(ctx context.Context, entity_id, field, locale string) error {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, translationServiceName, spanNameTranslation+"Delete")
+	defer span.End()
+
+	if err := t.guard.attached(ctx, entity_id); err != nil {
+		return err
+	}
+
+	return t.repo.DeleteTranslation(ctx, entity_id, field, locale)
+}
+
+func (t TranslationService) ListTranslations(ctx context.Context, entity_id string) ([]*entity.Translation, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, translationServiceName, spanNameTranslation+"List")
+	defer span.End()
+
+	return t.repo.ListTranslations(ctx, []string{entity_id}, nil)
+}
+
+// ListMissingTranslations returns a translation without value for every
+// translatable field and supported locale the entity has no translation for
+func (t TranslationService) ListMissingTranslations(ctx context.Context, entity_type, entity_id string) ([]*entity.Translation, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.ctxTimeout)
+	defer cancel()
+
+	ctx, span := otlp.Start(ctx, translationServiceName, spanNameTranslation+"ListM")
+	defer span.End()
+
+	existing, err := t.repo.ListTranslations(ctx, []string{entity_id}, entity.SupportedLocales)
+	if err != nil {
+		return nil, err
+	}
+	translations := entity.NewTranslations(existing)
+
+	var missing []*entity.Translation
+	for _, field := range entity.TranslationFields(entity_type) {
+		for _, locale := range entity.SupportedLocales {
+			if translations[entity_id][field][locale] == "" {
+				missing = append(missing, &entity.Translation{
+					EntityId:   entity_id,
+					EntityType: entity_type,
+					Field:      field,
+					Locale:     locale,
+				})
+			}
+		}
+	}
+
+	return missing, nil
+}
+
+func isTranslationField(entity_type, field string) bool {
+	for _, f := range entity.TranslationFields(entity_type) {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// localizer resolves translatable fields in the locale of the request
+type localizer struct {
+	repo repository.Translation
+}
+
+// translations fetches translations of the entities along the fallback chain
+// of the request locale, nil chain means the originals are kept
+func (l localizer) translations(ctx context.Context, entity_ids []string) (entity.Translations, []string, error) {
+	locales := entity.LocaleFallbacks(app.GetLocalizationFromContext(ctx))
+	if l.repo == nil || len(locales) == 0 {
+		return nil, nil, nil
+	}
+
+	translations, err := l.repo.ListTranslations(ctx, entity_ids, locales)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entity.NewTranslations(translations), locales, nil
+}
+
+func imageIds(images []*entity.Image) []string {
+	ids := make([]string, 0, len(images))
+	for _, image := range images {
+		ids = append(ids, image.ImageId)
+	}
+	return ids
+}
+
+func localizeImages(translations entity.Translations, locales []string, images []*entity.Image) {
+	for _, image := range images {
+		image.Caption = translations.Resolve(image.ImageId, entity.TranslationFieldCaption, locales, image.Caption)
+	}
+}
+
+func (l localizer) hotels(ctx context.Context, hotels ...*entity.Hotel) error {
+	var ids []string
+	for _, hotel := range hotels {
+		ids = append(append(ids, hotel.HotelId), imageIds(hotel.Images)...)
+	}
+
+	translations, locales, err := l.translations(ctx, ids)
+	if err != nil || locales == nil {
+		return err
+	}
+
+	for _, hotel := range hotels {
+		hotel.HotelName = translations.Resolve(hotel.HotelId, entity.TranslationFieldName, locales, hotel.HotelName)
+		hotel.Description = translations.Resolve(hotel.HotelId, entity.TranslationFieldDescription, locales, hotel.Description)
+		localizeImages(translations, locales, hotel.Images)
+	}
+	return nil
+}
+
+func (l localizer) restaurants(ctx context.Context, restaurants ...*entity.Restaurant) error {
+	var ids []string
+	for _, restaurant := range restaurants {
+		ids = append(append(ids, restaurant.RestaurantId), imageIds(restaurant.Images)...)
+	}
+
+	translations, locales, err := l.translations(ctx, ids)
+	if err != nil || locales == nil {
+		return err
+	}
+
+	for _, restaurant := range restaurants {
+		restaurant.RestaurantName = translations.Resolve(restaurant.RestaurantId, entity.TranslationFieldName, locales, restaurant.RestaurantName)
+		restaurant.Description = translations.Resolve(restaurant.RestaurantId, entity.TranslationFieldDescription, locales, restaurant.Description)
+		localizeImages(translations, locales, restaurant.Images)
+	}
+	return nil
+}
+
+func (l localizer) attractions(ctx context.Context, attractions ...*entity.Attraction) error {
+	var ids []string
+	for _, attraction := range attractions {
+		ids = append(append(ids, attraction.AttractionId), imageIds(attraction.Images)...)
+	}
+
+	translations, locales, err := l.translations(ctx, ids)
+	if err != nil || locales == nil {
+		return err
+	}
+
+	for _, attraction := range attractions {
+		attraction.AttractionName = translations.Resolve(attraction.AttractionId, entity.TranslationFieldName, locales, attraction.AttractionName)
+		attraction.Description = translations.Resolve(attraction.AttractionId, entity.TranslationFieldDescription, locales, attraction.Description)
+		localizeImages(translations, locales, attraction.Images)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"Booking/establishment-service-booking/internal/entity"
+	"Booking/establishment-service-booking/internal/pkg/app"
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +11,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// idempotencyNamespace scopes ids derived from idempotency keys of clients
+var idempotencyNamespace = uuid.MustParse("5b3f0c3e-6f0e-4d7a-9a57-2f1c7e0d9b41")
+
 type BaseUseCase struct{}
 
 func (u *BaseUseCase) Error(msg string, err error) error {
@@ -34,3 +40,52 @@ func (u *BaseUseCase) beforeRequest(id *string, createdAt *time.Time, updatedAt
 		*deletedAt = time.Now().UTC()
 	}
 }
+
+// newId returns the id of an object about to be created. With an idempotency
+// key in the request the id is derived from the key, the caller and the kind
+// of the object, so a retried request collides with the first one instead of
+// creating a duplicate
+func (u *BaseUseCase) newId(ctx context.Context, kind string) string {
+	key := app.GetIdempotencyKeyFromContext(ctx)
+	if key == "" {
+		return uuid.New().String()
+	}
+
+	var user_id string
+	if caller := app.GetCallerFromContext(ctx); caller != nil {
+		user_id = caller.UserId
+	}
+
+	return uuid.NewSHA1(idempotencyNamespace, []byte(kind+"/"+user_id+"/"+key)).String()
+}
+
+// childId derives the id of an object created together with its parent
+func childId(parent_id, name string) string {
+	return uuid.NewSHA1(uuid.MustParse(parent_id), []byte(name)).String()
+}
+
+// beforeCreateEstablishment assigns the id and timestamps of a new
+// establishment and of the location and images created with it
+func (u *BaseUseCase) beforeCreateEstablishment(ctx context.Context, kind string, id *string, createdAt, updatedAt *time.Time, location *entity.Location, images []*entity.Image) {
+	*id = u.newId(ctx, kind)
+	u.beforeRequest(nil, createdAt, updatedAt, nil)
+
+	location.LocationId = childId(*id, "location")
+	location.CreatedAt, location.UpdatedAt = *createdAt, *updatedAt
+
+	for i, image := range images {
+		image.ImageId = childId(*id, fmt.Sprintf("image/%d", i))
+		image.CreatedAt, image.UpdatedAt = *createdAt, *updatedAt
+	}
+
+	linkEstablishment(*id, location, images)
+}
+
+// linkEstablishment points the location and images to their establishment,
+// whatever the client sent
+func linkEstablishment(id string, location *entity.Location, images []*entity.Image) {
+	location.EstablishmentId = id
+	for _, image := range images {
+		image.EstablishmentId = id
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "establishment_category_table";
+DROP TABLE IF EXISTS "category_table";
@@ -0,0 +1,47 @@
+CREATE TABLE "category_table"(
+    "category_id" UUID PRIMARY KEY NOT NULL,
+    "parent_id" UUID REFERENCES "category_table"("category_id"),
+    "establishment_type" VARCHAR(32) NOT NULL,
+    "code" VARCHAR(255) NOT NULL UNIQUE,
+    "labels" JSONB DEFAULT '{}',
+    "created_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(0)
+);
+
+CREATE TABLE "establishment_category_table"(
+    "establishment_id" UUID NOT NULL,
+    "category_id" UUID NOT NULL REFERENCES "category_table"("category_id"),
+    "created_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("establishment_id", "category_id")
+);
+
+CREATE INDEX "establishment_category_category_id_idx" ON "establishment_category_table"("category_id");
+
+-- root categories
+INSERT INTO "category_table"("category_id", "parent_id", "establishment_type", "code", "labels") VALUES
+    ('a0000000-0000-0000-0000-000000000001', NULL, 'restaurant', 'cuisine', '{"en": "Cuisine", "ru": "Кухня", "uz": "Oshxona"}'),
+    ('a0000000-0000-0000-0000-000000000002', NULL, 'hotel', 'star_class', '{"en": "Star class", "ru": "Звёздность", "uz": "Yulduz toifasi"}'),
+    ('a0000000-0000-0000-0000-000000000003', NULL, 'attraction', 'attraction_type', '{"en": "Attraction type", "ru": "Тип достопримечательности", "uz": "Diqqatga sazovor joy turi"}');
+
+-- cuisines
+INSERT INTO "category_table"("category_id", "parent_id", "establishment_type", "code", "labels") VALUES
+    ('a0000000-0000-0000-0001-000000000001', 'a0000000-0000-0000-0000-000000000001', 'restaurant', 'cuisine_uzbek', '{"en": "Uzbek", "ru": "Узбекская", "uz": "O''zbek"}'),
+    ('a0000000-0000-0000-0001-000000000002', 'a0000000-0000-0000-0000-000000000001', 'restaurant', 'cuisine_european', '{"en": "European", "ru": "Европейская", "uz": "Yevropa"}'),
+    ('a0000000-0000-0000-0001-000000000003', 'a0000000-0000-0000-0000-000000000001', 'restaurant', 'cuisine_asian', '{"en": "Asian", "ru": "Азиатская", "uz": "Osiyo"}'),
+    ('a0000000-0000-0000-0001-000000000004', 'a0000000-0000-0000-0000-000000000001', 'restaurant', 'cuisine_turkish', '{"en": "Turkish", "ru": "Турецкая", "uz": "Turk"}'),
+    ('a0000000-0000-0000-0001-000000000005', 'a0000000-0000-0000-0000-000000000001', 'restaurant', 'cuisine_fast_food', '{"en": "Fast food", "ru": "Фастфуд", "uz": "Tezkor taomlar"}');
+
+-- hotel star classes
+INSERT INTO "category_table"("category_id", "parent_id", "establishment_type", "code", "labels") VALUES
+    ('a0000000-0000-0000-0002-000000000001', 'a0000000-0000-0000-0000-000000000002', 'hotel', 'star_1', '{"en": "1 star", "ru": "1 звезда", "uz": "1 yulduz"}'),
+    ('a0000000-0000-0000-0002-000000000002', 'a0000000-0000-0000-0000-000000000002', 'hotel', 'star_2', '{"en": "2 stars", "ru": "2 звезды", "uz": "2 yulduz"}'),
+    ('a0000000-0000-0000-0002-000000000003', 'a0000000-0000-0000-0000-000000000002', 'hotel', 'star_3', '{"en": "3 stars", "ru": "3 звезды", "uz": "3 yulduz"}'),
+    ('a0000000-0000-0000-0002-000000000004', 'a0000000-0000-0000-0000-000000000002', 'hotel', 'star_4', '{"en": "4 stars", "ru": "4 звезды", "uz": "4 yulduz"}'),
+    ('a0000000-0000-0000-0002-000000000005', 'a0000000-0000-0000-0000-000000000002', 'hotel', 'star_5', '{"en": "5 stars", "ru": "5 звёзд", "uz": "5 yulduz"}');
+
+-- attraction types
+INSERT INTO "category_table"("category_id", "parent_id", "establishment_type", "code", "labels") VALUES
+    ('a0000000-0000-0000-0003-000000000001', 'a0000000-0000-0000-0000-000000000003', 'attraction', 'museum', '{"en": "Museum", "ru": "Музей", "uz": "Muzey"}'),
+    ('a0000000-0000-0000-0003-000000000002', 'a0000000-0000-0000-0000-000000000003', 'attraction', 'park', '{"en": "Park", "ru": "Парк", "uz": "Bog''"}'),
+    ('a0000000-0000-0000-0003-000000000003', 'a0000000-0000-0000-0000-000000000003', 'attraction', 'historic_site', '{"en": "Historic site", "ru": "Историческое место", "uz": "Tarixiy joy"}');
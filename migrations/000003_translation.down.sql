@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "translation_table";
+ALTER TABLE "image_table" DROP COLUMN IF EXISTS "caption";
@@ -0,0 +1,12 @@
+ALTER TABLE "image_table" ADD COLUMN IF NOT EXISTS "caption" TEXT DEFAULT '';
+
+CREATE TABLE "translation_table"(
+    "entity_id" UUID NOT NULL,
+    "entity_type" VARCHAR(32) NOT NULL,
+    "field" VARCHAR(64) NOT NULL,
+    "locale" VARCHAR(16) NOT NULL,
+    "value" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("entity_id", "field", "locale")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "price_table";
+DROP TABLE IF EXISTS "currency_rate_table";
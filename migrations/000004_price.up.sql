@@ -0,0 +1,19 @@
+CREATE TABLE "currency_rate_table"(
+    "currency" CHAR(3) PRIMARY KEY,
+    "rate" NUMERIC(20, 8) NOT NULL CHECK ("rate" > 0),
+    "updated_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE "price_table"(
+    "price_id" UUID PRIMARY KEY,
+    "establishment_id" UUID NOT NULL,
+    "item_type" VARCHAR(32) NOT NULL,
+    "name" VARCHAR(255) NOT NULL DEFAULT '',
+    "amount" BIGINT NOT NULL,
+    "currency" CHAR(3) NOT NULL,
+    "created_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(0)
+);
+
+CREATE INDEX "price_establishment_id_idx" ON "price_table"("establishment_id");
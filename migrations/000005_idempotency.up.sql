@@ -0,0 +1,13 @@
+CREATE TABLE "idempotency_key_table"(
+    "idempotency_key" VARCHAR(255) NOT NULL,
+    "user_id" VARCHAR(255) NOT NULL DEFAULT '',
+    "method" VARCHAR(255) NOT NULL,
+    "request_hash" CHAR(64) NOT NULL,
+    "response_type" VARCHAR(255) NOT NULL DEFAULT '',
+    "response" BYTEA,
+    "created_at" TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMP(0) NOT NULL,
+    PRIMARY KEY ("idempotency_key", "user_id", "method")
+);
+
+CREATE INDEX "idempotency_key_expires_at_idx" ON "idempotency_key_table"("expires_at");
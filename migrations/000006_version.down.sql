@@ -0,0 +1,3 @@
+ALTER TABLE "attraction_table" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "restaurant_table" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "hotel_table" DROP COLUMN IF EXISTS "version";
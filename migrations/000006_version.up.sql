@@ -0,0 +1,3 @@
+ALTER TABLE "hotel_table" ADD COLUMN "version" BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE "restaurant_table" ADD COLUMN "version" BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE "attraction_table" ADD COLUMN "version" BIGINT NOT NULL DEFAULT 1;
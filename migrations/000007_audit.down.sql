@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "audit_event_append_only" ON "audit_event_table";
+DROP FUNCTION IF EXISTS "audit_event_append_only"();
+DROP TABLE IF EXISTS "audit_event_table";
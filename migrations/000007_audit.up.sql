@@ -0,0 +1,26 @@
+CREATE TABLE "audit_event_table"(
+    "event_id" UUID PRIMARY KEY,
+    "establishment_id" UUID NOT NULL,
+    "entity_type" VARCHAR(50) NOT NULL,
+    "entity_id" UUID NOT NULL,
+    "action" VARCHAR(50) NOT NULL,
+    "actor_id" VARCHAR(255) NOT NULL DEFAULT '',
+    "method" VARCHAR(255) NOT NULL DEFAULT '',
+    "changes" JSONB NOT NULL DEFAULT '{}',
+    "created_at" TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX "audit_event_establishment_id_idx" ON "audit_event_table"("establishment_id", "created_at");
+CREATE INDEX "audit_event_actor_id_idx" ON "audit_event_table"("actor_id", "created_at");
+CREATE INDEX "audit_event_created_at_idx" ON "audit_event_table"("created_at");
+
+-- the audit log is append-only
+CREATE FUNCTION "audit_event_append_only"() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_event_append_only"
+    BEFORE UPDATE OR DELETE ON "audit_event_table"
+    FOR EACH ROW EXECUTE FUNCTION "audit_event_append_only"();
@@ -0,0 +1,16 @@
+CREATE TABLE "outbox_table"(
+    "message_id" BIGSERIAL PRIMARY KEY,
+    "topic" VARCHAR(255) NOT NULL,
+    "message_key" VARCHAR(255) NOT NULL,
+    "payload" BYTEA NOT NULL,
+    "headers" JSONB NOT NULL DEFAULT '{}',
+    "attempts" INT NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "next_attempt_at" TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "sent_at" TIMESTAMP(0)
+);
+
+-- the relay looks up the oldest pending message of every key
+CREATE INDEX "outbox_pending_idx" ON "outbox_table"("message_key", "message_id") WHERE "sent_at" IS NULL;
+CREATE INDEX "outbox_sent_at_idx" ON "outbox_table"("sent_at") WHERE "sent_at" IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "review_user_idx";
+DROP INDEX IF EXISTS "favourite_user_idx";
+DROP TABLE IF EXISTS "inbox_table";
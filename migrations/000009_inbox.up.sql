@@ -0,0 +1,8 @@
+CREATE TABLE "inbox_table"(
+    "event_id" VARCHAR(255) PRIMARY KEY NOT NULL,
+    "event_type" VARCHAR(255) NOT NULL DEFAULT '',
+    "processed_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS "favourite_user_idx" ON "favourite_table" ("user_id") WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "review_user_idx" ON "review_table" ("user_id") WHERE "deleted_at" IS NULL;
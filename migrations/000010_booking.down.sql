@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "booking_count_table";
+DROP TABLE IF EXISTS "booking_record_table";
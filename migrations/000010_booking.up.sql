@@ -0,0 +1,17 @@
+CREATE TABLE "booking_record_table"(
+    "booking_id" VARCHAR(255) PRIMARY KEY NOT NULL,
+    "establishment_id" UUID NOT NULL,
+    "booked_on" DATE NOT NULL,
+    "canceled" BOOLEAN NOT NULL DEFAULT FALSE,
+    "updated_at" TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- bookings of an establishment per day, windows sum the days they span
+CREATE TABLE "booking_count_table"(
+    "establishment_id" UUID NOT NULL,
+    "day" DATE NOT NULL,
+    "bookings" BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY ("establishment_id", "day")
+);
+
+CREATE INDEX IF NOT EXISTS "booking_count_day_idx" ON "booking_count_table" ("day");
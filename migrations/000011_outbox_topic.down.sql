@@ -0,0 +1 @@
+ALTER TABLE "outbox_table" ADD COLUMN IF NOT EXISTS "topic" VARCHAR(255) NOT NULL DEFAULT '';
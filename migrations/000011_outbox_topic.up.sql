@@ -0,0 +1,3 @@
+-- the relay sends every message to the topic of the producer, messages were
+-- never stored with one
+ALTER TABLE "outbox_table" DROP COLUMN IF EXISTS "topic";
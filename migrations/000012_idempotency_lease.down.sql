@@ -0,0 +1 @@
+ALTER TABLE "idempotency_key_table" DROP COLUMN IF EXISTS "locked_until";
@@ -0,0 +1,3 @@
+-- a request in progress holds its key until the lease ends, a retry takes
+-- over the key of a request that never completed after that
+ALTER TABLE "idempotency_key_table" ADD COLUMN IF NOT EXISTS "locked_until" TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP;